@@ -4,14 +4,26 @@ import (
 	"fmt"
 	"github.com/khivuksergey/webserver"
 	"github.com/spf13/viper"
+	"time"
 )
 
 type Configuration struct {
-	Server  webserver.ServerConfig
-	Router  webserver.RouterConfig
-	Swagger *webserver.SwaggerConfig
-	Logger  *LoggerConfig
-	DB      DBConfig
+	Server      webserver.ServerConfig
+	Router      webserver.RouterConfig
+	Swagger     *webserver.SwaggerConfig
+	Logger      *LoggerConfig
+	DB          DBConfig
+	Webhook     WebhookConfig
+	ChangeFeed  ChangeFeedConfig
+	Stream      StreamConfig
+	Category    CategoryConfig
+	Idempotency IdempotencyConfig
+	Import      ImportConfig
+	Rules       RulesConfig
+	Budget      BudgetConfig
+	Spending    SpendingConfig
+	Household   HouseholdConfig
+	Audit       AuditConfig
 }
 
 type DBConfig struct {
@@ -19,6 +31,135 @@ type DBConfig struct {
 	TablePrefix      string
 }
 
+type CategoryConfig struct {
+	// RequireIfMatch rejects updates and deletes without If-Match header
+	RequireIfMatch bool
+}
+
+type WebhookConfig struct {
+	Timeout              time.Duration
+	MaxAttempts          int
+	InitialBackoff       time.Duration
+	MaxBackoff           time.Duration
+	DisableAfterFailures int
+	// AllowInsecureTargets lets webhooks use http and target loopback, private and link-local addresses,
+	// it is meant for local development only
+	AllowInsecureTargets bool
+}
+
+var DefaultWebhookConfig = WebhookConfig{
+	Timeout:              10 * time.Second,
+	MaxAttempts:          5,
+	InitialBackoff:       time.Second,
+	MaxBackoff:           time.Minute,
+	DisableAfterFailures: 10,
+}
+
+type ChangeFeedConfig struct {
+	Retention time.Duration
+	PageSize  int
+}
+
+var DefaultChangeFeedConfig = ChangeFeedConfig{
+	Retention: 30 * 24 * time.Hour,
+	PageSize:  500,
+}
+
+type StreamConfig struct {
+	Heartbeat  time.Duration
+	BufferSize int
+}
+
+var DefaultStreamConfig = StreamConfig{
+	Heartbeat:  15 * time.Second,
+	BufferSize: 64,
+}
+
+type IdempotencyConfig struct {
+	// TTL is how long the response is replayed for the same key
+	TTL time.Duration
+	// Storage is "postgres" or "memory", the latter is suitable for a single replica only
+	Storage string
+	// MaxBodySize limits in bytes the body of requests with a key, it is read whole to fingerprint the request
+	MaxBodySize int64
+}
+
+const (
+	IdempotencyStoragePostgres = "postgres"
+	IdempotencyStorageMemory   = "memory"
+)
+
+var DefaultIdempotencyConfig = IdempotencyConfig{
+	TTL:         24 * time.Hour,
+	Storage:     IdempotencyStoragePostgres,
+	MaxBodySize: 10 << 20,
+}
+
+type ImportConfig struct {
+	MaxRows int
+	// MaxBytes limits the size of the uploaded file
+	MaxBytes int64
+}
+
+var DefaultImportConfig = ImportConfig{
+	MaxRows:  10000,
+	MaxBytes: 10 << 20,
+}
+
+type RulesConfig struct {
+	// CacheTTL is how long compiled rules of a user are reused, changes made through
+	// other replicas become visible after it
+	CacheTTL time.Duration
+	// MaxConditions limits the number of nodes in a rule condition tree
+	MaxConditions int
+}
+
+var DefaultRulesConfig = RulesConfig{
+	CacheTTL:      5 * time.Minute,
+	MaxConditions: 50,
+}
+
+type BudgetConfig struct {
+	// Thresholds are percentages of the budget limit, crossing one emits budget.threshold_crossed event
+	Thresholds []int
+}
+
+var DefaultBudgetConfig = BudgetConfig{
+	Thresholds: []int{80, 100},
+}
+
+type SpendingConfig struct {
+	// Storage of the spending totals is "postgres", "memory" or "file", the latter two are
+	// stand-ins for a single replica
+	Storage string
+	// File is the JSON file of the "file" storage
+	File string
+}
+
+const (
+	SpendingStoragePostgres = "postgres"
+	SpendingStorageMemory   = "memory"
+	SpendingStorageFile     = "file"
+)
+
+var DefaultSpendingConfig = SpendingConfig{
+	Storage: SpendingStoragePostgres,
+}
+
+type HouseholdConfig struct {
+	// InvitationTTL is how long an invitation to a household can be accepted
+	InvitationTTL time.Duration
+}
+
+var DefaultHouseholdConfig = HouseholdConfig{
+	InvitationTTL: 7 * 24 * time.Hour,
+}
+
+type AuditConfig struct {
+	// AdminUserIds are JWT subjects allowed to verify and export the audit log
+	AdminUserIds []uint64
+}
+
 type LoggerConfig struct {
 	LogLevel string
 }
@@ -43,7 +184,16 @@ func LoadConfiguration(path string) *Configuration {
 func defaultConfiguration() *Configuration {
 	fmt.Println("loading default configuration...")
 	return &Configuration{
-		Server: webserver.DefaultServerConfig,
-		Router: webserver.DefaultRouterConfig,
+		Server:      webserver.DefaultServerConfig,
+		Router:      webserver.DefaultRouterConfig,
+		Webhook:     DefaultWebhookConfig,
+		ChangeFeed:  DefaultChangeFeedConfig,
+		Stream:      DefaultStreamConfig,
+		Idempotency: DefaultIdempotencyConfig,
+		Import:      DefaultImportConfig,
+		Rules:       DefaultRulesConfig,
+		Budget:      DefaultBudgetConfig,
+		Spending:    DefaultSpendingConfig,
+		Household:   DefaultHouseholdConfig,
 	}
 }
@@ -19,9 +19,136 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/audit/export": {
+            "get": {
+                "description": "Downloads the audit entries created in the range as JSON lines in the order they were recorded. Hash of an entry is the hex SHA-256\nof its JSON with zero id, empty hash and UTC createdAt, prevHash is the hash of the previous entry of the same chain",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Export audit log",
+                "operationId": "export-audit-log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start of the range, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end of the range, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit/verify": {
+            "get": {
+                "description": "Recomputes hashes of the audit entries created in the range and checks each links to the previous one of its chain without gaps.\nEvery user has a chain, calls without a user form chain 0. Without from and to the whole log is checked. Keep lastHash of the chains to detect later removal of the latest entries",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Verify audit log",
+                "operationId": "verify-audit-log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start of the range, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end of the range, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit log verified",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AuditVerification"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/users/{userId}/categories": {
             "get": {
-                "description": "Gets user's categories",
+                "description": "Gets user's categories. The TRANSFER category of moves between own accounts is created along with the first\npersonal category, it is flagged as system so reports can exclude it. With accountId only categories linked to the account\nand categories linked to no account are returned. With asOf the categories are returned as they were at the time",
                 "consumes": [
                     "application/json"
                 ],
@@ -40,11 +167,47 @@ const docTemplate = `{
                         "name": "userId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, can't be combined with accountId",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached categories list",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Categories retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Weak entity tag of the categories list"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
@@ -58,7 +221,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new category with the provided information",
+                "description": "Creates a new category with the provided information.\nGOAL category requires target amount, target date and target currency, other types must not have them.\nWith warnSimilar user's categories with near-duplicate names are returned alongside the created category,\nin strict mode the category isn't created when it has near-duplicates.",
                 "consumes": [
                     "application/json"
                 ],
@@ -78,6 +241,24 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "enum": [
+                            "false",
+                            "true",
+                            "warn",
+                            "strict"
+                        ],
+                        "type": "string",
+                        "description": "Near-duplicate names check, true is the same as warn",
+                        "name": "warnSimilar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "description": "Category object to be created",
                         "name": "category",
@@ -90,9 +271,15 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "201": {
-                        "description": "Category created",
+                        "description": "Category created, with warnSimilar data is model.CategoryCreatedDTO",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Category version"
+                            }
                         }
                     },
                     "400": {
@@ -101,6 +288,33 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.Response"
                         }
                     },
+                    "409": {
+                        "description": "Request with the same Idempotency-Key is in progress or similar categories exist in strict mode",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.CategorySuggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "Request body is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable entity",
                         "schema": {
@@ -110,9 +324,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{userId}/categories/{categoryId}": {
-            "delete": {
-                "description": "Deletes category by the provided category ID",
+        "/users/{userId}/categories/budget-status": {
+            "get": {
+                "description": "Gets limit, spent and remaining amounts of user's budgets in their periods containing the date.\nThe limit includes the amount carried over from the previous periods of rollover budgets.\nSpent amounts of household categories include the spending of all members.",
                 "consumes": [
                     "application/json"
                 ],
@@ -120,10 +334,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Budget"
                 ],
-                "summary": "Delete category",
-                "operationId": "delete-category",
+                "summary": "Get budget status",
+                "operationId": "get-budget-status",
                 "parameters": [
                     {
                         "type": "integer",
@@ -133,20 +347,29 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Category delete request",
-                        "name": "category",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CategoryDeleteDTO"
-                        }
+                        "enum": [
+                            "weekly",
+                            "monthly",
+                            "yearly",
+                            "custom"
+                        ],
+                        "type": "string",
+                        "description": "Budget period",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (2006-01-02 or RFC 3339), now by default",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No content",
+                    "200": {
+                        "description": "Budget status retrieved",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.Response"
                         }
                     },
                     "400": {
@@ -162,9 +385,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates category's properties",
+            }
+        },
+        "/users/{userId}/categories/by-external-id/{externalId}": {
+            "put": {
+                "description": "Creates the category with the external ID or replaces name and description of the existing one. Type can't be changed.\nHousehold categories with the external ID are found too and replacing them requires the editor role",
                 "consumes": [
                     "application/json"
                 ],
@@ -174,8 +399,8 @@ const docTemplate = `{
                 "tags": [
                     "Category"
                 ],
-                "summary": "Update category",
-                "operationId": "update-category",
+                "summary": "Create or replace category by external ID",
+                "operationId": "put-category",
                 "parameters": [
                     {
                         "type": "integer",
@@ -185,12 +410,31 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Category update attributes",
+                        "type": "string",
+                        "description": "Client supplied category ID",
+                        "name": "externalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category version from ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Category object",
                         "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CategoryUpdateDTO"
+                            "$ref": "#/definitions/model.CategoryPutDTO"
                         }
                     }
                 ],
@@ -199,6 +443,24 @@ const docTemplate = `{
                         "description": "Category updated",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Category version"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Category created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Category version"
+                            }
                         }
                     },
                     "400": {
@@ -207,82 +469,4614 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.Response"
                         }
                     },
+                    "409": {
+                        "description": "Request with the same Idempotency-Key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Category version doesn't match",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable entity",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.CategoryType": {
-            "type": "string",
-            "enum": [
-                "INCOME",
-                "EXPENSE"
-            ],
-            "x-enum-varnames": [
-                "Income",
-                "Expense"
-            ]
         },
-        "model.CategoryCreateDTO": {
-            "type": "object",
-            "required": [
-                "name",
-                "type"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "type": {
-                    "enum": [
-                        "INCOME",
-                        "EXPENSE"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.CategoryType"
+        "/users/{userId}/categories/changes": {
+            "get": {
+                "description": "Gets created, updated and deleted categories since the opaque sync token. Without token returns all categories.\nKeep requesting with the returned token while hasMore is true. When fullResyncRequired is true drop the local cache and request again without token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Get category changes",
+                "operationId": "get-category-changes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sync token from the previous response",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category changes retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CategoryChangesDTO"
+                                        }
+                                    }
+                                }
+                            ]
                         }
-                    ]
-                },
-                "userId": {
-                    "type": "integer"
-                }
-            }
-        },
-        "model.CategoryDeleteDTO": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "userId": {
-                    "type": "integer"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
                 }
             }
         },
-        "model.CategoryUpdateDTO": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "userId": {
-                    "type": "integer"
+        "/users/{userId}/categories/classify": {
+            "post": {
+                "description": "Evaluates user's rules against every transaction and returns the category and the matched rule id in the order of the batch.\nBoth are null when no rule matched. Up to 1000 transactions per request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Classify transactions",
+                "operationId": "classify-transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transactions to classify",
+                        "name": "transactions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ClassifyDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transactions classified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/export": {
+            "get": {
+                "description": "Downloads all user's categories except the system ones as CSV or JSON array. Columns: id, externalId, name, type, description, targetAmount, targetDate, targetCurrency, createdAt, updatedAt",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Export categories",
+                "operationId": "export-categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Categories file",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CategoryRecord"
+                            }
+                        },
+                        "headers": {
+                            "X-Export-Version": {
+                                "type": "string",
+                                "description": "Version of the export schema"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/import": {
+            "post": {
+                "description": "Imports categories from CSV with name, type, description, externalId and, for goals, targetAmount, targetDate and targetCurrency columns or from JSON array of such objects, export files are accepted.\nQIF files (!Type:Cat blocks), YNAB budget or register CSV and CoinKeeper transactions CSV exports are accepted too. The format is detected from the file content.\nThe file is sent as the request body or as the file field of a multipart form. Every category is validated, when any is invalid nothing is imported.\nConflict policy tells what to do when the name is taken: skip the category, rename it with a numeric suffix or overwrite the description.",
+                "consumes": [
+                    "text/csv",
+                    "application/json",
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Import categories",
+                "operationId": "import-categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "qif",
+                            "ynab",
+                            "coinkeeper"
+                        ],
+                        "type": "string",
+                        "description": "File format, detected by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report the changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "rename",
+                            "overwrite"
+                        ],
+                        "type": "string",
+                        "default": "skip",
+                        "description": "Conflict policy",
+                        "name": "conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Categories file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Categories imported",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CategoryImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid categories",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CategoryImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/resolve-mcc": {
+            "post": {
+                "description": "Returns user's category for the merchant category code of a bank transaction.\nThe narrowest matching user's mapping wins. Without a mapping the category named after the code's default template is returned.\nCategory is null when nothing matches, template then suggests the category to create.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Resolve MCC to category",
+                "operationId": "resolve-mcc",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merchant category code",
+                        "name": "mcc",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MccResolveDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MCC resolved",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/spending": {
+            "post": {
+                "description": "Saves amounts spent in user's categories over the periods, pushed by the transaction service.\nA total replaces the previous one of the same category, currency and period.\nEmits budget.threshold_crossed event when a budget reaches one of the configured percentages,\nfor household categories to every member.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budget"
+                ],
+                "summary": "Record spending",
+                "operationId": "record-spending",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Spending totals",
+                        "name": "spending",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SpendingRecordDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/stream": {
+            "get": {
+                "description": "Streams category.created, category.updated and category.deleted events with periodic heartbeat comments.\nEvent id is a sync token: reconnect with Last-Event-ID header (or lastEventId query param) to receive missed changes as category.changes events.\nThe resync event means the client must reload all categories.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Stream category changes",
+                "operationId": "stream-category-events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last received event",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last received event",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/suggest": {
+            "get": {
+                "description": "Ranks user's categories and category templates by normalized edit distance and word overlap with the query.\nNames starting with the query rank high. Templates the user already has are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Suggest categories",
+                "operationId": "suggest-categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category name being typed",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of suggestions, up to 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Categories suggested",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.CategorySuggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/tax-codes": {
+            "get": {
+                "description": "Gets tax form lines categories can be mapped to. Jurisdictions: US (Form 1040 Schedule C), GB (SA103F)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Get tax codes",
+                "operationId": "get-tax-codes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jurisdiction, all when empty",
+                        "name": "jurisdiction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax codes retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/reference.TaxCode"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/tax-summary": {
+            "get": {
+                "description": "Groups user's categories by the tax form lines they are mapped to, in form line order.\nDeductible categories without a tax code are grouped last under an empty code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Get tax summary",
+                "operationId": "get-tax-summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jurisdiction, all when empty",
+                        "name": "jurisdiction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax summary retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.TaxSummaryLine"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}": {
+            "get": {
+                "description": "Gets user's category by ID. Answers 304 when If-None-Match or If-Modified-Since show the cached category is fresh",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Get category",
+                "operationId": "get-category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached category",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached category",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Category version"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the last category update"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes category by the provided category ID. System categories can't be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Delete category",
+                "operationId": "delete-category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category version from ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Category delete request",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CategoryDeleteDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the same Idempotency-Key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Category version doesn't match",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates category's properties. Besides JSON with the changed fields accepts\nJSON Merge Patch (application/merge-patch+json) and JSON Patch (application/json-patch+json) applied to the category",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Update category",
+                "operationId": "update-category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category version from ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Category update attributes",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CategoryUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Category version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the same Idempotency-Key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Category version doesn't match",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/accounts": {
+            "get": {
+                "description": "Gets ids of the accounts the category is shown for, the category is shown for all accounts when there are none",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get category accounts",
+                "operationId": "get-category-accounts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category accounts retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces ids of the accounts the category is shown for. Account ids are opaque ids of the wallet service,\nan empty list shows the category for all accounts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Set category accounts",
+                "operationId": "set-category-accounts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account ids",
+                        "name": "accounts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CategoryAccountsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category accounts set",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/accounts/{accountId}": {
+            "delete": {
+                "description": "Stops showing the category for the account, the category is shown for all accounts when it was the last one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Unlink category account",
+                "operationId": "delete-category-account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/budgets": {
+            "get": {
+                "description": "Gets spending limits of user's category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budget"
+                ],
+                "summary": "Get category budgets",
+                "operationId": "get-budgets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Budgets retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a spending limit to user's expense category. Amounts are decimal strings.\nWeekly, monthly and yearly budgets repeat from the start date, one per category, period and currency. Custom budget runs from start to end date.\nRollover carries the unspent amount (surplus) or the unspent amount and the overspending (full) to the next period, up to the rollover limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budget"
+                ],
+                "summary": "Create a new budget",
+                "operationId": "create-budget",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Budget object to be created",
+                        "name": "budget",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BudgetCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Budget created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/budgets/{budgetId}": {
+            "delete": {
+                "description": "Deletes budget by the provided budget ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budget"
+                ],
+                "summary": "Delete budget",
+                "operationId": "delete-budget",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Budget ID",
+                        "name": "budgetId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates budget's properties",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budget"
+                ],
+                "summary": "Update budget",
+                "operationId": "update-budget",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Budget ID",
+                        "name": "budgetId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Budget update attributes",
+                        "name": "budget",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BudgetUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Budget updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/history": {
+            "get": {
+                "description": "Gets creation, updates, reverts and deletion of the category with the user who made them\nand the category before and after each change, the latest first. History of a deleted category is available too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Get category history",
+                "operationId": "get-category-history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category history retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.CategoryHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/progress": {
+            "get": {
+                "description": "Gets the amount saved towards the target of user's goal category.\nContributions are spending totals of the category in the target currency, pushed to POST /users/{userId}/categories/spending",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Goal"
+                ],
+                "summary": "Get goal progress",
+                "operationId": "get-goal-progress",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Goal category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goal progress retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.GoalProgress"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/revert": {
+            "post": {
+                "description": "Restores name, description, external id and goal target of the category from the version.\nThe revert is recorded as a new version, the history after the version is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Revert category",
+                "operationId": "revert-category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category version from ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Version to roll back to",
+                        "name": "revert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CategoryRevertDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category reverted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Category version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Category version not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Category version doesn't match",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/tags": {
+            "get": {
+                "description": "Gets user's tags assigned to the category. Members of a household see their own tags of its categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Get category tags",
+                "operationId": "get-category-tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category tags retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Tag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/tags/{tagId}": {
+            "put": {
+                "description": "Assigns user's tag to the category, assigning it again does nothing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Assign tag",
+                "operationId": "assign-tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes user's tag from the category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Unassign tag",
+                "operationId": "unassign-tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/categories/{categoryId}/tax": {
+            "get": {
+                "description": "Gets deductible flag, deductible percentage and tax code of user's category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Get category tax attributes",
+                "operationId": "get-category-tax",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax attributes retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CategoryTax"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces tax attributes of user's income or expense category. Only expense categories can be deductible,\ndeductible percentage defaults to 100. The tax code must be a code of the jurisdiction for the category type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Set category tax attributes",
+                "operationId": "set-category-tax",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax attributes",
+                        "name": "tax",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CategoryTaxDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax attributes set",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CategoryTax"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Makes user's category not deductible and not mapped to a tax code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Delete category tax attributes",
+                "operationId": "delete-category-tax",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/households": {
+            "get": {
+                "description": "Gets households the user is a member of",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Get households",
+                "operationId": "get-households",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Households retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Household"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a household with the user as its owner. Categories created with the household id are shared with its members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Create household",
+                "operationId": "create-household",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Household object",
+                        "name": "household",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Household created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Household"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/households/invitations/accept": {
+            "post": {
+                "description": "Adds the user to the household with the role of the invitation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Accept household invitation",
+                "operationId": "accept-household-invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation token",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdInvitationAcceptDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.HouseholdMember"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/households/{householdId}/invitations": {
+            "post": {
+                "description": "The owner invites a viewer or an editor. The returned token is accepted by the invitee, anyone with\nthe token can accept it unless inviteeId is set. The invitation expires after the configured time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Invite to household",
+                "operationId": "create-household-invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "householdId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation object",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdInvitationCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Invitation created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.HouseholdInvitation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/households/{householdId}/members": {
+            "get": {
+                "description": "Gets members of the household with their roles, available to the members only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Get household members",
+                "operationId": "get-household-members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "householdId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.HouseholdMember"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/households/{householdId}/members/{memberId}": {
+            "delete": {
+                "description": "The owner removes other members, members leave the household by removing themselves. The owner can't leave",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Household"
+                ],
+                "summary": "Remove household member",
+                "operationId": "remove-household-member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "householdId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the member",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/mcc-mappings": {
+            "get": {
+                "description": "Gets user's mappings of merchant category codes to categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCC"
+                ],
+                "summary": "Get user's MCC mappings",
+                "operationId": "get-mcc-mappings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MCC mappings retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Maps a merchant category code (\"5411\") or an inclusive range of codes (\"3000-3299\") to user's category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCC"
+                ],
+                "summary": "Create a new MCC mapping",
+                "operationId": "create-mcc-mapping",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "MCC mapping to be created",
+                        "name": "mapping",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MccMappingCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "MCC mapping created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/mcc-mappings/{mappingId}": {
+            "delete": {
+                "description": "Deletes MCC mapping by the provided mapping ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCC"
+                ],
+                "summary": "Delete MCC mapping",
+                "operationId": "delete-mcc-mapping",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "MCC mapping ID",
+                        "name": "mappingId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the codes or the category of the MCC mapping",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCC"
+                ],
+                "summary": "Update MCC mapping",
+                "operationId": "update-mcc-mapping",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "MCC mapping ID",
+                        "name": "mappingId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "MCC mapping update attributes",
+                        "name": "mapping",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MccMappingUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MCC mapping updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/rules": {
+            "get": {
+                "description": "Gets user's categorization rules in evaluation order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rule"
+                ],
+                "summary": "Get user's rules",
+                "operationId": "get-rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rules retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a rule assigning the category to transactions matching the condition.\nCondition operators are and, or (with conditions), contains, equals, prefix, regex (with value, case-insensitive unless caseSensitive) and amount (absolute amount from min inclusive to max exclusive, decimal strings or numbers).\nEnabled rules are evaluated in ascending priority and the first match wins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rule"
+                ],
+                "summary": "Create a new rule",
+                "operationId": "create-rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule object to be created",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RuleCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Rule created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/rules/{ruleId}": {
+            "delete": {
+                "description": "Deletes rule by the provided rule ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rule"
+                ],
+                "summary": "Delete rule",
+                "operationId": "delete-rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "ruleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates rule's properties, the condition is replaced as a whole",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rule"
+                ],
+                "summary": "Update rule",
+                "operationId": "update-rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "ruleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule update attributes",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RuleUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rule updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/smart-groups": {
+            "get": {
+                "description": "Gets user's saved category filters ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartGroup"
+                ],
+                "summary": "Get user's smart groups",
+                "operationId": "get-smart-groups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Smart groups retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.SmartGroup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves a filter of user's categories resolved on every read. Filter operators are and, or, not (with filters),\ntag (with tagId of user's tag) and type (with category type), e.g. all essential expense categories:\n{\"op\":\"and\",\"filters\":[{\"op\":\"tag\",\"tagId\":1},{\"op\":\"type\",\"type\":\"EXPENSE\"}]}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartGroup"
+                ],
+                "summary": "Create a new smart group",
+                "operationId": "create-smart-group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Smart group object",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SmartGroupCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Smart group created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.SmartGroup"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/smart-groups/{groupId}": {
+            "delete": {
+                "description": "Deletes smart group by the provided ID, categories and tags are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartGroup"
+                ],
+                "summary": "Delete smart group",
+                "operationId": "delete-smart-group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Smart group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates smart group's name or filter, the filter is replaced as a whole",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartGroup"
+                ],
+                "summary": "Update smart group",
+                "operationId": "update-smart-group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Smart group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Smart group update attributes",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SmartGroupUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Smart group updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.SmartGroup"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/smart-groups/{groupId}/categories": {
+            "get": {
+                "description": "Gets categories the user can access matching the smart group filter at the moment of the request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SmartGroup"
+                ],
+                "summary": "Get smart group categories",
+                "operationId": "get-smart-group-categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Smart group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Smart group resolved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Category"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/tags": {
+            "get": {
+                "description": "Gets user's tags ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Get user's tags",
+                "operationId": "get-tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Tag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a label like \"essential\" or \"subscription\" to assign to any of user's categories. Names are unique per user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Create a new tag",
+                "operationId": "create-tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag object",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tag created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/tags/{tagId}": {
+            "delete": {
+                "description": "Deletes the tag and its assignments to categories, smart groups filtering by it no longer match it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Delete tag",
+                "operationId": "delete-tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Renames the tag, its assignments and smart groups filtering by it are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Update tag",
+                "operationId": "update-tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag update attributes",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/webhooks": {
+            "get": {
+                "description": "Gets user's webhook subscriptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get user's webhooks",
+                "operationId": "get-webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhooks retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribes the URL to category events. Payloads are signed with HMAC-SHA256 of the secret in X-Portmonetka-Signature header. The URL must be https and must not target loopback, private or link-local addresses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Create a new webhook",
+                "operationId": "create-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook object to be created",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/webhooks/{webhookId}": {
+            "delete": {
+                "description": "Deletes webhook by the provided webhook ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Delete webhook",
+                "operationId": "delete-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates webhook's properties. Enabling the webhook resets its failures counter. A new URL must be https and must not target loopback, private or link-local addresses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Update webhook",
+                "operationId": "update-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook update attributes",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "description": "Gets the latest delivery attempts of the webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get webhook deliveries",
+                "operationId": "get-webhook-deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorized user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deliveries retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "description": "Chain is UserId, 0 for calls without one",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "prevHash": {
+                    "type": "string"
+                },
+                "requestUuid": {
+                    "type": "string"
+                },
+                "route": {
+                    "description": "Route is the matched route pattern, Path is the requested path",
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "description": "UserId is the authenticated caller, or the subject of a valid token that was refused, unset without a valid token",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.BudgetPeriod": {
+            "type": "string",
+            "enum": [
+                "weekly",
+                "monthly",
+                "yearly",
+                "custom"
+            ],
+            "x-enum-varnames": [
+                "Weekly",
+                "Monthly",
+                "Yearly",
+                "Custom"
+            ]
+        },
+        "entity.BudgetRollover": {
+            "type": "string",
+            "enum": [
+                "none",
+                "surplus",
+                "full"
+            ],
+            "x-enum-varnames": [
+                "RolloverNone",
+                "RolloverSurplus",
+                "RolloverFull"
+            ]
+        },
+        "entity.Category": {
+            "type": "object",
+            "required": [
+                "name",
+                "type",
+                "userId"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "externalId": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "householdId": {
+                    "description": "HouseholdId is set for categories shared with the household members, UserId is then the author",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 3
+                },
+                "system": {
+                    "description": "System category is managed by the service, it can't be deleted",
+                    "type": "boolean"
+                },
+                "targetAmount": {
+                    "description": "TargetAmount, TargetDate and TargetCurrency are set for goal categories only",
+                    "type": "string"
+                },
+                "targetCurrency": {
+                    "type": "string"
+                },
+                "targetDate": {
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "INCOME",
+                        "EXPENSE",
+                        "GOAL",
+                        "TRANSFER"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.CategoryType"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.CategoryHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/entity.HistoryAction"
+                },
+                "actorId": {
+                    "type": "integer"
+                },
+                "after": {
+                    "$ref": "#/definitions/entity.Category"
+                },
+                "before": {
+                    "$ref": "#/definitions/entity.Category"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version is the category version the change produced",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.CategoryTax": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "deductible": {
+                    "type": "boolean"
+                },
+                "deductiblePercentage": {
+                    "description": "DeductiblePercentage is the deductible share of the category spending, zero when not deductible",
+                    "type": "string"
+                },
+                "jurisdiction": {
+                    "type": "string"
+                },
+                "taxCode": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "description": "UserId is the user who set the attributes, they are shared with everyone who can access the category",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.CategoryType": {
+            "type": "string",
+            "enum": [
+                "INCOME",
+                "EXPENSE",
+                "GOAL",
+                "TRANSFER"
+            ],
+            "x-enum-varnames": [
+                "Income",
+                "Expense",
+                "Goal",
+                "Transfer"
+            ]
+        },
+        "entity.GroupFilter": {
+            "type": "object",
+            "properties": {
+                "filters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GroupFilter"
+                    }
+                },
+                "op": {
+                    "$ref": "#/definitions/entity.GroupFilterOperator"
+                },
+                "tagId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.CategoryType"
+                }
+            }
+        },
+        "entity.GroupFilterOperator": {
+            "type": "string",
+            "enum": [
+                "and",
+                "or",
+                "not",
+                "tag",
+                "type"
+            ],
+            "x-enum-varnames": [
+                "FilterAnd",
+                "FilterOr",
+                "FilterNot",
+                "FilterTag",
+                "FilterType"
+            ]
+        },
+        "entity.HistoryAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete",
+                "revert"
+            ],
+            "x-enum-varnames": [
+                "HistoryCreate",
+                "HistoryUpdate",
+                "HistoryDelete",
+                "HistoryRevert"
+            ]
+        },
+        "entity.Household": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.HouseholdInvitation": {
+            "type": "object",
+            "properties": {
+                "acceptedAt": {
+                    "type": "string"
+                },
+                "acceptedBy": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invitedBy": {
+                    "type": "integer"
+                },
+                "role": {
+                    "$ref": "#/definitions/entity.HouseholdRole"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.HouseholdMember": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "integer"
+                },
+                "role": {
+                    "$ref": "#/definitions/entity.HouseholdRole"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.HouseholdRole": {
+            "type": "string",
+            "enum": [
+                "viewer",
+                "editor",
+                "owner"
+            ],
+            "x-enum-varnames": [
+                "RoleViewer",
+                "RoleEditor",
+                "RoleOwner"
+            ]
+        },
+        "entity.RuleCondition": {
+            "type": "object",
+            "properties": {
+                "caseSensitive": {
+                    "type": "boolean"
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.RuleCondition"
+                    }
+                },
+                "max": {
+                    "type": "string"
+                },
+                "min": {
+                    "type": "string"
+                },
+                "op": {
+                    "$ref": "#/definitions/entity.RuleOperator"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RuleOperator": {
+            "type": "string",
+            "enum": [
+                "and",
+                "or",
+                "contains",
+                "regex",
+                "equals",
+                "prefix",
+                "amount"
+            ],
+            "x-enum-varnames": [
+                "RuleAnd",
+                "RuleOr",
+                "RuleContains",
+                "RuleRegex",
+                "RuleEquals",
+                "RulePrefix",
+                "RuleAmount"
+            ]
+        },
+        "entity.SmartGroup": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "filter": {
+                    "$ref": "#/definitions/entity.GroupFilter"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Tag": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuditChainHead": {
+            "type": "object",
+            "properties": {
+                "firstSeq": {
+                    "type": "integer"
+                },
+                "lastHash": {
+                    "type": "string"
+                },
+                "lastSeq": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuditVerification": {
+            "type": "object",
+            "properties": {
+                "brokenChain": {
+                    "description": "BrokenChain and BrokenSeq are the first entry failing the check, Reason tells why",
+                    "type": "integer"
+                },
+                "brokenSeq": {
+                    "type": "integer"
+                },
+                "chains": {
+                    "description": "Chains are the verified chains of the range by chain id, the user id or 0 for calls without one",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.AuditChainHead"
+                    }
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.BudgetCreateDTO": {
+            "type": "object",
+            "required": [
+                "currency",
+                "period"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "period": {
+                    "enum": [
+                        "weekly",
+                        "monthly",
+                        "yearly",
+                        "custom"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BudgetPeriod"
+                        }
+                    ]
+                },
+                "rollover": {
+                    "enum": [
+                        "none",
+                        "surplus",
+                        "full"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BudgetRollover"
+                        }
+                    ]
+                },
+                "rolloverLimit": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "StartDate defaults to the start of the current week, month or year, custom period requires it",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BudgetUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "period": {
+                    "enum": [
+                        "weekly",
+                        "monthly",
+                        "yearly",
+                        "custom"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BudgetPeriod"
+                        }
+                    ]
+                },
+                "rollover": {
+                    "enum": [
+                        "none",
+                        "surplus",
+                        "full"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BudgetRollover"
+                        }
+                    ]
+                },
+                "rolloverLimit": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategoryAccountsDTO": {
+            "type": "object",
+            "properties": {
+                "accountIds": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategoryChangesDTO": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Category"
+                    }
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CategoryTombstone"
+                    }
+                },
+                "fullResyncRequired": {
+                    "type": "boolean"
+                },
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Category"
+                    }
+                }
+            }
+        },
+        "model.CategoryCreateDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "householdId": {
+                    "description": "HouseholdId shares the category with the household, the user must be its editor",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targetAmount": {
+                    "description": "TargetAmount, TargetDate and TargetCurrency are required for GOAL type and not allowed for others",
+                    "type": "string"
+                },
+                "targetCurrency": {
+                    "type": "string"
+                },
+                "targetDate": {
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "INCOME",
+                        "EXPENSE",
+                        "GOAL"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.CategoryType"
+                        }
+                    ]
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategoryDeleteDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategoryImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CategoryImportRow"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategoryImportRow": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Row is the number of the category in the file starting from 1",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.ImportStatus"
+                }
+            }
+        },
+        "model.CategoryPutDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 3
+                },
+                "targetAmount": {
+                    "description": "TargetAmount, TargetDate and TargetCurrency are required for GOAL type and not allowed for others",
+                    "type": "string"
+                },
+                "targetCurrency": {
+                    "type": "string"
+                },
+                "targetDate": {
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "INCOME",
+                        "EXPENSE",
+                        "GOAL"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.CategoryType"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.CategoryRecord": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targetAmount": {
+                    "description": "TargetAmount, TargetDate and TargetCurrency are set for goal categories only",
+                    "type": "string"
+                },
+                "targetCurrency": {
+                    "type": "string"
+                },
+                "targetDate": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.CategoryType"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CategoryRevertDTO": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "version": {
+                    "description": "Version is the version to roll back to",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategorySuggestion": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Score is the similarity from 0 to 1",
+                    "type": "number"
+                },
+                "source": {
+                    "$ref": "#/definitions/model.SuggestionSource"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.CategoryType"
+                }
+            }
+        },
+        "model.CategoryTaxDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "deductible": {
+                    "description": "Deductible is allowed for expense categories only",
+                    "type": "boolean"
+                },
+                "deductiblePercentage": {
+                    "description": "DeductiblePercentage defaults to 100 for deductible categories",
+                    "type": "string"
+                },
+                "jurisdiction": {
+                    "description": "Jurisdiction and TaxCode are set together, the code must match the category type",
+                    "type": "string"
+                },
+                "taxCode": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategoryTombstone": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategoryUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targetAmount": {
+                    "description": "TargetAmount, TargetDate and TargetCurrency can be changed for goal categories",
+                    "type": "string"
+                },
+                "targetCurrency": {
+                    "type": "string"
+                },
+                "targetDate": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ClassifyDTO": {
+            "type": "object",
+            "required": [
+                "transactions"
+            ],
+            "properties": {
+                "transactions": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.TransactionDescriptor"
+                    }
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GoalProgress": {
+            "type": "object",
+            "properties": {
+                "achieved": {
+                    "type": "boolean"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "daysLeft": {
+                    "description": "DaysLeft is the number of days until the target date, zero when it has passed",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "string"
+                },
+                "saved": {
+                    "type": "string"
+                },
+                "targetAmount": {
+                    "type": "string"
+                },
+                "targetCurrency": {
+                    "type": "string"
+                },
+                "targetDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HouseholdCreateDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HouseholdInvitationAcceptDTO": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HouseholdInvitationCreateDTO": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "householdId": {
+                    "type": "integer"
+                },
+                "inviteeId": {
+                    "type": "integer"
+                },
+                "role": {
+                    "enum": [
+                        "viewer",
+                        "editor"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.HouseholdRole"
+                        }
+                    ]
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "renamed",
+                "updated",
+                "skipped",
+                "invalid"
+            ],
+            "x-enum-varnames": [
+                "ImportCreated",
+                "ImportRenamed",
+                "ImportUpdated",
+                "ImportSkipped",
+                "ImportInvalid"
+            ]
+        },
+        "model.MccMappingCreateDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "mcc"
+            ],
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "mcc": {
+                    "description": "Mcc is a single code \"5411\" or an inclusive range \"3000-3299\"",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MccMappingUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mcc": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MccResolveDTO": {
+            "type": "object",
+            "required": [
+                "mcc"
+            ],
+            "properties": {
+                "mcc": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
@@ -297,6 +5091,331 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "model.RuleCreateDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "name"
+            ],
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "condition": {
+                    "$ref": "#/definitions/entity.RuleCondition"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RuleUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "condition": {
+                    "$ref": "#/definitions/entity.RuleCondition"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 1
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SmartGroupCreateDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/entity.GroupFilter"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SmartGroupUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/entity.GroupFilter"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 1
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SpendingRecordDTO": {
+            "type": "object",
+            "required": [
+                "totals"
+            ],
+            "properties": {
+                "totals": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.SpendingTotalDTO"
+                    }
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SpendingTotalDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "currency",
+                "periodEnd",
+                "periodStart"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "periodEnd": {
+                    "type": "string"
+                },
+                "periodStart": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SuggestionSource": {
+            "type": "string",
+            "enum": [
+                "category",
+                "template"
+            ],
+            "x-enum-varnames": [
+                "SuggestionCategory",
+                "SuggestionTemplate"
+            ]
+        },
+        "model.TagCreateDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TagUpdateDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TaxSummaryCategory": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "deductible": {
+                    "type": "boolean"
+                },
+                "deductiblePercentage": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.CategoryType"
+                }
+            }
+        },
+        "model.TaxSummaryLine": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TaxSummaryCategory"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "form": {
+                    "type": "string"
+                },
+                "jurisdiction": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "string"
+                },
+                "taxCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TransactionDescriptor": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "id": {
+                    "description": "Id is an optional client reference echoed back in the classification",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.WebhookCreateDTO": {
+            "type": "object",
+            "required": [
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WebhookUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "reference.TaxCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "form": {
+                    "type": "string"
+                },
+                "jurisdiction": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.CategoryType"
+                }
+            }
         }
     }
 }`
@@ -7,19 +7,144 @@ import (
 
 var (
 	CategoryAlreadyExists          = errors.New("category with this name already exists")
+	CategoryExternalIdExists       = errors.New("category with this external id already exists")
 	CategoryDoesntExist            = errors.New("category with this id doesn't exists")
 	CategoryDoesntBelongToUser     = errors.New("category with this id doesn't belong to user")
 	AtLeastOneFieldIsRequired      = errors.New("at least one field for updating category is required")
 	CategoryNameLengthError        = errors.New("category name must be from 3 to 128 symbols long")
 	CategoryDescriptionLengthError = errors.New("category description must be less than 256 symbols long")
+	WebhookDoesntExist             = errors.New("webhook with this id doesn't exists")
+	WebhookDoesntBelongToUser      = errors.New("webhook with this id doesn't belong to user")
+	InsecureWebhookUrl             = errors.New("webhook url must be https and not target loopback, private or link-local addresses")
+	InvalidSyncToken               = errors.New("invalid sync token")
+	CategoryVersionMismatch        = errors.New("category was modified, version doesn't match")
+	IfMatchRequired                = errors.New("If-Match header with category version is required")
+	InvalidIfMatch                 = errors.New("invalid If-Match header")
+	InvalidExportFormat            = errors.New("export format must be csv or json")
+	InvalidImportFile              = errors.New("import file must be categories CSV or JSON, QIF, YNAB or CoinKeeper CSV export")
+	TooManyImportRows              = errors.New("import file has too many categories")
+	InvalidConflictPolicy          = errors.New("conflict policy must be skip, rename or overwrite")
+	ImportHasInvalidRows           = errors.New("import file has invalid categories, nothing was imported")
+	InvalidPatch                   = errors.New("invalid patch document")
+	ReadOnlyFieldChanged           = errors.New("patch changes read-only field")
+	InvalidIdempotencyKey          = errors.New("idempotency key must be from 1 to 255 symbols long")
+	IdempotencyKeyReused           = errors.New("idempotency key was already used with a different request")
+	IdempotencyKeyInProgress       = errors.New("request with this idempotency key is still in progress")
+	InvalidMcc                     = errors.New("mcc must be a four digit code or a range of codes like 3000-3299")
+	MccMappingExists               = errors.New("mapping of this mcc range already exists")
+	MccMappingDoesntExist          = errors.New("mcc mapping with this id doesn't exists")
+	MccMappingDoesntBelongToUser   = errors.New("mcc mapping with this id doesn't belong to user")
+	InvalidRuleCondition           = errors.New("invalid rule condition")
+	RuleDoesntExist                = errors.New("rule with this id doesn't exists")
+	RuleDoesntBelongToUser         = errors.New("rule with this id doesn't belong to user")
+	InvalidSimilarMode             = errors.New("warnSimilar must be true, false, warn or strict")
+	SimilarCategoryExists          = errors.New("category with a similar name already exists")
+	BudgetDoesntExist              = errors.New("budget with this id doesn't exists")
+	BudgetDoesntBelongToUser       = errors.New("budget with this id doesn't belong to user")
+	BudgetAlreadyExists            = errors.New("category already has a budget with this period and currency")
+	BudgetRequiresExpenseCategory  = errors.New("only expense categories can have budgets")
+	InvalidBudgetAmount            = errors.New("budget amount must be positive and rollover limit must not be negative")
+	InvalidBudgetPeriod            = errors.New("budget end date must be after start date, custom period requires end date")
+	InvalidSpendingTotal           = errors.New("spending total must not be negative and its period end must be after start")
+	InvalidGoalTarget              = errors.New("goal category requires positive target amount, target date and target currency")
+	GoalTargetNotAllowed           = errors.New("target amount, date and currency are allowed for goal categories only")
+	CategoryIsNotGoal              = errors.New("category is not a goal")
+	SystemCategoryCantBeDeleted    = errors.New("system category can't be deleted")
+	TransferCategoryExists         = errors.New("user already has a transfer category")
+	TransferCategoryCantBeImported = errors.New("transfer category is created by the service and can't be imported")
+	TaxAttributesNotAllowed        = errors.New("only income and expense categories have tax attributes")
+	InvalidDeductible              = errors.New("only expense categories can be deductible, deductible percentage must be from 0 to 100")
+	InvalidJurisdiction            = errors.New("unknown tax jurisdiction")
+	InvalidTaxCode                 = errors.New("tax code must be a code of the jurisdiction for the category type")
+	HouseholdDoesntExist           = errors.New("household with this id doesn't exists")
+	NotHouseholdMember             = errors.New("user isn't a member of the household")
+	HouseholdEditorRequired        = errors.New("household viewers can't change household categories")
+	HouseholdOwnerRequired         = errors.New("only the household owner can manage members")
+	HouseholdOwnerCantLeave        = errors.New("household owner can't leave the household")
+	AlreadyHouseholdMember         = errors.New("user is already a member of the household")
+	InvalidInvitation              = errors.New("invitation doesn't exist, has expired or was already accepted")
+	InvitationForAnotherUser       = errors.New("invitation is for another user")
+	InvalidAccountId               = errors.New("account id must be from 1 to 64 symbols long")
+	TagDoesntExist                 = errors.New("tag with this id doesn't exists")
+	TagDoesntBelongToUser          = errors.New("tag with this id doesn't belong to user")
+	TagAlreadyExists               = errors.New("tag with this name already exists")
+	TagNameRequired                = errors.New("tag name must not be blank")
+	SmartGroupDoesntExist          = errors.New("smart group with this id doesn't exists")
+	SmartGroupDoesntBelongToUser   = errors.New("smart group with this id doesn't belong to user")
+	SmartGroupAlreadyExists        = errors.New("smart group with this name already exists")
+	InvalidGroupFilter             = errors.New("invalid smart group filter")
+	CategoryVersionDoesntExist     = errors.New("category has no such version")
+	InvalidAsOf                    = errors.New("asOf must be an RFC 3339 time and can't be combined with accountId")
+	InvalidAuditRange              = errors.New("from and to must be RFC 3339 times, from before to")
+	AdminRequired                  = errors.New("only administrators can access the audit log")
+	RequestBodyTooLarge            = errors.New("request body is too large")
 )
 
 const (
-	InvalidInputData     = "invalid input data"
-	CannotCreateCategory = "cannot create category"
-	CannotGetCategories  = "cannot retrieve categories"
-	CannotUpdateCategory = "cannot update category"
-	CannotDeleteCategory = "cannot delete category"
+	InvalidInputData      = "invalid input data"
+	CannotCreateCategory  = "cannot create category"
+	CannotGetCategories   = "cannot retrieve categories"
+	CannotGetCategory     = "cannot retrieve category"
+	CannotUpdateCategory  = "cannot update category"
+	CannotUpsertCategory  = "cannot create or replace category"
+	CannotDeleteCategory  = "cannot delete category"
+	CannotGetWebhooks     = "cannot retrieve webhooks"
+	CannotCreateWebhook   = "cannot create webhook"
+	CannotUpdateWebhook   = "cannot update webhook"
+	CannotDeleteWebhook   = "cannot delete webhook"
+	CannotGetDeliveries   = "cannot retrieve webhook deliveries"
+	CannotGetChanges      = "cannot retrieve category changes"
+	CannotExport          = "cannot export categories"
+	CannotImport          = "cannot import categories"
+	CannotStreamEvents    = "cannot stream category events"
+	CannotUseIdempotency  = "cannot process idempotency key"
+	CannotGetMccMappings  = "cannot retrieve mcc mappings"
+	CannotCreateMapping   = "cannot create mcc mapping"
+	CannotUpdateMapping   = "cannot update mcc mapping"
+	CannotDeleteMapping   = "cannot delete mcc mapping"
+	CannotResolveMcc      = "cannot resolve mcc"
+	CannotGetRules        = "cannot retrieve rules"
+	CannotCreateRule      = "cannot create rule"
+	CannotUpdateRule      = "cannot update rule"
+	CannotDeleteRule      = "cannot delete rule"
+	CannotClassify        = "cannot classify transactions"
+	CannotSuggest         = "cannot suggest categories"
+	CannotGetBudgets      = "cannot retrieve budgets"
+	CannotCreateBudget    = "cannot create budget"
+	CannotUpdateBudget    = "cannot update budget"
+	CannotDeleteBudget    = "cannot delete budget"
+	CannotGetStatus       = "cannot retrieve budget status"
+	CannotRecordSpending  = "cannot record spending"
+	CannotGetProgress     = "cannot retrieve goal progress"
+	CannotGetTax          = "cannot retrieve tax attributes"
+	CannotSetTax          = "cannot set tax attributes"
+	CannotDeleteTax       = "cannot delete tax attributes"
+	CannotGetTaxSummary   = "cannot retrieve tax summary"
+	CannotGetHouseholds   = "cannot retrieve households"
+	CannotCreateHousehold = "cannot create household"
+	CannotGetMembers      = "cannot retrieve household members"
+	CannotRemoveMember    = "cannot remove household member"
+	CannotInvite          = "cannot create household invitation"
+	CannotAcceptInvite    = "cannot accept household invitation"
+	CannotGetAccounts     = "cannot retrieve category accounts"
+	CannotSetAccounts     = "cannot set category accounts"
+	CannotDeleteAccount   = "cannot unlink category account"
+	CannotGetTags         = "cannot retrieve tags"
+	CannotCreateTag       = "cannot create tag"
+	CannotUpdateTag       = "cannot update tag"
+	CannotDeleteTag       = "cannot delete tag"
+	CannotAssignTag       = "cannot assign tag"
+	CannotUnassignTag     = "cannot unassign tag"
+	CannotGetGroups       = "cannot retrieve smart groups"
+	CannotCreateGroup     = "cannot create smart group"
+	CannotUpdateGroup     = "cannot update smart group"
+	CannotDeleteGroup     = "cannot delete smart group"
+	CannotResolveGroup    = "cannot resolve smart group"
+	CannotGetHistory      = "cannot retrieve category history"
+	CannotRevertCategory  = "cannot revert category"
+	CannotRecordAudit     = "cannot record audit entry"
+	CannotVerifyAudit     = "cannot verify audit log"
+	CannotExportAudit     = "cannot export audit log"
 )
 
 type ErrorMessage string
@@ -0,0 +1,19 @@
+package event
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/event"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+)
+
+type fanOutPublisher []event.Publisher
+
+// NewFanOutPublisher publishes every event to all the publishers in order.
+func NewFanOutPublisher(publishers ...event.Publisher) event.Publisher {
+	return fanOutPublisher(publishers)
+}
+
+func (p fanOutPublisher) Publish(e model.Event) {
+	for _, publisher := range p {
+		publisher.Publish(e)
+	}
+}
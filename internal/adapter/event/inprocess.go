@@ -0,0 +1,98 @@
+package event
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/event"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"sync"
+)
+
+type inProcessBroadcaster struct {
+	mu          sync.RWMutex
+	subscribers map[uint64]map[*subscriber]struct{}
+	bufferSize  int
+	closed      bool
+}
+
+type subscriber struct {
+	events chan model.Event
+	once   sync.Once
+}
+
+// NewInProcessBroadcaster creates a broadcaster for a single replica deployment.
+// Subscriber that doesn't keep up with bufferSize events is disconnected and expected to resume.
+func NewInProcessBroadcaster(bufferSize int) event.Broadcaster {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	return &inProcessBroadcaster{
+		subscribers: make(map[uint64]map[*subscriber]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+func (b *inProcessBroadcaster) Publish(e model.Event) {
+	b.mu.RLock()
+	var slow []*subscriber
+	for s := range b.subscribers[e.UserId] {
+		select {
+		case s.events <- e:
+		default:
+			slow = append(slow, s)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, s := range slow {
+		b.unsubscribe(e.UserId, s)
+	}
+}
+
+func (b *inProcessBroadcaster) Subscribe(userId uint64) (<-chan model.Event, func()) {
+	s := &subscriber{events: make(chan model.Event, b.bufferSize)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		s.close()
+		return s.events, func() {}
+	}
+	if b.subscribers[userId] == nil {
+		b.subscribers[userId] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[userId][s] = struct{}{}
+
+	return s.events, func() { b.unsubscribe(userId, s) }
+}
+
+// Close disconnects every subscriber.
+func (b *inProcessBroadcaster) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for userId, subscribers := range b.subscribers {
+		for s := range subscribers {
+			s.close()
+		}
+		delete(b.subscribers, userId)
+	}
+	return nil
+}
+
+func (b *inProcessBroadcaster) unsubscribe(userId uint64, s *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if subscribers, ok := b.subscribers[userId]; ok {
+		delete(subscribers, s)
+		if len(subscribers) == 0 {
+			delete(b.subscribers, userId)
+		}
+	}
+	s.close()
+}
+
+func (s *subscriber) close() {
+	s.once.Do(func() { close(s.events) })
+}
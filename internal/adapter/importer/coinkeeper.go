@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"io"
+	"strings"
+)
+
+// coinKeeperColumns are names of the used columns in English and Russian exports.
+var coinKeeperColumns = map[string][]string{
+	"type": {"type", "тип"},
+	"from": {"from", "из"},
+	"to":   {"to", "в"},
+}
+
+type coinKeeperImporter struct{}
+
+// NewCoinKeeperImporter reads categories from CoinKeeper transactions CSV export.
+// Income category is the source of income transactions, expense category is the target of expense ones.
+func NewCoinKeeperImporter() importer.Importer {
+	return coinKeeperImporter{}
+}
+
+func (coinKeeperImporter) Format() model.ImportFormat {
+	return model.ImportCoinKeeper
+}
+
+func (coinKeeperImporter) Detect(head []byte) bool {
+	columns, err := readHeader(head)
+	if err != nil {
+		return false
+	}
+	return coinKeeperHasColumns(columns)
+}
+
+func (coinKeeperImporter) Parse(r io.Reader, maxRows int) ([]model.CategoryCreateDTO, error) {
+	reader := newCSVReader(r)
+	columns, err := readColumns(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !coinKeeperHasColumns(columns) {
+		return nil, fmt.Errorf("%w: no type, from or to column", serviceerror.InvalidImportFile)
+	}
+
+	var categories []model.CategoryCreateDTO
+	seen := make(map[string]bool)
+	err = forEachRow(reader, func(row []string) error {
+		var category model.CategoryCreateDTO
+		switch strings.ToLower(coinKeeperGet(columns, row, "type")) {
+		case "income", "доход":
+			category = model.CategoryCreateDTO{Name: coinKeeperGet(columns, row, "from"), Type: entity.Income}
+		case "expense", "расход":
+			category = model.CategoryCreateDTO{Name: coinKeeperGet(columns, row, "to"), Type: entity.Expense}
+		default:
+			// transfers between accounts
+			return nil
+		}
+		if category.Name == "" || seen[category.Name] {
+			return nil
+		}
+		if len(categories) == maxRows {
+			return serviceerror.TooManyImportRows
+		}
+		seen[category.Name] = true
+		categories = append(categories, category)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func coinKeeperHasColumns(columns columns) bool {
+	for column := range coinKeeperColumns {
+		if coinKeeperColumn(columns, column) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func coinKeeperGet(columns columns, row []string, column string) string {
+	return columns.get(row, coinKeeperColumn(columns, column))
+}
+
+// coinKeeperColumn returns the name of the column in the file's language.
+func coinKeeperColumn(columns columns, column string) string {
+	for _, name := range coinKeeperColumns[column] {
+		if columns.has(name) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+package importer
+
+import "github.com/khivuksergey/portmonetka.category/internal/core/port/importer"
+
+// Default returns all importers in the order of format detection, from the most specific one.
+func Default() []importer.Importer {
+	return []importer.Importer{
+		NewJSONImporter(),
+		NewQIFImporter(),
+		NewYNABImporter(),
+		NewCoinKeeperImporter(),
+		NewCSVImporter(),
+	}
+}
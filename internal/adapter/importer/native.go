@@ -0,0 +1,218 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"io"
+	"strings"
+	"time"
+)
+
+// record is a category of the service's own export file.
+type record struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Description    string `json:"description"`
+	ExternalId     string `json:"externalId"`
+	TargetAmount   string `json:"targetAmount"`
+	TargetDate     string `json:"targetDate"`
+	TargetCurrency string `json:"targetCurrency"`
+}
+
+// categoryCreateDTO converts the record, the target date is a date or RFC 3339 timestamp.
+func (r record) categoryCreateDTO() (model.CategoryCreateDTO, error) {
+	dto := model.CategoryCreateDTO{
+		Name:        strings.TrimSpace(r.Name),
+		Description: strings.TrimSpace(r.Description),
+		Type:        entity.CategoryType(strings.ToUpper(strings.TrimSpace(r.Type))),
+	}
+	if externalId := strings.TrimSpace(r.ExternalId); externalId != "" {
+		dto.ExternalId = &externalId
+	}
+	if targetAmount := strings.TrimSpace(r.TargetAmount); targetAmount != "" {
+		amount, err := decimal.NewFromString(targetAmount)
+		if err != nil {
+			return dto, fmt.Errorf("%w: invalid targetAmount %q", serviceerror.InvalidImportFile, targetAmount)
+		}
+		dto.TargetAmount = &amount
+	}
+	if targetDate := strings.TrimSpace(r.TargetDate); targetDate != "" {
+		date, err := time.Parse(time.DateOnly, targetDate)
+		if err != nil {
+			if date, err = time.Parse(time.RFC3339, targetDate); err != nil {
+				return dto, fmt.Errorf("%w: invalid targetDate %q", serviceerror.InvalidImportFile, targetDate)
+			}
+		}
+		dto.TargetDate = &date
+	}
+	if targetCurrency := strings.ToUpper(strings.TrimSpace(r.TargetCurrency)); targetCurrency != "" {
+		dto.TargetCurrency = &targetCurrency
+	}
+	return dto, nil
+}
+
+type csvImporter struct{}
+
+// NewCSVImporter reads CSV with name, type, description, externalId and goal target columns in any order.
+func NewCSVImporter() importer.Importer {
+	return csvImporter{}
+}
+
+func (csvImporter) Format() model.ImportFormat {
+	return model.ImportCSV
+}
+
+func (csvImporter) Detect(head []byte) bool {
+	columns, err := readHeader(head)
+	if err != nil {
+		return false
+	}
+	return columns.has("name") && columns.has("type")
+}
+
+func (csvImporter) Parse(r io.Reader, maxRows int) ([]model.CategoryCreateDTO, error) {
+	reader := newCSVReader(r)
+	columns, err := readColumns(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !columns.has("name") || !columns.has("type") {
+		return nil, fmt.Errorf("%w: no name or type column", serviceerror.InvalidImportFile)
+	}
+
+	var categories []model.CategoryCreateDTO
+	err = forEachRow(reader, func(row []string) error {
+		if len(categories) == maxRows {
+			return serviceerror.TooManyImportRows
+		}
+		category, err := record{
+			Name:           columns.get(row, "name"),
+			Type:           columns.get(row, "type"),
+			Description:    columns.get(row, "description"),
+			ExternalId:     columns.get(row, "externalId"),
+			TargetAmount:   columns.get(row, "targetAmount"),
+			TargetDate:     columns.get(row, "targetDate"),
+			TargetCurrency: columns.get(row, "targetCurrency"),
+		}.categoryCreateDTO()
+		if err != nil {
+			return fmt.Errorf("row %d: %w", len(categories)+1, err)
+		}
+		categories = append(categories, category)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+type jsonImporter struct{}
+
+// NewJSONImporter reads JSON array of categories.
+func NewJSONImporter() importer.Importer {
+	return jsonImporter{}
+}
+
+func (jsonImporter) Format() model.ImportFormat {
+	return model.ImportJSON
+}
+
+func (jsonImporter) Detect(head []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(trimBOM(head)), []byte("["))
+}
+
+func (jsonImporter) Parse(r io.Reader, maxRows int) ([]model.CategoryCreateDTO, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("%w: expected array", serviceerror.InvalidImportFile)
+	}
+
+	var categories []model.CategoryCreateDTO
+	for decoder.More() {
+		if len(categories) == maxRows {
+			return nil, serviceerror.TooManyImportRows
+		}
+		var category record
+		if err := decoder.Decode(&category); err != nil {
+			return nil, fmt.Errorf("%w: %w", serviceerror.InvalidImportFile, err)
+		}
+		dto, err := category.categoryCreateDTO()
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", len(categories)+1, err)
+		}
+		categories = append(categories, dto)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %w", serviceerror.InvalidImportFile, err)
+	}
+	return categories, nil
+}
+
+// columns maps lower case CSV column names to their positions.
+type columns map[string]int
+
+func (c columns) has(column string) bool {
+	_, ok := c[strings.ToLower(column)]
+	return ok
+}
+
+func (c columns) get(row []string, column string) string {
+	if i, ok := c[strings.ToLower(column)]; ok && i < len(row) {
+		return strings.TrimSpace(row[i])
+	}
+	return ""
+}
+
+func newCSVReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	return reader
+}
+
+func readColumns(reader *csv.Reader) (columns, error) {
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", serviceerror.InvalidImportFile, err)
+	}
+	result := make(columns, len(header))
+	for i, column := range header {
+		result[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+	return result, nil
+}
+
+// readHeader reads CSV columns from the beginning of the file.
+func readHeader(head []byte) (columns, error) {
+	line, _, _ := bytes.Cut(head, []byte("\n"))
+	return readColumns(newCSVReader(bytes.NewReader(line)))
+}
+
+// forEachRow calls fn for every row until the end of the file or the first error fn returns.
+func forEachRow(reader *csv.Reader, fn func(row []string) error) error {
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", serviceerror.InvalidImportFile, err)
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+func trimBOM(b []byte) []byte {
+	return bytes.TrimPrefix(b, []byte("\ufeff"))
+}
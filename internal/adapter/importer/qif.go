@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"io"
+	"strings"
+)
+
+const qifCategoryHeader = "!type:cat"
+
+type qifImporter struct{}
+
+// NewQIFImporter reads !Type:Cat blocks of Quicken Interchange Format file, other blocks are skipped.
+// Subcategories keep the "Master:Sub" path as the name, categories without I flag are expenses.
+func NewQIFImporter() importer.Importer {
+	return qifImporter{}
+}
+
+func (qifImporter) Format() model.ImportFormat {
+	return model.ImportQIF
+}
+
+func (qifImporter) Detect(head []byte) bool {
+	return bytes.Contains(bytes.ToLower(head), []byte(qifCategoryHeader))
+}
+
+func (qifImporter) Parse(r io.Reader, maxRows int) ([]model.CategoryCreateDTO, error) {
+	var categories []model.CategoryCreateDTO
+	var category model.CategoryCreateDTO
+	inCategories, found := false, false
+
+	flush := func() {
+		if inCategories && category.Name != "" {
+			if category.Type == "" {
+				category.Type = entity.Expense
+			}
+			categories = append(categories, category)
+		}
+		category = model.CategoryCreateDTO{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(categories) > maxRows {
+			return nil, serviceerror.TooManyImportRows
+		}
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case '!':
+			flush()
+			inCategories = strings.ToLower(line) == qifCategoryHeader
+			found = found || inCategories
+		case '^':
+			flush()
+		case 'N':
+			category.Name = strings.TrimSpace(line[1:])
+		case 'D':
+			category.Description = strings.TrimSpace(line[1:])
+		case 'I':
+			category.Type = entity.Income
+		case 'E':
+			category.Type = entity.Expense
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", serviceerror.InvalidImportFile, err)
+	}
+	flush()
+	if len(categories) > maxRows {
+		return nil, serviceerror.TooManyImportRows
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no !Type:Cat block", serviceerror.InvalidImportFile)
+	}
+	return categories, nil
+}
@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"io"
+	"strings"
+)
+
+const (
+	ynabGroupColumn    = "category group"
+	ynabCategoryColumn = "category"
+	ynabPathColumn     = "category group/category"
+	ynabInflowGroup    = "inflow"
+)
+
+type ynabImporter struct{}
+
+// NewYNABImporter reads categories from YNAB budget or register CSV export.
+// The name is "Group: Category". Categories of the Inflow group, and in the register
+// categories that only have inflows, are incomes.
+func NewYNABImporter() importer.Importer {
+	return ynabImporter{}
+}
+
+func (ynabImporter) Format() model.ImportFormat {
+	return model.ImportYNAB
+}
+
+func (ynabImporter) Detect(head []byte) bool {
+	columns, err := readHeader(head)
+	if err != nil {
+		return false
+	}
+	return columns.has(ynabGroupColumn) && columns.has(ynabCategoryColumn)
+}
+
+func (ynabImporter) Parse(r io.Reader, maxRows int) ([]model.CategoryCreateDTO, error) {
+	reader := newCSVReader(r)
+	columns, err := readColumns(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !columns.has(ynabGroupColumn) || !columns.has(ynabCategoryColumn) {
+		return nil, fmt.Errorf("%w: no category group or category column", serviceerror.InvalidImportFile)
+	}
+
+	type ynabCategory struct {
+		group           string
+		inflow, outflow bool
+	}
+	var names []string
+	categories := make(map[string]*ynabCategory)
+
+	err = forEachRow(reader, func(row []string) error {
+		group, name := columns.get(row, ynabGroupColumn), columns.get(row, ynabCategoryColumn)
+		if name == "" {
+			// transfers between accounts
+			return nil
+		}
+		path := columns.get(row, ynabPathColumn)
+		if path == "" {
+			path = group + ": " + name
+		}
+		category, ok := categories[path]
+		if !ok {
+			if len(names) == maxRows {
+				return serviceerror.TooManyImportRows
+			}
+			category = &ynabCategory{group: group}
+			categories[path] = category
+			names = append(names, path)
+		}
+		category.inflow = category.inflow || isNonZeroAmount(columns.get(row, "inflow"))
+		category.outflow = category.outflow || isNonZeroAmount(columns.get(row, "outflow"))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.CategoryCreateDTO, 0, len(names))
+	for _, name := range names {
+		category := categories[name]
+		categoryType := entity.Expense
+		if strings.EqualFold(category.group, ynabInflowGroup) || category.inflow && !category.outflow {
+			categoryType = entity.Income
+		}
+		result = append(result, model.CategoryCreateDTO{Name: name, Type: categoryType})
+	}
+	return result, nil
+}
+
+// isNonZeroAmount reports whether the formatted amount like "$1,200.00" has a non-zero digit.
+func isNonZeroAmount(amount string) bool {
+	return strings.ContainsAny(amount, "123456789")
+}
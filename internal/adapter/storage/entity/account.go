@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// CategoryAccount scopes the category to an account of the wallet service. Categories without
+// accounts are shown for every account, linked ones only for their accounts.
+type CategoryAccount struct {
+	CategoryId uint64    `json:"categoryId" gorm:"primaryKey;autoIncrement:false"`
+	AccountId  string    `json:"accountId" gorm:"primaryKey;size:64;index"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"<-:create"`
+}
+
+func (CategoryAccount) TableName() string { return "portmonetka.category_accounts" }
+
+// MaxAccountIdLength is the size of the account id column.
+const MaxAccountIdLength = 64
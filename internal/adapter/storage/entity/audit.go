@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records a mutating API call. Entries of each caller form a hash chain: PrevHash is the Hash
+// of the entry of the same Chain with the previous Seq, empty for the first one, so changing or removing
+// an entry breaks the chain after it. Id orders entries of all chains as they were recorded.
+type AuditEntry struct {
+	Id uint64 `json:"id" gorm:"primaryKey"`
+	// Chain is UserId, 0 for calls without one
+	Chain     uint64    `json:"chain" gorm:"not null;uniqueIndex:idx_audit_log_chain_seq"`
+	Seq       uint64    `json:"seq" gorm:"not null;uniqueIndex:idx_audit_log_chain_seq"`
+	CreatedAt time.Time `json:"createdAt" gorm:"not null;index"`
+	// UserId is the authenticated caller, or the subject of a valid token that was refused, unset without a valid token
+	UserId      *uint64 `json:"userId"`
+	Ip          string  `json:"ip" gorm:"not null"`
+	RequestUuid string  `json:"requestUuid" gorm:"not null"`
+	Method      string  `json:"method" gorm:"not null"`
+	// Route is the matched route pattern, Path is the requested path
+	Route    string `json:"route" gorm:"not null"`
+	Path     string `json:"path" gorm:"not null"`
+	Status   int    `json:"status" gorm:"not null"`
+	PrevHash string `json:"prevHash" gorm:"not null;size:64"`
+	Hash     string `json:"hash" gorm:"not null;size:64"`
+}
+
+func (AuditEntry) TableName() string { return "portmonetka.audit_log" }
+
+// ChainHash is the hex SHA-256 of the entry JSON with zero Id, empty Hash and UTC CreatedAt.
+// Id is assigned when the entry is stored, so it isn't hashed. Verifiers outside the service
+// can recompute the hash from exported entries.
+func (e AuditEntry) ChainHash() string {
+	e.Id, e.Hash = 0, ""
+	e.CreatedAt = e.CreatedAt.UTC()
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
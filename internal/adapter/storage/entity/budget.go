@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Budget is a spending limit of an expense category. Fixed periods repeat from StartDate,
+// a custom period runs once from StartDate to EndDate inclusive. UserId is the user who created the budget,
+// it is shared with everyone who can access the category.
+type Budget struct {
+	Id         uint64          `json:"id" gorm:"primarykey"`
+	UserId     uint64          `json:"userId" gorm:"not null;index"`
+	CategoryId uint64          `json:"categoryId" gorm:"not null;uniqueIndex:idx_budgets_category_period,where:period <> 'custom'"`
+	Amount     decimal.Decimal `json:"amount" gorm:"type:numeric(19,4);not null" swaggertype:"string"`
+	Currency   string          `json:"currency" gorm:"type:char(3);not null;uniqueIndex:idx_budgets_category_period,where:period <> 'custom'"`
+	Period     BudgetPeriod    `json:"period" gorm:"not null;uniqueIndex:idx_budgets_category_period,where:period <> 'custom'"`
+	StartDate  time.Time       `json:"startDate" gorm:"type:date;not null"`
+	EndDate    *time.Time      `json:"endDate,omitempty" gorm:"type:date"`
+	Rollover   BudgetRollover  `json:"rollover" gorm:"not null;default:none"`
+	// RolloverLimit caps the amount carried to the next period, no cap when nil
+	RolloverLimit *decimal.Decimal `json:"rolloverLimit,omitempty" gorm:"type:numeric(19,4)" swaggertype:"string"`
+	CreatedAt     time.Time        `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+}
+
+func (Budget) TableName() string { return "portmonetka.budgets" }
+
+// BudgetPeriodIndex keeps one repeating budget per category, period and currency.
+const BudgetPeriodIndex = "idx_budgets_category_period"
+
+type BudgetPeriod string
+
+const (
+	Weekly  BudgetPeriod = "weekly"
+	Monthly BudgetPeriod = "monthly"
+	Yearly  BudgetPeriod = "yearly"
+	Custom  BudgetPeriod = "custom"
+)
+
+// BudgetRollover tells what is carried from the previous period.
+type BudgetRollover string
+
+const (
+	// RolloverNone starts every period from the budget amount
+	RolloverNone BudgetRollover = "none"
+	// RolloverSurplus adds the unspent amount to the next period
+	RolloverSurplus BudgetRollover = "surplus"
+	// RolloverFull adds the unspent amount and subtracts the overspending
+	RolloverFull BudgetRollover = "full"
+)
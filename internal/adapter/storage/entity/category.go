@@ -1,26 +1,84 @@
 package entity
 
 import (
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 	"time"
 )
 
 type Category struct {
-	Id          uint64         `json:"id" gorm:"primarykey"`
-	UserId      uint64         `json:"userId" gorm:"not null;uniqueIndex:idx_userid_name_deletedat" validate:"required"`
-	Name        string         `json:"name" gorm:"not null;uniqueIndex:idx_userid_name_deletedat" validate:"required,min=3,max=128"`
-	Description string         `json:"description" gorm:"null" validate:"max=256"`
-	Type        CategoryType   `json:"type" gorm:"not null" validate:"required,oneof=INCOME EXPENSE"`
-	CreatedAt   time.Time      `json:"createdAt" gorm:"<-:create"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index;uniqueIndex:idx_userid_name_deletedat"`
+	Id          uint64       `json:"id" gorm:"primarykey"`
+	UserId      uint64       `json:"userId" gorm:"not null;uniqueIndex:idx_categories_user_name,where:deleted_at IS NULL AND household_id IS NULL;uniqueIndex:idx_categories_user_external_id,where:deleted_at IS NULL;uniqueIndex:idx_categories_user_transfer,where:type = 'TRANSFER' AND deleted_at IS NULL" validate:"required"`
+	Name        string       `json:"name" gorm:"not null;uniqueIndex:idx_categories_user_name,where:deleted_at IS NULL AND household_id IS NULL;uniqueIndex:idx_categories_household_name,where:deleted_at IS NULL AND household_id IS NOT NULL" validate:"required,min=3,max=128"`
+	Description string       `json:"description" gorm:"null" validate:"max=256"`
+	Type        CategoryType `json:"type" gorm:"not null" validate:"required,oneof=INCOME EXPENSE GOAL TRANSFER"`
+	ExternalId  *string      `json:"externalId,omitempty" gorm:"size:255;uniqueIndex:idx_categories_user_external_id,where:deleted_at IS NULL" validate:"omitnil,min=1,max=255"`
+	// TargetAmount, TargetDate and TargetCurrency are set for goal categories only
+	TargetAmount   *decimal.Decimal `json:"targetAmount,omitempty" gorm:"type:numeric(19,4)" validate:"required_if=Type GOAL,excluded_unless=Type GOAL" swaggertype:"string"`
+	TargetDate     *time.Time       `json:"targetDate,omitempty" gorm:"type:date" validate:"required_if=Type GOAL,excluded_unless=Type GOAL"`
+	TargetCurrency *string          `json:"targetCurrency,omitempty" gorm:"type:char(3)" validate:"required_if=Type GOAL,excluded_unless=Type GOAL,omitnil,iso4217"`
+	// HouseholdId is set for categories shared with the household members, UserId is then the author
+	HouseholdId *uint64 `json:"householdId,omitempty" gorm:"index;uniqueIndex:idx_categories_household_name,where:deleted_at IS NULL AND household_id IS NOT NULL"`
+	// System category is managed by the service, it can't be deleted
+	System     bool           `json:"system" gorm:"not null;default:false"`
+	Version    uint64         `json:"version" gorm:"not null;default:1"`
+	CreatedAt  time.Time      `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+	CreatedSeq uint64         `json:"-" gorm:"<-:false;not null;default:nextval('portmonetka.category_change_seq')"`
+	ChangeSeq  uint64         `json:"-" gorm:"not null;index;default:nextval('portmonetka.category_change_seq')"`
 }
 
 func (Category) TableName() string { return "portmonetka.categories" }
 
+// SetTarget changes the provided goal target attributes and keeps the others, the target date is kept as a UTC date.
+func (c *Category) SetTarget(amount *decimal.Decimal, date *time.Time, currency *string) {
+	if amount != nil {
+		c.TargetAmount = amount
+	}
+	if date != nil {
+		targetDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		c.TargetDate = &targetDate
+	}
+	if currency != nil {
+		c.TargetCurrency = currency
+	}
+}
+
+// CategoryChange is the category as it appears in user's change feed. FeedSeq orders the feed, it is the
+// sequence number of the category change or of joining or leaving the category's household, whichever is later.
+type CategoryChange struct {
+	Category
+	FeedSeq uint64
+	// JoinedSeq is when the user joined the category's household, zero for personal categories
+	JoinedSeq uint64
+	// RevokedAt is set when the user left the category's household
+	RevokedAt *time.Time
+}
+
+const (
+	// CategoryChangeSequence orders category mutations for the change feed.
+	CategoryChangeSequence = "portmonetka.category_change_seq"
+	// CategoryNameIndex keeps names of user's live personal categories unique.
+	CategoryNameIndex = "idx_categories_user_name"
+	// CategoryHouseholdNameIndex keeps names of household's live categories unique.
+	CategoryHouseholdNameIndex = "idx_categories_household_name"
+	// CategoryExternalIdIndex keeps client supplied external ids of user's live categories unique.
+	CategoryExternalIdIndex = "idx_categories_user_external_id"
+	// CategoryTransferIndex keeps one live transfer category per user.
+	CategoryTransferIndex = "idx_categories_user_transfer"
+	// TransferCategoryName is the name the transfer category is created with.
+	TransferCategoryName = "Transfers"
+)
+
 type CategoryType string
 
 const (
 	Income  CategoryType = "INCOME"
 	Expense CategoryType = "EXPENSE"
+	// Goal category collects savings towards the target amount by the target date
+	Goal CategoryType = "GOAL"
+	// Transfer is the system category of moves between user's own accounts,
+	// reports exclude it so the moves don't count as both income and expense
+	Transfer CategoryType = "TRANSFER"
 )
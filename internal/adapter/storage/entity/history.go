@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// CategoryHistory is an append-only record of a category change. Before is the category
+// before the change and After the one after it, creation has no Before and deletion no After.
+type CategoryHistory struct {
+	Id         uint64        `json:"id" gorm:"primarykey"`
+	CategoryId uint64        `json:"categoryId" gorm:"not null;index"`
+	ActorId    uint64        `json:"actorId" gorm:"not null"`
+	Action     HistoryAction `json:"action" gorm:"not null"`
+	// Version is the category version the change produced
+	Version   uint64    `json:"version" gorm:"not null"`
+	Before    *Category `json:"before,omitempty" gorm:"type:jsonb;serializer:json"`
+	After     *Category `json:"after,omitempty" gorm:"type:jsonb;serializer:json"`
+	CreatedAt time.Time `json:"createdAt" gorm:"<-:create;index"`
+}
+
+func (CategoryHistory) TableName() string { return "portmonetka.category_history" }
+
+type HistoryAction string
+
+const (
+	HistoryCreate HistoryAction = "create"
+	HistoryUpdate HistoryAction = "update"
+	HistoryDelete HistoryAction = "delete"
+	// HistoryRevert is an update restoring the category to one of its versions
+	HistoryRevert HistoryAction = "revert"
+)
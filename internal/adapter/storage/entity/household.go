@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Household shares its categories with the members, e.g. a couple or a family with one budget.
+type Household struct {
+	Id        uint64    `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"not null"`
+	OwnerId   uint64    `json:"ownerId" gorm:"not null;index"`
+	CreatedAt time.Time `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (Household) TableName() string { return "portmonetka.households" }
+
+// HouseholdMember is soft deleted when the member leaves, so the change feed of the former
+// member can remove household categories. JoinedSeq and LeftSeq place joining and leaving in it.
+type HouseholdMember struct {
+	HouseholdId uint64         `json:"householdId" gorm:"primaryKey;autoIncrement:false"`
+	UserId      uint64         `json:"userId" gorm:"primaryKey;autoIncrement:false;index"`
+	Role        HouseholdRole  `json:"role" gorm:"not null"`
+	JoinedSeq   uint64         `json:"-" gorm:"<-:false;not null;default:nextval('portmonetka.category_change_seq')"`
+	LeftSeq     *uint64        `json:"-"`
+	CreatedAt   time.Time      `json:"createdAt" gorm:"<-:create"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (HouseholdMember) TableName() string { return "portmonetka.household_members" }
+
+// HouseholdInvitation lets the user with the token join the household.
+// Invitation with UserId set can be accepted by that user only.
+type HouseholdInvitation struct {
+	Id          uint64        `json:"id" gorm:"primarykey"`
+	HouseholdId uint64        `json:"householdId" gorm:"not null;index"`
+	InvitedBy   uint64        `json:"invitedBy" gorm:"not null"`
+	UserId      *uint64       `json:"userId,omitempty"`
+	Role        HouseholdRole `json:"role" gorm:"not null"`
+	Token       string        `json:"token,omitempty" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt   time.Time     `json:"expiresAt" gorm:"not null"`
+	AcceptedBy  *uint64       `json:"acceptedBy,omitempty"`
+	AcceptedAt  *time.Time    `json:"acceptedAt,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt" gorm:"<-:create"`
+}
+
+func (HouseholdInvitation) TableName() string { return "portmonetka.household_invitations" }
+
+// HouseholdMemberKey is the primary key of household members, violating it means the user is already a member.
+const HouseholdMemberKey = "household_members_pkey"
+
+// HouseholdRole is what a member can do with household categories: viewers read them,
+// editors change them too, the owner also manages the members.
+type HouseholdRole string
+
+const (
+	RoleViewer HouseholdRole = "viewer"
+	RoleEditor HouseholdRole = "editor"
+	RoleOwner  HouseholdRole = "owner"
+)
+
+var roleRanks = map[HouseholdRole]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// Allows reports whether the role grants everything the required role does.
+func (r HouseholdRole) Allows(required HouseholdRole) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// RolesAllowing returns the roles granting the required role.
+func RolesAllowing(required HouseholdRole) []HouseholdRole {
+	roles := make([]HouseholdRole, 0, len(roleRanks))
+	for _, role := range []HouseholdRole{RoleViewer, RoleEditor, RoleOwner} {
+		if role.Allows(required) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
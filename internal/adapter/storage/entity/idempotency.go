@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// IdempotencyKey is a client supplied key of an unsafe request with its stored response.
+type IdempotencyKey struct {
+	UserId      uint64            `gorm:"primaryKey;autoIncrement:false"`
+	Key         string            `gorm:"column:idempotency_key;primaryKey;size:255"`
+	Fingerprint string            `gorm:"not null"`
+	Completed   bool              `gorm:"not null"`
+	StatusCode  int               `gorm:"not null;default:0"`
+	Headers     map[string]string `gorm:"serializer:json"`
+	Body        []byte
+	CreatedAt   time.Time `gorm:"<-:create"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (IdempotencyKey) TableName() string { return "portmonetka.idempotency_keys" }
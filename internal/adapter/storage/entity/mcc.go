@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// MccMapping assigns user's category to merchant category codes from MccFrom to MccTo inclusive.
+type MccMapping struct {
+	Id         uint64    `json:"id" gorm:"primarykey"`
+	UserId     uint64    `json:"userId" gorm:"not null;uniqueIndex:idx_mcc_mappings_user_range"`
+	MccFrom    string    `json:"mccFrom" gorm:"type:char(4);not null;uniqueIndex:idx_mcc_mappings_user_range"`
+	MccTo      string    `json:"mccTo" gorm:"type:char(4);not null;uniqueIndex:idx_mcc_mappings_user_range"`
+	CategoryId uint64    `json:"categoryId" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (MccMapping) TableName() string { return "portmonetka.mcc_mappings" }
+
+// MccMappingRangeIndex keeps a code range mapped once per user.
+const MccMappingRangeIndex = "idx_mcc_mappings_user_range"
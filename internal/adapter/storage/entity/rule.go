@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Rule assigns CategoryId to transactions matching Condition. Enabled rules are evaluated
+// in ascending Priority, then by Id, and the first match wins.
+type Rule struct {
+	Id         uint64        `json:"id" gorm:"primarykey"`
+	UserId     uint64        `json:"userId" gorm:"not null;index"`
+	Name       string        `json:"name" gorm:"not null"`
+	Priority   int           `json:"priority" gorm:"not null;default:0"`
+	CategoryId uint64        `json:"categoryId" gorm:"not null;index"`
+	Condition  RuleCondition `json:"condition" gorm:"not null;serializer:json"`
+	Enabled    bool          `json:"enabled" gorm:"not null"`
+	CreatedAt  time.Time     `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+}
+
+func (Rule) TableName() string { return "portmonetka.rules" }
+
+type RuleOperator string
+
+const (
+	RuleAnd      RuleOperator = "and"
+	RuleOr       RuleOperator = "or"
+	RuleContains RuleOperator = "contains"
+	RuleRegex    RuleOperator = "regex"
+	RuleEquals   RuleOperator = "equals"
+	RulePrefix   RuleOperator = "prefix"
+	RuleAmount   RuleOperator = "amount"
+)
+
+// RuleCondition is a node of the condition tree. "and" and "or" combine Conditions, text operators
+// match Value against the transaction description, case-insensitive unless CaseSensitive is set.
+// "amount" matches absolute transaction amount from Min inclusive to Max exclusive.
+type RuleCondition struct {
+	Op            RuleOperator     `json:"op"`
+	Value         string           `json:"value,omitempty"`
+	CaseSensitive bool             `json:"caseSensitive,omitempty"`
+	Min           *decimal.Decimal `json:"min,omitempty" swaggertype:"string"`
+	Max           *decimal.Decimal `json:"max,omitempty" swaggertype:"string"`
+	Conditions    []RuleCondition  `json:"conditions,omitempty"`
+}
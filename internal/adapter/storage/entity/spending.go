@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// SpendingTotal is the amount the user spent in the category over the period from PeriodStart inclusive
+// to PeriodEnd exclusive, as reported by the transaction service. Every member of a household reports
+// own totals of the household categories, budgets and goals sum them.
+type SpendingTotal struct {
+	UserId      uint64          `json:"userId" gorm:"primaryKey;autoIncrement:false"`
+	CategoryId  uint64          `json:"categoryId" gorm:"primaryKey;autoIncrement:false;index"`
+	Currency    string          `json:"currency" gorm:"primaryKey;type:char(3)"`
+	PeriodStart time.Time       `json:"periodStart" gorm:"primaryKey"`
+	PeriodEnd   time.Time       `json:"periodEnd" gorm:"primaryKey"`
+	Amount      decimal.Decimal `json:"amount" gorm:"type:numeric(19,4);not null" swaggertype:"string"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+func (SpendingTotal) TableName() string { return "portmonetka.spending_totals" }
+
+// Within reports whether the total falls into the period from start inclusive to end exclusive.
+func (t SpendingTotal) Within(start, end time.Time) bool {
+	return !t.PeriodStart.Before(start) && !t.PeriodEnd.After(end)
+}
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// Tag is user's label cutting across categories, e.g. "essential" or "subscription".
+type Tag struct {
+	Id        uint64    `json:"id" gorm:"primarykey"`
+	UserId    uint64    `json:"userId" gorm:"not null;uniqueIndex:idx_tags_user_name"`
+	Name      string    `json:"name" gorm:"size:64;not null;uniqueIndex:idx_tags_user_name"`
+	CreatedAt time.Time `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (Tag) TableName() string { return "portmonetka.tags" }
+
+// TagNameIndex keeps tag names unique per user.
+const TagNameIndex = "idx_tags_user_name"
+
+// CategoryTag assigns the tag to the category. Tags are personal, so members of a household
+// label its categories with their own tags.
+type CategoryTag struct {
+	CategoryId uint64    `json:"categoryId" gorm:"primaryKey;autoIncrement:false"`
+	TagId      uint64    `json:"tagId" gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"<-:create"`
+}
+
+func (CategoryTag) TableName() string { return "portmonetka.category_tags" }
+
+// SmartGroup is a saved filter of user's categories resolved on every read.
+type SmartGroup struct {
+	Id        uint64      `json:"id" gorm:"primarykey"`
+	UserId    uint64      `json:"userId" gorm:"not null;uniqueIndex:idx_smart_groups_user_name"`
+	Name      string      `json:"name" gorm:"not null;uniqueIndex:idx_smart_groups_user_name"`
+	Filter    GroupFilter `json:"filter" gorm:"not null;serializer:json"`
+	CreatedAt time.Time   `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+func (SmartGroup) TableName() string { return "portmonetka.smart_groups" }
+
+// SmartGroupNameIndex keeps smart group names unique per user.
+const SmartGroupNameIndex = "idx_smart_groups_user_name"
+
+type GroupFilterOperator string
+
+const (
+	FilterAnd  GroupFilterOperator = "and"
+	FilterOr   GroupFilterOperator = "or"
+	FilterNot  GroupFilterOperator = "not"
+	FilterTag  GroupFilterOperator = "tag"
+	FilterType GroupFilterOperator = "type"
+)
+
+// GroupFilter is a node of the smart group filter tree. "and" and "or" combine Filters, "not" negates
+// its single filter, "tag" matches categories with the tag TagId and "type" categories of the Type.
+type GroupFilter struct {
+	Op      GroupFilterOperator `json:"op"`
+	TagId   uint64              `json:"tagId,omitempty"`
+	Type    CategoryType        `json:"type,omitempty"`
+	Filters []GroupFilter       `json:"filters,omitempty"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// CategoryTax holds tax attributes of the category: whether its spending is deductible
+// and the line of the jurisdiction's tax form the category maps to.
+type CategoryTax struct {
+	CategoryId uint64 `json:"categoryId" gorm:"primaryKey;autoIncrement:false"`
+	// UserId is the user who set the attributes, they are shared with everyone who can access the category
+	UserId     uint64 `json:"userId" gorm:"not null;index"`
+	Deductible bool   `json:"deductible" gorm:"not null;default:false"`
+	// DeductiblePercentage is the deductible share of the category spending, zero when not deductible
+	DeductiblePercentage decimal.Decimal `json:"deductiblePercentage" gorm:"type:numeric(5,2);not null;default:0" swaggertype:"string"`
+	Jurisdiction         *string         `json:"jurisdiction,omitempty" gorm:"type:char(2)"`
+	TaxCode              *string         `json:"taxCode,omitempty" gorm:"size:32"`
+	UpdatedAt            time.Time       `json:"updatedAt"`
+}
+
+func (CategoryTax) TableName() string { return "portmonetka.category_taxes" }
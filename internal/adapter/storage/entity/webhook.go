@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+type Webhook struct {
+	Id                  uint64         `json:"id" gorm:"primarykey"`
+	UserId              uint64         `json:"userId" gorm:"not null;index"`
+	Url                 string         `json:"url" gorm:"not null"`
+	Secret              string         `json:"-" gorm:"not null"`
+	Events              []string       `json:"events" gorm:"serializer:json"`
+	Enabled             bool           `json:"enabled" gorm:"not null"`
+	ConsecutiveFailures int            `json:"consecutiveFailures" gorm:"not null;default:0"`
+	CreatedAt           time.Time      `json:"createdAt" gorm:"<-:create"`
+	UpdatedAt           time.Time      `json:"updatedAt"`
+	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (Webhook) TableName() string { return "portmonetka.webhooks" }
+
+// Subscribed reports whether the webhook wants the event type. Empty list subscribes to every event.
+func (w Webhook) Subscribed(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDelivery struct {
+	Id         uint64    `json:"id" gorm:"primarykey"`
+	WebhookId  uint64    `json:"webhookId" gorm:"not null;index"`
+	DeliveryId string    `json:"deliveryId" gorm:"not null;index"`
+	Event      string    `json:"event" gorm:"not null"`
+	Attempt    int       `json:"attempt" gorm:"not null"`
+	StatusCode int       `json:"statusCode"`
+	Success    bool      `json:"success" gorm:"not null"`
+	Error      string    `json:"error,omitempty"`
+	Duration   int64     `json:"durationMs"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"<-:create"`
+}
+
+func (WebhookDelivery) TableName() string { return "portmonetka.webhook_deliveries" }
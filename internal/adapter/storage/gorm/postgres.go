@@ -27,7 +27,75 @@ func NewDbManager(config config.DBConfig) storage.IDB {
 	return &dbm
 }
 
+// OpenDbManager connects to the database without changing the schema, for tools that work
+// with the database of a running service.
+func OpenDbManager(config config.DBConfig) (storage.IDB, error) {
+	dbm := dbManager{}
+	if err := dbm.open(config); err != nil {
+		return nil, err
+	}
+	return &dbm, nil
+}
+
 func (m *dbManager) InitDB(config config.DBConfig) (err error) {
+	if err = m.open(config); err != nil {
+		return err
+	}
+
+	err = m.db.Exec("CREATE SEQUENCE IF NOT EXISTS " + entity.CategoryChangeSequence).Error
+	if err != nil {
+		return err
+	}
+
+	// replaced by the partial CategoryNameIndex, NULL deleted_at never conflicted in it
+	err = m.db.Exec("DROP INDEX IF EXISTS portmonetka.idx_userid_name_deletedat").Error
+	if err != nil {
+		return err
+	}
+
+	// personal name index is recreated scoped to categories outside households
+	err = m.db.Exec(`DO $$ BEGIN
+		IF EXISTS (SELECT 1 FROM pg_indexes WHERE schemaname = 'portmonetka' AND indexname = '` + entity.CategoryNameIndex + `'
+			AND indexdef NOT LIKE '%household_id%') THEN
+			DROP INDEX portmonetka.` + entity.CategoryNameIndex + `;
+		END IF;
+	END $$`).Error
+	if err != nil {
+		return err
+	}
+
+	err = m.db.AutoMigrate(
+		&entity.Category{},
+		&entity.Webhook{},
+		&entity.WebhookDelivery{},
+		&entity.IdempotencyKey{},
+		&entity.MccMapping{},
+		&entity.Rule{},
+		&entity.Budget{},
+		&entity.SpendingTotal{},
+		&entity.CategoryTax{},
+		&entity.Household{},
+		&entity.HouseholdMember{},
+		&entity.HouseholdInvitation{},
+		&entity.CategoryAccount{},
+		&entity.Tag{},
+		&entity.CategoryTag{},
+		&entity.SmartGroup{},
+		&entity.CategoryHistory{},
+		&entity.AuditEntry{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = repo.ProtectAuditLog(m.db); err != nil {
+		return err
+	}
+
+	return repo.BackfillCategoryHistory(m.db)
+}
+
+func (m *dbManager) open(config config.DBConfig) (err error) {
 	dsn := fmt.Sprintf(config.ConnectionString,
 		viper.GetString("DB_USER"),
 		viper.GetString("DB_PASSWORD"),
@@ -45,18 +113,23 @@ func (m *dbManager) InitDB(config config.DBConfig) (err error) {
 		},
 	)
 
-	if err != nil {
-		return err
-	}
-
-	err = m.db.AutoMigrate(&entity.Category{})
-
 	return err
 }
 
 func (m *dbManager) InitRepositoryManager() *repository.Manager {
 	return &repository.Manager{
-		Category: repo.NewCategoryRepository(m.db),
+		Category:    repo.NewCategoryRepository(m.db),
+		Webhook:     repo.NewWebhookRepository(m.db),
+		Idempotency: repo.NewIdempotencyRepository(m.db),
+		MccMapping:  repo.NewMccMappingRepository(m.db),
+		Rule:        repo.NewRuleRepository(m.db),
+		Budget:      repo.NewBudgetRepository(m.db),
+		Spending:    repo.NewSpendingRepository(m.db),
+		Tax:         repo.NewTaxRepository(m.db),
+		Household:   repo.NewHouseholdRepository(m.db),
+		Account:     repo.NewCategoryAccountRepository(m.db),
+		Tag:         repo.NewTagRepository(m.db),
+		Audit:       repo.NewAuditRepository(m.db),
 	}
 }
 
@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+)
+
+type categoryAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryAccountRepository(db *gorm.DB) repository.CategoryAccountRepository {
+	return &categoryAccountRepository{db: db}
+}
+
+func (w *categoryAccountRepository) GetAccountIds(categoryId uint64) ([]string, error) {
+	var accountIds []string
+	result := w.db.
+		Model(&entity.CategoryAccount{}).
+		Where("category_id = ?", categoryId).
+		Order("account_id").
+		Pluck("account_id", &accountIds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return accountIds, nil
+}
+
+func (w *categoryAccountRepository) SetAccountIds(categoryId uint64, accountIds []string) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ?", categoryId).Delete(&entity.CategoryAccount{}).Error; err != nil {
+			return err
+		}
+		if len(accountIds) == 0 {
+			return nil
+		}
+		links := make([]entity.CategoryAccount, len(accountIds))
+		for i, accountId := range accountIds {
+			links[i] = entity.CategoryAccount{CategoryId: categoryId, AccountId: accountId}
+		}
+		return tx.Create(&links).Error
+	})
+}
+
+func (w *categoryAccountRepository) DeleteAccountId(categoryId uint64, accountId string) error {
+	return w.db.
+		Where("category_id = ? AND account_id = ?", categoryId, accountId).
+		Delete(&entity.CategoryAccount{}).Error
+}
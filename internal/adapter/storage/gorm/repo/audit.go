@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"errors"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+	"time"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Append takes the next sequence number and the hash of the last entry of the caller's chain under
+// the chain lock, so concurrent appends can't fork the chain while appends of other callers don't wait.
+func (w *auditRepository) Append(entry *entity.AuditEntry) error {
+	entry.Chain = 0
+	if entry.UserId != nil {
+		entry.Chain = *entry.UserId
+	}
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := advisoryLock(tx, auditLockNamespace, entry.Chain); err != nil {
+			return err
+		}
+		last := &entity.AuditEntry{}
+		err := tx.Where("chain = ?", entry.Chain).Order("seq desc").First(last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			entry.Seq, entry.PrevHash = 1, ""
+		case err != nil:
+			return err
+		default:
+			entry.Seq, entry.PrevHash = last.Seq+1, last.Hash
+		}
+		// the database keeps microseconds, the hash must survive the round trip
+		entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		entry.Hash = entry.ChainHash()
+		return tx.Create(entry).Error
+	})
+}
+
+func (w *auditRepository) GetEntry(chain, seq uint64) (*entity.AuditEntry, error) {
+	entry := &entity.AuditEntry{}
+	result := w.db.Where("chain = ? AND seq = ?", chain, seq).First(entry)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entry, nil
+}
+
+func (w *auditRepository) ForEachEntry(start, end time.Time, batchSize int, fn func(entry entity.AuditEntry) error) error {
+	var batch []entity.AuditEntry
+	query := w.db.Model(&entity.AuditEntry{})
+	if !start.IsZero() {
+		query = query.Where("created_at >= ?", start)
+	}
+	if !end.IsZero() {
+		query = query.Where("created_at < ?", end)
+	}
+	result := query.FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+		for _, entry := range batch {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result.Error
+}
+
+// ProtectAuditLog makes the audit table append-only for every database client, not just this service.
+func ProtectAuditLog(db *gorm.DB) error {
+	table := entity.AuditEntry{}.TableName()
+	err := db.Exec(`CREATE OR REPLACE FUNCTION portmonetka.audit_log_append_only() RETURNS trigger AS $$
+	BEGIN
+		RAISE EXCEPTION 'audit log entries can''t be changed or deleted';
+	END $$ LANGUAGE plpgsql`).Error
+	if err != nil {
+		return err
+	}
+	return db.Exec(`DO $$ BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'audit_log_append_only') THEN
+			CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE OR TRUNCATE ON ` + table + `
+				FOR EACH STATEMENT EXECUTE FUNCTION portmonetka.audit_log_append_only();
+		END IF;
+	END $$`).Error
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+)
+
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) repository.BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+func (w *budgetRepository) GetBudgetById(id uint64) (*entity.Budget, error) {
+	budget := &entity.Budget{}
+	result := w.db.First(budget, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return budget, nil
+}
+
+func (w *budgetRepository) GetBudgetsByCategoryId(categoryId uint64) ([]entity.Budget, error) {
+	var budgets []entity.Budget
+	result := w.db.
+		Where("category_id = ?", categoryId).
+		Order("id").
+		Find(&budgets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return budgets, nil
+}
+
+func (w *budgetRepository) GetBudgetsByUserId(userId uint64) ([]entity.Budget, error) {
+	var budgets []entity.Budget
+	result := w.db.
+		Where("category_id IN (?)", accessibleCategoryIds(w.db, userId)).
+		Order("category_id, id").
+		Find(&budgets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return budgets, nil
+}
+
+func (w *budgetRepository) CreateBudget(budget *entity.Budget) (*entity.Budget, error) {
+	if err := w.db.Create(budget).Error; err != nil {
+		return nil, translateBudgetError(err)
+	}
+	return budget, nil
+}
+
+func (w *budgetRepository) UpdateBudget(budget *entity.Budget) (*entity.Budget, error) {
+	if err := w.db.Save(budget).Error; err != nil {
+		return nil, translateBudgetError(err)
+	}
+	return budget, nil
+}
+
+func (w *budgetRepository) DeleteBudget(id uint64) error {
+	return w.db.Delete(&entity.Budget{}, id).Error
+}
+
+func translateBudgetError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == entity.BudgetPeriodIndex {
+		return serviceerror.BudgetAlreadyExists
+	}
+	return err
+}
@@ -1,11 +1,23 @@
 package repo
 
 import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"strings"
+	"time"
 )
 
+// uniqueViolation is the Postgres SQLSTATE of unique constraint violation
+const uniqueViolation = "23505"
+
+var nextChangeSeq = gorm.Expr("nextval('" + entity.CategoryChangeSequence + "')")
+
 type categoryRepository struct {
 	db        *gorm.DB
 	tableName string
@@ -15,10 +27,10 @@ func NewCategoryRepository(db *gorm.DB) repository.CategoryRepository {
 	return &categoryRepository{db: db, tableName: entity.Category{}.TableName()}
 }
 
-func (w *categoryRepository) ExistsWithName(userId uint64, name string) bool {
-	var count int64
-	w.db.Model(&entity.Category{}).Where("user_id = ? AND name = ?", userId, name).Count(&count)
-	return count == 1
+func (w *categoryRepository) Transaction(fn func(categoryRepository repository.CategoryRepository) error) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&categoryRepository{db: tx, tableName: w.tableName})
+	})
 }
 
 func (w *categoryRepository) GetCategoryById(id uint64) (*entity.Category, error) {
@@ -30,18 +42,88 @@ func (w *categoryRepository) GetCategoryById(id uint64) (*entity.Category, error
 	return category, nil
 }
 
-func (w *categoryRepository) CategoryBelongsToUser(id, userId uint64) bool {
-	category, err := w.GetCategoryById(id)
-	if err != nil || category == nil {
-		return false
+func (w *categoryRepository) GetCategoryByIdUnscoped(id uint64) (*entity.Category, error) {
+	category := &entity.Category{}
+	result := w.db.Unscoped().First(category, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return category, nil
+}
+
+func (w *categoryRepository) UserCanAccessCategory(id, userId uint64, role entity.HouseholdRole) bool {
+	var count int64
+	result := w.db.
+		Unscoped().
+		Model(&entity.Category{}).
+		Where("id = ?", id).
+		Where("(user_id = ? AND household_id IS NULL) OR household_id IN (?)", userId,
+			w.db.Model(&entity.HouseholdMember{}).Select("household_id").
+				Where("user_id = ? AND role IN ?", userId, entity.RolesAllowing(role))).
+		Count(&count)
+	return result.Error == nil && count > 0
+}
+
+// accessibleBy limits the query to user's personal categories and categories of user's households.
+func (w *categoryRepository) accessibleBy(userId uint64) func(db *gorm.DB) *gorm.DB {
+	return categoriesAccessibleBy(w.db, userId)
+}
+
+func categoriesAccessibleBy(db *gorm.DB, userId uint64) func(db *gorm.DB) *gorm.DB {
+	households := db.Model(&entity.HouseholdMember{}).Select("household_id").Where("user_id = ?", userId)
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(user_id = ? AND household_id IS NULL) OR household_id IN (?)", userId, households)
 	}
-	return category.UserId == userId
+}
+
+// accessibleCategoryIds is the subquery of ids of the live categories the user can access,
+// so data attached to categories is shared the same way the categories are.
+func accessibleCategoryIds(db *gorm.DB, userId uint64) *gorm.DB {
+	return db.Model(&entity.Category{}).Select("id").Scopes(categoriesAccessibleBy(db, userId))
+}
+
+func (w *categoryRepository) GetCategoryByExternalId(userId uint64, externalId string) (*entity.Category, error) {
+	category := &entity.Category{}
+	result := w.db.
+		Scopes(w.accessibleBy(userId)).
+		Where("external_id = ?", externalId).
+		Order("household_id IS NOT NULL, id").
+		First(category)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return category, nil
+}
+
+// HasTransferCategory looks the category up by the partial CategoryTransferIndex.
+func (w *categoryRepository) HasTransferCategory(userId uint64) (bool, error) {
+	var exists bool
+	err := w.db.
+		Raw("SELECT EXISTS (SELECT 1 FROM "+w.tableName+" WHERE user_id = ? AND type = ? AND deleted_at IS NULL)",
+			userId, entity.Transfer).
+		Scan(&exists).Error
+	return exists, err
 }
 
 func (w *categoryRepository) GetCategoriesByUserId(userId uint64) ([]entity.Category, error) {
 	var categories []entity.Category
 	result := w.db.
-		Where("user_id = ?", userId).
+		Scopes(w.accessibleBy(userId)).
+		Order("updated_at desc").
+		Find(&categories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return categories, nil
+}
+
+func (w *categoryRepository) GetCategoriesByAccountId(userId uint64, accountId string) ([]entity.Category, error) {
+	var categories []entity.Category
+	linked := w.db.Model(&entity.CategoryAccount{}).Select("category_id")
+	linkedToAccount := w.db.Model(&entity.CategoryAccount{}).Select("category_id").Where("account_id = ?", accountId)
+	result := w.db.
+		Scopes(w.accessibleBy(userId)).
+		Where("id NOT IN (?) OR id IN (?)", linked, linkedToAccount).
 		Order("updated_at desc").
 		Find(&categories)
 	if result.Error != nil {
@@ -50,18 +132,327 @@ func (w *categoryRepository) GetCategoriesByUserId(userId uint64) ([]entity.Cate
 	return categories, nil
 }
 
+func (w *categoryRepository) GetCategoriesByFilter(userId uint64, filter entity.GroupFilter) ([]entity.Category, error) {
+	var categories []entity.Category
+	expr := filterExpr(filter)
+	result := w.db.
+		Scopes(w.accessibleBy(userId)).
+		Where(expr.SQL, expr.Vars...).
+		Order("name").
+		Find(&categories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return categories, nil
+}
+
+// filterExpr translates the smart group filter tree to a condition on categories.
+func filterExpr(filter entity.GroupFilter) clause.Expr {
+	switch filter.Op {
+	case entity.FilterAnd, entity.FilterOr:
+		if len(filter.Filters) == 0 {
+			break
+		}
+		parts := make([]string, len(filter.Filters))
+		var vars []any
+		for i, child := range filter.Filters {
+			expr := filterExpr(child)
+			parts[i] = "(" + expr.SQL + ")"
+			vars = append(vars, expr.Vars...)
+		}
+		separator := " AND "
+		if filter.Op == entity.FilterOr {
+			separator = " OR "
+		}
+		return clause.Expr{SQL: strings.Join(parts, separator), Vars: vars}
+	case entity.FilterNot:
+		if len(filter.Filters) != 1 {
+			break
+		}
+		expr := filterExpr(filter.Filters[0])
+		return clause.Expr{SQL: "NOT (" + expr.SQL + ")", Vars: expr.Vars}
+	case entity.FilterTag:
+		return clause.Expr{
+			SQL:  "id IN (SELECT category_id FROM " + entity.CategoryTag{}.TableName() + " WHERE tag_id = ?)",
+			Vars: []any{filter.TagId},
+		}
+	case entity.FilterType:
+		return clause.Expr{SQL: "type = ?", Vars: []any{filter.Type}}
+	}
+	return clause.Expr{SQL: "FALSE"}
+}
+
+func (w *categoryRepository) ForEachCategoryByUserId(userId uint64, batchSize int, fn func(category entity.Category) error) error {
+	var batch []entity.Category
+	result := w.db.
+		Scopes(w.accessibleBy(userId)).
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			for _, category := range batch {
+				if err := fn(category); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	return result.Error
+}
+
+// GetChangedCategories returns categories of user's feed after the position (sinceSeq, sinceId).
+// Categories of households the user joined are placed at the joining, ones of households
+// the user left at the leaving, so joins and departures reach the feed too.
+func (w *categoryRepository) GetChangedCategories(userId, sinceSeq, sinceId uint64, limit int) ([]entity.CategoryChange, error) {
+	var changes []entity.CategoryChange
+	categories := entity.Category{}.TableName()
+	members := entity.HouseholdMember{}.TableName()
+	result := w.db.
+		Raw(`SELECT * FROM (
+			SELECT c.*, GREATEST(c.change_seq, COALESCE(m.joined_seq, 0)) AS feed_seq,
+				COALESCE(m.joined_seq, 0) AS joined_seq, NULL::timestamptz AS revoked_at
+			FROM `+categories+` c
+			LEFT JOIN `+members+` m ON m.household_id = c.household_id AND m.user_id = @user AND m.deleted_at IS NULL
+			WHERE ((c.user_id = @user AND c.household_id IS NULL) OR m.user_id IS NOT NULL)
+				AND (c.change_seq >= @seq OR m.joined_seq >= @seq)
+			UNION ALL
+			SELECT c.*, m.left_seq, m.joined_seq, m.deleted_at
+			FROM `+categories+` c
+			JOIN `+members+` m ON m.household_id = c.household_id AND m.user_id = @user AND m.deleted_at IS NOT NULL
+			WHERE m.left_seq >= @seq
+		) changes
+		WHERE feed_seq > @seq OR (feed_seq = @seq AND id > @id)
+		ORDER BY feed_seq, id
+		LIMIT @limit`,
+			map[string]any{"user": userId, "seq": sinceSeq, "id": sinceId, "limit": limit}).
+		Scan(&changes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return changes, nil
+}
+
 func (w *categoryRepository) CreateCategory(category *entity.Category) (*entity.Category, error) {
-	if err := w.db.Create(category).Error; err != nil {
+	err := w.withCategoryLock(category, func(tx *gorm.DB) error {
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
+		return recordHistory(tx, entity.HistoryCreate, category.UserId, nil, category)
+	})
+	if err != nil {
+		return nil, TranslateCategoryError(err)
+	}
+	return category, nil
+}
+
+// UpdateCategory writes the category only if its stored version is still expectedVersion.
+func (w *categoryRepository) UpdateCategory(category *entity.Category, expectedVersion, actorId uint64) (*entity.Category, error) {
+	return w.updateCategory(category, expectedVersion, actorId, entity.HistoryUpdate)
+}
+
+// RevertCategory writes the category restored from its history like UpdateCategory.
+func (w *categoryRepository) RevertCategory(category *entity.Category, expectedVersion, actorId uint64) (*entity.Category, error) {
+	return w.updateCategory(category, expectedVersion, actorId, entity.HistoryRevert)
+}
+
+func (w *categoryRepository) updateCategory(category *entity.Category, expectedVersion, actorId uint64, action entity.HistoryAction) (*entity.Category, error) {
+	err := w.withCategoryLock(category, func(tx *gorm.DB) error {
+		before := &entity.Category{}
+		if err := tx.First(before, category.Id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return serviceerror.CategoryVersionMismatch
+			}
+			return err
+		}
+		if err := tx.Raw("SELECT ?", nextChangeSeq).Scan(&category.ChangeSeq).Error; err != nil {
+			return err
+		}
+		category.Version = expectedVersion + 1
+		result := tx.Model(category).
+			Where("version = ?", expectedVersion).
+			Select("*").
+			Omit("id", "user_id", "created_at", "created_seq", "deleted_at").
+			Updates(category)
+		if result.Error != nil {
+			return TranslateCategoryError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return serviceerror.CategoryVersionMismatch
+		}
+		return recordHistory(tx, action, actorId, before, category)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return category, nil
 }
 
-func (w *categoryRepository) UpdateCategory(category *entity.Category) (*entity.Category, error) {
-	err := w.db.Save(category).Error
-	return category, err
+// DeleteCategory soft deletes the category. Zero expectedVersion deletes any version.
+func (w *categoryRepository) DeleteCategory(id, expectedVersion, actorId uint64) error {
+	category, err := w.GetCategoryById(id)
+	if err != nil {
+		return err
+	}
+	return w.withCategoryLock(category, func(tx *gorm.DB) error {
+		before := &entity.Category{}
+		if err := tx.First(before, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return serviceerror.CategoryVersionMismatch
+			}
+			return err
+		}
+		query := tx.Model(&entity.Category{}).Where("id = ?", id)
+		if expectedVersion > 0 {
+			query = query.Where("version = ?", expectedVersion)
+		}
+		result := query.Updates(map[string]any{
+			"deleted_at": time.Now(),
+			"version":    gorm.Expr("version + 1"),
+			"change_seq": nextChangeSeq,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return serviceerror.CategoryVersionMismatch
+		}
+		return recordHistory(tx, entity.HistoryDelete, actorId, before, nil)
+	})
+}
+
+// recordHistory appends the change to the category history in the transaction of the change.
+func recordHistory(tx *gorm.DB, action entity.HistoryAction, actorId uint64, before, after *entity.Category) error {
+	entry := &entity.CategoryHistory{
+		ActorId: actorId,
+		Action:  action,
+		Before:  before,
+		After:   after,
+	}
+	if after != nil {
+		entry.CategoryId, entry.Version = after.Id, after.Version
+	} else {
+		entry.CategoryId, entry.Version = before.Id, before.Version+1
+	}
+	return tx.Create(entry).Error
+}
+
+func (w *categoryRepository) GetCategoryHistory(categoryId uint64) ([]entity.CategoryHistory, error) {
+	var history []entity.CategoryHistory
+	result := w.db.
+		Where("category_id = ?", categoryId).
+		Order("id desc").
+		Find(&history)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return history, nil
 }
 
-func (w *categoryRepository) DeleteCategory(id uint64) error {
-	return w.db.Delete(&entity.Category{}, id).Error
+func (w *categoryRepository) GetCategoryVersion(categoryId, version uint64) (*entity.Category, error) {
+	entry := &entity.CategoryHistory{}
+	result := w.db.
+		Where("category_id = ? AND version = ? AND action <> ?", categoryId, version, entity.HistoryDelete).
+		First(entry)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if entry.After == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return entry.After, nil
+}
+
+func (w *categoryRepository) GetCategoriesAsOf(userId uint64, asOf time.Time) ([]entity.Category, error) {
+	var history []entity.CategoryHistory
+	result := w.db.
+		Raw("SELECT DISTINCT ON (category_id) * FROM "+entity.CategoryHistory{}.TableName()+
+			" WHERE category_id IN (?) AND created_at <= ? ORDER BY category_id, id DESC",
+			w.db.Unscoped().Model(&entity.Category{}).Select("id").Scopes(w.accessibleBy(userId)), asOf).
+		Scan(&history)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	categories := make([]entity.Category, 0, len(history))
+	for _, entry := range history {
+		if entry.Action != entity.HistoryDelete && entry.After != nil {
+			categories = append(categories, *entry.After)
+		}
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].UpdatedAt.After(categories[j].UpdatedAt)
+	})
+	return categories, nil
+}
+
+// BackfillCategoryHistory records categories changed before the history was kept as created
+// in their current state, deleted ones also as deleted. Their earlier versions are unknown.
+func BackfillCategoryHistory(db *gorm.DB) error {
+	var batch []entity.Category
+	result := db.
+		Unscoped().
+		Where("id NOT IN (?)", db.Model(&entity.CategoryHistory{}).Select("category_id")).
+		FindInBatches(&batch, 500, func(_ *gorm.DB, _ int) error {
+			entries := make([]entity.CategoryHistory, 0, len(batch))
+			for i := range batch {
+				category := batch[i]
+				created := entity.CategoryHistory{
+					CategoryId: category.Id,
+					ActorId:    category.UserId,
+					Action:     entity.HistoryCreate,
+					Version:    category.Version,
+					After:      &category,
+					CreatedAt:  category.CreatedAt,
+				}
+				if !category.DeletedAt.Valid {
+					entries = append(entries, created)
+					continue
+				}
+				live := category
+				live.Version--
+				created.Version, created.After = live.Version, &live
+				entries = append(entries, created, entity.CategoryHistory{
+					CategoryId: category.Id,
+					ActorId:    category.UserId,
+					Action:     entity.HistoryDelete,
+					Version:    category.Version,
+					Before:     &category,
+					CreatedAt:  category.DeletedAt.Time,
+				})
+			}
+			return db.Create(&entries).Error
+		})
+	return result.Error
+}
+
+// withCategoryLock runs fn in a transaction holding locks of every change feed showing the category:
+// the author's one for personal categories, the members' ones for household categories.
+func (w *categoryRepository) withCategoryLock(category *entity.Category, fn func(tx *gorm.DB) error) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if category.HouseholdId != nil {
+			err = lockHousehold(tx, *category.HouseholdId)
+		} else {
+			err = lockFeeds(tx, category.UserId)
+		}
+		if err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// TranslateCategoryError turns violations of category unique indexes into service errors,
+// other errors are returned as they are.
+func TranslateCategoryError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return err
+	}
+	switch pgErr.ConstraintName {
+	case entity.CategoryNameIndex, entity.CategoryHouseholdNameIndex:
+		return serviceerror.CategoryAlreadyExists
+	case entity.CategoryExternalIdIndex:
+		return serviceerror.CategoryExternalIdExists
+	case entity.CategoryTransferIndex:
+		return serviceerror.TransferCategoryExists
+	default:
+		return err
+	}
 }
@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+	"time"
+)
+
+type householdRepository struct {
+	db *gorm.DB
+}
+
+func NewHouseholdRepository(db *gorm.DB) repository.HouseholdRepository {
+	return &householdRepository{db: db}
+}
+
+func (w *householdRepository) GetHouseholdById(id uint64) (*entity.Household, error) {
+	household := &entity.Household{}
+	result := w.db.First(household, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return household, nil
+}
+
+func (w *householdRepository) GetHouseholdsByUserId(userId uint64) ([]entity.Household, error) {
+	var households []entity.Household
+	result := w.db.
+		Where("id IN (?)", w.db.Model(&entity.HouseholdMember{}).Select("household_id").Where("user_id = ?", userId)).
+		Order("id").
+		Find(&households)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return households, nil
+}
+
+func (w *householdRepository) CreateHousehold(household *entity.Household) (*entity.Household, error) {
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(household).Error; err != nil {
+			return err
+		}
+		return tx.Create(&entity.HouseholdMember{
+			HouseholdId: household.Id,
+			UserId:      household.OwnerId,
+			Role:        entity.RoleOwner,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+func (w *householdRepository) GetMember(householdId, userId uint64) (*entity.HouseholdMember, error) {
+	member := &entity.HouseholdMember{}
+	result := w.db.
+		Where("household_id = ? AND user_id = ?", householdId, userId).
+		First(member)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return member, nil
+}
+
+func (w *householdRepository) GetMembers(householdId uint64) ([]entity.HouseholdMember, error) {
+	var members []entity.HouseholdMember
+	result := w.db.
+		Where("household_id = ?", householdId).
+		Order("created_at").
+		Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+// RemoveMember keeps the member soft deleted with the feed position of leaving.
+func (w *householdRepository) RemoveMember(householdId, userId uint64) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockHousehold(tx, householdId); err != nil {
+			return err
+		}
+		return tx.Model(&entity.HouseholdMember{}).
+			Where("household_id = ? AND user_id = ?", householdId, userId).
+			Updates(map[string]any{"deleted_at": time.Now(), "left_seq": nextChangeSeq}).Error
+	})
+}
+
+func (w *householdRepository) CreateInvitation(invitation *entity.HouseholdInvitation) (*entity.HouseholdInvitation, error) {
+	if err := w.db.Create(invitation).Error; err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (w *householdRepository) GetInvitationByToken(token string) (*entity.HouseholdInvitation, error) {
+	invitation := &entity.HouseholdInvitation{}
+	result := w.db.
+		Where("token = ?", token).
+		First(invitation)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation accepts the invitation only if nobody accepted it concurrently.
+func (w *householdRepository) AcceptInvitation(invitation *entity.HouseholdInvitation, member *entity.HouseholdMember) error {
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockHousehold(tx, member.HouseholdId, member.UserId); err != nil {
+			return err
+		}
+		result := tx.Model(invitation).
+			Where("accepted_at IS NULL").
+			Updates(map[string]any{"accepted_by": invitation.AcceptedBy, "accepted_at": invitation.AcceptedAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return serviceerror.InvalidInvitation
+		}
+		// the member left before, the feed places the new joining instead
+		err := tx.Unscoped().
+			Where("household_id = ? AND user_id = ? AND deleted_at IS NOT NULL", member.HouseholdId, member.UserId).
+			Delete(&entity.HouseholdMember{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(member).Error
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == entity.HouseholdMemberKey {
+		return serviceerror.AlreadyHouseholdMember
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) repository.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// CreateKey inserts the key or takes over an expired one, concurrent requests with the same key
+// are serialized by the primary key.
+func (w *idempotencyRepository) CreateKey(key *entity.IdempotencyKey) (bool, error) {
+	result := w.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "idempotency_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"fingerprint", "completed", "status_code", "headers", "body", "created_at", "expires_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "idempotency_keys.expires_at <= ?", Vars: []any{key.CreatedAt}},
+			}},
+		}).
+		Create(key)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (w *idempotencyRepository) GetKey(userId uint64, key string) (*entity.IdempotencyKey, error) {
+	idempotencyKey := &entity.IdempotencyKey{}
+	result := w.db.
+		Where("user_id = ? AND idempotency_key = ? AND expires_at > ?", userId, key, time.Now()).
+		First(idempotencyKey)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return idempotencyKey, nil
+}
+
+func (w *idempotencyRepository) CompleteKey(key *entity.IdempotencyKey) error {
+	return w.db.
+		Model(&entity.IdempotencyKey{}).
+		Where("user_id = ? AND idempotency_key = ?", key.UserId, key.Key).
+		Select("completed", "status_code", "headers", "body").
+		Updates(&entity.IdempotencyKey{
+			Completed:  true,
+			StatusCode: key.StatusCode,
+			Headers:    key.Headers,
+			Body:       key.Body,
+		}).Error
+}
+
+func (w *idempotencyRepository) DeleteKey(userId uint64, key string) error {
+	return w.db.
+		Where("user_id = ? AND idempotency_key = ?", userId, key).
+		Delete(&entity.IdempotencyKey{}).Error
+}
+
+func (w *idempotencyRepository) DeleteExpiredKeys(before time.Time) error {
+	return w.db.
+		Where("expires_at <= ?", before).
+		Delete(&entity.IdempotencyKey{}).Error
+}
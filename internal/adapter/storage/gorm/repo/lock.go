@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"gorm.io/gorm"
+	"slices"
+)
+
+const (
+	// feedLockNamespace serializes changes shown in the change feed of a user
+	feedLockNamespace = 1
+	// auditLockNamespace serializes appends to the audit chain of a user
+	auditLockNamespace = 2
+	// householdLockNamespace serializes changes of household membership with changes of household categories
+	householdLockNamespace = 3
+	// spendingLockNamespace serializes recording of spending totals of a category
+	spendingLockNamespace = 4
+)
+
+func advisoryLock(tx *gorm.DB, namespace int, key uint64) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", namespace, int32(key)).Error
+}
+
+// advisoryLocks takes the locks of the ids in ascending key order to avoid deadlocks.
+func advisoryLocks(tx *gorm.DB, namespace int, ids ...uint64) error {
+	keys := make([]int32, len(ids))
+	for i, id := range ids {
+		keys[i] = int32(id)
+	}
+	slices.Sort(keys)
+	for _, key := range slices.Compact(keys) {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", namespace, key).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockFeeds locks change feeds of the users, so change sequence numbers are committed in the same
+// order they are taken and no feed skips one.
+func lockFeeds(tx *gorm.DB, userIds ...uint64) error {
+	return advisoryLocks(tx, feedLockNamespace, userIds...)
+}
+
+// lockHousehold locks the household and the feeds of its members and of the extra users. The household
+// lock is always taken before feed locks and keeps the members from changing until the commit.
+func lockHousehold(tx *gorm.DB, householdId uint64, extraUserIds ...uint64) error {
+	if err := advisoryLock(tx, householdLockNamespace, householdId); err != nil {
+		return err
+	}
+	var memberIds []uint64
+	err := tx.Model(&entity.HouseholdMember{}).
+		Where("household_id = ?", householdId).
+		Pluck("user_id", &memberIds).Error
+	if err != nil {
+		return err
+	}
+	return lockFeeds(tx, append(memberIds, extraUserIds...)...)
+}
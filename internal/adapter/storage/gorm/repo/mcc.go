@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+)
+
+type mccMappingRepository struct {
+	db *gorm.DB
+}
+
+func NewMccMappingRepository(db *gorm.DB) repository.MccMappingRepository {
+	return &mccMappingRepository{db: db}
+}
+
+func (w *mccMappingRepository) MccMappingBelongsToUser(id, userId uint64) bool {
+	mapping, err := w.GetMccMappingById(id)
+	if err != nil || mapping == nil {
+		return false
+	}
+	return mapping.UserId == userId
+}
+
+func (w *mccMappingRepository) GetMccMappingById(id uint64) (*entity.MccMapping, error) {
+	mapping := &entity.MccMapping{}
+	result := w.db.First(mapping, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return mapping, nil
+}
+
+func (w *mccMappingRepository) GetMccMappingsByUserId(userId uint64) ([]entity.MccMapping, error) {
+	var mappings []entity.MccMapping
+	result := w.db.
+		Where("user_id = ?", userId).
+		Order("mcc_from, mcc_to").
+		Find(&mappings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return mappings, nil
+}
+
+func (w *mccMappingRepository) GetMccMappingsByMcc(userId uint64, mcc string) ([]entity.MccMapping, error) {
+	var mappings []entity.MccMapping
+	result := w.db.
+		Where("user_id = ? AND mcc_from <= ? AND mcc_to >= ?", userId, mcc, mcc).
+		Order("id").
+		Find(&mappings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return mappings, nil
+}
+
+func (w *mccMappingRepository) CreateMccMapping(mapping *entity.MccMapping) (*entity.MccMapping, error) {
+	if err := w.db.Create(mapping).Error; err != nil {
+		return nil, translateMccMappingError(err)
+	}
+	return mapping, nil
+}
+
+func (w *mccMappingRepository) UpdateMccMapping(mapping *entity.MccMapping) (*entity.MccMapping, error) {
+	if err := w.db.Save(mapping).Error; err != nil {
+		return nil, translateMccMappingError(err)
+	}
+	return mapping, nil
+}
+
+func (w *mccMappingRepository) DeleteMccMapping(id uint64) error {
+	return w.db.Delete(&entity.MccMapping{}, id).Error
+}
+
+func translateMccMappingError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == entity.MccMappingRangeIndex {
+		return serviceerror.MccMappingExists
+	}
+	return err
+}
@@ -11,8 +11,10 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	repository "github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,46 +57,76 @@ func (mr *MockCategoryRepositoryMockRecorder) CreateCategory(category any) *gomo
 }
 
 // DeleteCategory mocks base method.
-func (m *MockCategoryRepository) DeleteCategory(id uint64) error {
+func (m *MockCategoryRepository) DeleteCategory(id, expectedVersion, actorId uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", id)
+	ret := m.ctrl.Call(m, "DeleteCategory", id, expectedVersion, actorId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(id any) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(id, expectedVersion, actorId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), id, expectedVersion, actorId)
 }
 
-// ExistsWithName mocks base method.
-func (m *MockCategoryRepository) ExistsWithName(userId uint64, name string) bool {
+// ForEachCategoryByUserId mocks base method.
+func (m *MockCategoryRepository) ForEachCategoryByUserId(userId uint64, batchSize int, fn func(entity.Category) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExistsWithName", userId, name)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "ForEachCategoryByUserId", userId, batchSize, fn)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ExistsWithName indicates an expected call of ExistsWithName.
-func (mr *MockCategoryRepositoryMockRecorder) ExistsWithName(userId, name any) *gomock.Call {
+// ForEachCategoryByUserId indicates an expected call of ForEachCategoryByUserId.
+func (mr *MockCategoryRepositoryMockRecorder) ForEachCategoryByUserId(userId, batchSize, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsWithName", reflect.TypeOf((*MockCategoryRepository)(nil).ExistsWithName), userId, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachCategoryByUserId", reflect.TypeOf((*MockCategoryRepository)(nil).ForEachCategoryByUserId), userId, batchSize, fn)
 }
 
-// GetCategoryById mocks base method.
-func (m *MockCategoryRepository) GetCategoryById(id uint64) (*entity.Category, error) {
+// GetCategoriesAsOf mocks base method.
+func (m *MockCategoryRepository) GetCategoriesAsOf(userId uint64, asOf time.Time) ([]entity.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoryById", id)
-	ret0, _ := ret[0].(*entity.Category)
+	ret := m.ctrl.Call(m, "GetCategoriesAsOf", userId, asOf)
+	ret0, _ := ret[0].([]entity.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategoryById indicates an expected call of GetCategoryById.
-func (mr *MockCategoryRepositoryMockRecorder) GetCategoryById(id any) *gomock.Call {
+// GetCategoriesAsOf indicates an expected call of GetCategoriesAsOf.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoriesAsOf(userId, asOf any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryById", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesAsOf", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoriesAsOf), userId, asOf)
+}
+
+// GetCategoriesByAccountId mocks base method.
+func (m *MockCategoryRepository) GetCategoriesByAccountId(userId uint64, accountId string) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesByAccountId", userId, accountId)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesByAccountId indicates an expected call of GetCategoriesByAccountId.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoriesByAccountId(userId, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByAccountId", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoriesByAccountId), userId, accountId)
+}
+
+// GetCategoriesByFilter mocks base method.
+func (m *MockCategoryRepository) GetCategoriesByFilter(userId uint64, filter entity.GroupFilter) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesByFilter", userId, filter)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesByFilter indicates an expected call of GetCategoriesByFilter.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoriesByFilter(userId, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByFilter", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoriesByFilter), userId, filter)
 }
 
 // GetCategoriesByUserId mocks base method.
@@ -112,31 +144,1457 @@ func (mr *MockCategoryRepositoryMockRecorder) GetCategoriesByUserId(userId any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByUserId", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoriesByUserId), userId)
 }
 
+// GetCategoryByExternalId mocks base method.
+func (m *MockCategoryRepository) GetCategoryByExternalId(userId uint64, externalId string) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByExternalId", userId, externalId)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByExternalId indicates an expected call of GetCategoryByExternalId.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoryByExternalId(userId, externalId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByExternalId", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryByExternalId), userId, externalId)
+}
+
+// GetCategoryById mocks base method.
+func (m *MockCategoryRepository) GetCategoryById(id uint64) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryById", id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryById indicates an expected call of GetCategoryById.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoryById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryById", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryById), id)
+}
+
+// GetCategoryByIdUnscoped mocks base method.
+func (m *MockCategoryRepository) GetCategoryByIdUnscoped(id uint64) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByIdUnscoped", id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByIdUnscoped indicates an expected call of GetCategoryByIdUnscoped.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoryByIdUnscoped(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByIdUnscoped", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryByIdUnscoped), id)
+}
+
+// GetCategoryHistory mocks base method.
+func (m *MockCategoryRepository) GetCategoryHistory(categoryId uint64) ([]entity.CategoryHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryHistory", categoryId)
+	ret0, _ := ret[0].([]entity.CategoryHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryHistory indicates an expected call of GetCategoryHistory.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoryHistory(categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryHistory", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryHistory), categoryId)
+}
+
+// GetCategoryVersion mocks base method.
+func (m *MockCategoryRepository) GetCategoryVersion(categoryId, version uint64) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryVersion", categoryId, version)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryVersion indicates an expected call of GetCategoryVersion.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoryVersion(categoryId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryVersion", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryVersion), categoryId, version)
+}
+
+// GetChangedCategories mocks base method.
+func (m *MockCategoryRepository) GetChangedCategories(userId, sinceSeq, sinceId uint64, limit int) ([]entity.CategoryChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedCategories", userId, sinceSeq, sinceId, limit)
+	ret0, _ := ret[0].([]entity.CategoryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangedCategories indicates an expected call of GetChangedCategories.
+func (mr *MockCategoryRepositoryMockRecorder) GetChangedCategories(userId, sinceSeq, sinceId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedCategories", reflect.TypeOf((*MockCategoryRepository)(nil).GetChangedCategories), userId, sinceSeq, sinceId, limit)
+}
+
+// HasTransferCategory mocks base method.
+func (m *MockCategoryRepository) HasTransferCategory(userId uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasTransferCategory", userId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasTransferCategory indicates an expected call of HasTransferCategory.
+func (mr *MockCategoryRepositoryMockRecorder) HasTransferCategory(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTransferCategory", reflect.TypeOf((*MockCategoryRepository)(nil).HasTransferCategory), userId)
+}
+
+// RevertCategory mocks base method.
+func (m *MockCategoryRepository) RevertCategory(category *entity.Category, expectedVersion, actorId uint64) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertCategory", category, expectedVersion, actorId)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertCategory indicates an expected call of RevertCategory.
+func (mr *MockCategoryRepositoryMockRecorder) RevertCategory(category, expectedVersion, actorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertCategory", reflect.TypeOf((*MockCategoryRepository)(nil).RevertCategory), category, expectedVersion, actorId)
+}
+
+// Transaction mocks base method.
+func (m *MockCategoryRepository) Transaction(fn func(repository.CategoryRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockCategoryRepositoryMockRecorder) Transaction(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockCategoryRepository)(nil).Transaction), fn)
+}
+
 // UpdateCategory mocks base method.
-func (m *MockCategoryRepository) UpdateCategory(category *entity.Category) (*entity.Category, error) {
+func (m *MockCategoryRepository) UpdateCategory(category *entity.Category, expectedVersion, actorId uint64) (*entity.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", category)
+	ret := m.ctrl.Call(m, "UpdateCategory", category, expectedVersion, actorId)
 	ret0, _ := ret[0].(*entity.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateCategory indicates an expected call of UpdateCategory.
-func (mr *MockCategoryRepositoryMockRecorder) UpdateCategory(category any) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) UpdateCategory(category, expectedVersion, actorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).UpdateCategory), category, expectedVersion, actorId)
+}
+
+// UserCanAccessCategory mocks base method.
+func (m *MockCategoryRepository) UserCanAccessCategory(id, userId uint64, role entity.HouseholdRole) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserCanAccessCategory", id, userId, role)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// UserCanAccessCategory indicates an expected call of UserCanAccessCategory.
+func (mr *MockCategoryRepositoryMockRecorder) UserCanAccessCategory(id, userId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCanAccessCategory", reflect.TypeOf((*MockCategoryRepository)(nil).UserCanAccessCategory), id, userId, role)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDelivery mocks base method.
+func (m *MockWebhookRepository) CreateDelivery(delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) CreateDelivery(delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).CreateDelivery), delivery)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookRepository) CreateWebhook(webhook *entity.Webhook) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", webhook)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhook(webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhook), webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepository) DeleteWebhook(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteWebhook(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteWebhook), id)
+}
+
+// GetDeliveriesByWebhookId mocks base method.
+func (m *MockWebhookRepository) GetDeliveriesByWebhookId(webhookId uint64, limit int) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveriesByWebhookId", webhookId, limit)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveriesByWebhookId indicates an expected call of GetDeliveriesByWebhookId.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveriesByWebhookId(webhookId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveriesByWebhookId", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveriesByWebhookId), webhookId, limit)
+}
+
+// GetEnabledWebhooksByUserId mocks base method.
+func (m *MockWebhookRepository) GetEnabledWebhooksByUserId(userId uint64) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledWebhooksByUserId", userId)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledWebhooksByUserId indicates an expected call of GetEnabledWebhooksByUserId.
+func (mr *MockWebhookRepositoryMockRecorder) GetEnabledWebhooksByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledWebhooksByUserId", reflect.TypeOf((*MockWebhookRepository)(nil).GetEnabledWebhooksByUserId), userId)
+}
+
+// GetWebhookById mocks base method.
+func (m *MockWebhookRepository) GetWebhookById(id uint64) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookById", id)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookById indicates an expected call of GetWebhookById.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookById", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookById), id)
+}
+
+// GetWebhooksByUserId mocks base method.
+func (m *MockWebhookRepository) GetWebhooksByUserId(userId uint64) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksByUserId", userId)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksByUserId indicates an expected call of GetWebhooksByUserId.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhooksByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksByUserId", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhooksByUserId), userId)
+}
+
+// RegisterFailure mocks base method.
+func (m *MockWebhookRepository) RegisterFailure(id uint64, disableAfter int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailure", id, disableAfter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockWebhookRepositoryMockRecorder) RegisterFailure(id, disableAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockWebhookRepository)(nil).RegisterFailure), id, disableAfter)
+}
+
+// ResetFailures mocks base method.
+func (m *MockWebhookRepository) ResetFailures(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailures", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailures indicates an expected call of ResetFailures.
+func (mr *MockWebhookRepositoryMockRecorder) ResetFailures(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailures", reflect.TypeOf((*MockWebhookRepository)(nil).ResetFailures), id)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookRepository) UpdateWebhook(webhook *entity.Webhook) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", webhook)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateWebhook(webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateWebhook), webhook)
+}
+
+// WebhookBelongsToUser mocks base method.
+func (m *MockWebhookRepository) WebhookBelongsToUser(id, userId uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookBelongsToUser", id, userId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// WebhookBelongsToUser indicates an expected call of WebhookBelongsToUser.
+func (mr *MockWebhookRepositoryMockRecorder) WebhookBelongsToUser(id, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookBelongsToUser", reflect.TypeOf((*MockWebhookRepository)(nil).WebhookBelongsToUser), id, userId)
+}
+
+// MockIdempotencyRepository is a mock of IdempotencyRepository interface.
+type MockIdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryMockRecorder
+}
+
+// MockIdempotencyRepositoryMockRecorder is the mock recorder for MockIdempotencyRepository.
+type MockIdempotencyRepositoryMockRecorder struct {
+	mock *MockIdempotencyRepository
+}
+
+// NewMockIdempotencyRepository creates a new mock instance.
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteKey mocks base method.
+func (m *MockIdempotencyRepository) CompleteKey(key *entity.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteKey indicates an expected call of CompleteKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) CompleteKey(key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).UpdateCategory), category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).CompleteKey), key)
 }
 
-// CategoryBelongsToUser mocks base method.
-func (m *MockCategoryRepository) CategoryBelongsToUser(id, userId uint64) bool {
+// CreateKey mocks base method.
+func (m *MockIdempotencyRepository) CreateKey(key *entity.IdempotencyKey) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CategoryBelongsToUser", id, userId)
+	ret := m.ctrl.Call(m, "CreateKey", key)
 	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) CreateKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).CreateKey), key)
+}
+
+// DeleteExpiredKeys mocks base method.
+func (m *MockIdempotencyRepository) DeleteExpiredKeys(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredKeys", before)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CategoryBelongsToUser indicates an expected call of CategoryBelongsToUser.
-func (mr *MockCategoryRepositoryMockRecorder) CategoryBelongsToUser(id, userId any) *gomock.Call {
+// DeleteExpiredKeys indicates an expected call of DeleteExpiredKeys.
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteExpiredKeys(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredKeys", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteExpiredKeys), before)
+}
+
+// DeleteKey mocks base method.
+func (m *MockIdempotencyRepository) DeleteKey(userId uint64, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKey", userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKey indicates an expected call of DeleteKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteKey(userId, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteKey), userId, key)
+}
+
+// GetKey mocks base method.
+func (m *MockIdempotencyRepository) GetKey(userId uint64, key string) (*entity.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKey", userId, key)
+	ret0, _ := ret[0].(*entity.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKey indicates an expected call of GetKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) GetKey(userId, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).GetKey), userId, key)
+}
+
+// MockMccMappingRepository is a mock of MccMappingRepository interface.
+type MockMccMappingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMccMappingRepositoryMockRecorder
+}
+
+// MockMccMappingRepositoryMockRecorder is the mock recorder for MockMccMappingRepository.
+type MockMccMappingRepositoryMockRecorder struct {
+	mock *MockMccMappingRepository
+}
+
+// NewMockMccMappingRepository creates a new mock instance.
+func NewMockMccMappingRepository(ctrl *gomock.Controller) *MockMccMappingRepository {
+	mock := &MockMccMappingRepository{ctrl: ctrl}
+	mock.recorder = &MockMccMappingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMccMappingRepository) EXPECT() *MockMccMappingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMccMapping mocks base method.
+func (m *MockMccMappingRepository) CreateMccMapping(mapping *entity.MccMapping) (*entity.MccMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMccMapping", mapping)
+	ret0, _ := ret[0].(*entity.MccMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMccMapping indicates an expected call of CreateMccMapping.
+func (mr *MockMccMappingRepositoryMockRecorder) CreateMccMapping(mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMccMapping", reflect.TypeOf((*MockMccMappingRepository)(nil).CreateMccMapping), mapping)
+}
+
+// DeleteMccMapping mocks base method.
+func (m *MockMccMappingRepository) DeleteMccMapping(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMccMapping", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMccMapping indicates an expected call of DeleteMccMapping.
+func (mr *MockMccMappingRepositoryMockRecorder) DeleteMccMapping(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMccMapping", reflect.TypeOf((*MockMccMappingRepository)(nil).DeleteMccMapping), id)
+}
+
+// GetMccMappingById mocks base method.
+func (m *MockMccMappingRepository) GetMccMappingById(id uint64) (*entity.MccMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMccMappingById", id)
+	ret0, _ := ret[0].(*entity.MccMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMccMappingById indicates an expected call of GetMccMappingById.
+func (mr *MockMccMappingRepositoryMockRecorder) GetMccMappingById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMccMappingById", reflect.TypeOf((*MockMccMappingRepository)(nil).GetMccMappingById), id)
+}
+
+// GetMccMappingsByMcc mocks base method.
+func (m *MockMccMappingRepository) GetMccMappingsByMcc(userId uint64, mcc string) ([]entity.MccMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMccMappingsByMcc", userId, mcc)
+	ret0, _ := ret[0].([]entity.MccMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMccMappingsByMcc indicates an expected call of GetMccMappingsByMcc.
+func (mr *MockMccMappingRepositoryMockRecorder) GetMccMappingsByMcc(userId, mcc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMccMappingsByMcc", reflect.TypeOf((*MockMccMappingRepository)(nil).GetMccMappingsByMcc), userId, mcc)
+}
+
+// GetMccMappingsByUserId mocks base method.
+func (m *MockMccMappingRepository) GetMccMappingsByUserId(userId uint64) ([]entity.MccMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMccMappingsByUserId", userId)
+	ret0, _ := ret[0].([]entity.MccMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMccMappingsByUserId indicates an expected call of GetMccMappingsByUserId.
+func (mr *MockMccMappingRepositoryMockRecorder) GetMccMappingsByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMccMappingsByUserId", reflect.TypeOf((*MockMccMappingRepository)(nil).GetMccMappingsByUserId), userId)
+}
+
+// MccMappingBelongsToUser mocks base method.
+func (m *MockMccMappingRepository) MccMappingBelongsToUser(id, userId uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MccMappingBelongsToUser", id, userId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// MccMappingBelongsToUser indicates an expected call of MccMappingBelongsToUser.
+func (mr *MockMccMappingRepositoryMockRecorder) MccMappingBelongsToUser(id, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MccMappingBelongsToUser", reflect.TypeOf((*MockMccMappingRepository)(nil).MccMappingBelongsToUser), id, userId)
+}
+
+// UpdateMccMapping mocks base method.
+func (m *MockMccMappingRepository) UpdateMccMapping(mapping *entity.MccMapping) (*entity.MccMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMccMapping", mapping)
+	ret0, _ := ret[0].(*entity.MccMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMccMapping indicates an expected call of UpdateMccMapping.
+func (mr *MockMccMappingRepositoryMockRecorder) UpdateMccMapping(mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMccMapping", reflect.TypeOf((*MockMccMappingRepository)(nil).UpdateMccMapping), mapping)
+}
+
+// MockRuleRepository is a mock of RuleRepository interface.
+type MockRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleRepositoryMockRecorder
+}
+
+// MockRuleRepositoryMockRecorder is the mock recorder for MockRuleRepository.
+type MockRuleRepositoryMockRecorder struct {
+	mock *MockRuleRepository
+}
+
+// NewMockRuleRepository creates a new mock instance.
+func NewMockRuleRepository(ctrl *gomock.Controller) *MockRuleRepository {
+	mock := &MockRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuleRepository) EXPECT() *MockRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockRuleRepository) CreateRule(rule *entity.Rule) (*entity.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", rule)
+	ret0, _ := ret[0].(*entity.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockRuleRepositoryMockRecorder) CreateRule(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockRuleRepository)(nil).CreateRule), rule)
+}
+
+// DeleteRule mocks base method.
+func (m *MockRuleRepository) DeleteRule(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRuleRepositoryMockRecorder) DeleteRule(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRuleRepository)(nil).DeleteRule), id)
+}
+
+// GetRuleById mocks base method.
+func (m *MockRuleRepository) GetRuleById(id uint64) (*entity.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleById", id)
+	ret0, _ := ret[0].(*entity.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleById indicates an expected call of GetRuleById.
+func (mr *MockRuleRepositoryMockRecorder) GetRuleById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleById", reflect.TypeOf((*MockRuleRepository)(nil).GetRuleById), id)
+}
+
+// GetRulesByUserId mocks base method.
+func (m *MockRuleRepository) GetRulesByUserId(userId uint64) ([]entity.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRulesByUserId", userId)
+	ret0, _ := ret[0].([]entity.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRulesByUserId indicates an expected call of GetRulesByUserId.
+func (mr *MockRuleRepositoryMockRecorder) GetRulesByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesByUserId", reflect.TypeOf((*MockRuleRepository)(nil).GetRulesByUserId), userId)
+}
+
+// RuleBelongsToUser mocks base method.
+func (m *MockRuleRepository) RuleBelongsToUser(id, userId uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RuleBelongsToUser", id, userId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RuleBelongsToUser indicates an expected call of RuleBelongsToUser.
+func (mr *MockRuleRepositoryMockRecorder) RuleBelongsToUser(id, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RuleBelongsToUser", reflect.TypeOf((*MockRuleRepository)(nil).RuleBelongsToUser), id, userId)
+}
+
+// UpdateRule mocks base method.
+func (m *MockRuleRepository) UpdateRule(rule *entity.Rule) (*entity.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", rule)
+	ret0, _ := ret[0].(*entity.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockRuleRepositoryMockRecorder) UpdateRule(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockRuleRepository)(nil).UpdateRule), rule)
+}
+
+// MockBudgetRepository is a mock of BudgetRepository interface.
+type MockBudgetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetRepositoryMockRecorder
+}
+
+// MockBudgetRepositoryMockRecorder is the mock recorder for MockBudgetRepository.
+type MockBudgetRepositoryMockRecorder struct {
+	mock *MockBudgetRepository
+}
+
+// NewMockBudgetRepository creates a new mock instance.
+func NewMockBudgetRepository(ctrl *gomock.Controller) *MockBudgetRepository {
+	mock := &MockBudgetRepository{ctrl: ctrl}
+	mock.recorder = &MockBudgetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetRepository) EXPECT() *MockBudgetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBudget mocks base method.
+func (m *MockBudgetRepository) CreateBudget(budget *entity.Budget) (*entity.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudget", budget)
+	ret0, _ := ret[0].(*entity.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudget indicates an expected call of CreateBudget.
+func (mr *MockBudgetRepositoryMockRecorder) CreateBudget(budget any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockBudgetRepository)(nil).CreateBudget), budget)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockBudgetRepository) DeleteBudget(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockBudgetRepositoryMockRecorder) DeleteBudget(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockBudgetRepository)(nil).DeleteBudget), id)
+}
+
+// GetBudgetById mocks base method.
+func (m *MockBudgetRepository) GetBudgetById(id uint64) (*entity.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetById", id)
+	ret0, _ := ret[0].(*entity.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetById indicates an expected call of GetBudgetById.
+func (mr *MockBudgetRepositoryMockRecorder) GetBudgetById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetById", reflect.TypeOf((*MockBudgetRepository)(nil).GetBudgetById), id)
+}
+
+// GetBudgetsByCategoryId mocks base method.
+func (m *MockBudgetRepository) GetBudgetsByCategoryId(categoryId uint64) ([]entity.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetsByCategoryId", categoryId)
+	ret0, _ := ret[0].([]entity.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetsByCategoryId indicates an expected call of GetBudgetsByCategoryId.
+func (mr *MockBudgetRepositoryMockRecorder) GetBudgetsByCategoryId(categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetsByCategoryId", reflect.TypeOf((*MockBudgetRepository)(nil).GetBudgetsByCategoryId), categoryId)
+}
+
+// GetBudgetsByUserId mocks base method.
+func (m *MockBudgetRepository) GetBudgetsByUserId(userId uint64) ([]entity.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetsByUserId", userId)
+	ret0, _ := ret[0].([]entity.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetsByUserId indicates an expected call of GetBudgetsByUserId.
+func (mr *MockBudgetRepositoryMockRecorder) GetBudgetsByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetsByUserId", reflect.TypeOf((*MockBudgetRepository)(nil).GetBudgetsByUserId), userId)
+}
+
+// UpdateBudget mocks base method.
+func (m *MockBudgetRepository) UpdateBudget(budget *entity.Budget) (*entity.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBudget", budget)
+	ret0, _ := ret[0].(*entity.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBudget indicates an expected call of UpdateBudget.
+func (mr *MockBudgetRepositoryMockRecorder) UpdateBudget(budget any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBudget", reflect.TypeOf((*MockBudgetRepository)(nil).UpdateBudget), budget)
+}
+
+// MockSpendingRepository is a mock of SpendingRepository interface.
+type MockSpendingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpendingRepositoryMockRecorder
+}
+
+// MockSpendingRepositoryMockRecorder is the mock recorder for MockSpendingRepository.
+type MockSpendingRepositoryMockRecorder struct {
+	mock *MockSpendingRepository
+}
+
+// NewMockSpendingRepository creates a new mock instance.
+func NewMockSpendingRepository(ctrl *gomock.Controller) *MockSpendingRepository {
+	mock := &MockSpendingRepository{ctrl: ctrl}
+	mock.recorder = &MockSpendingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpendingRepository) EXPECT() *MockSpendingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCategoryTotals mocks base method.
+func (m *MockSpendingRepository) GetCategoryTotals(categoryId uint64) ([]entity.SpendingTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTotals", categoryId)
+	ret0, _ := ret[0].([]entity.SpendingTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTotals indicates an expected call of GetCategoryTotals.
+func (mr *MockSpendingRepositoryMockRecorder) GetCategoryTotals(categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTotals", reflect.TypeOf((*MockSpendingRepository)(nil).GetCategoryTotals), categoryId)
+}
+
+// GetTotals mocks base method.
+func (m *MockSpendingRepository) GetTotals(categoryIds []uint64, start, end time.Time) ([]entity.SpendingTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotals", categoryIds, start, end)
+	ret0, _ := ret[0].([]entity.SpendingTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotals indicates an expected call of GetTotals.
+func (mr *MockSpendingRepositoryMockRecorder) GetTotals(categoryIds, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotals", reflect.TypeOf((*MockSpendingRepository)(nil).GetTotals), categoryIds, start, end)
+}
+
+// SaveTotals mocks base method.
+func (m *MockSpendingRepository) SaveTotals(totals []entity.SpendingTotal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTotals", totals)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTotals indicates an expected call of SaveTotals.
+func (mr *MockSpendingRepositoryMockRecorder) SaveTotals(totals any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTotals", reflect.TypeOf((*MockSpendingRepository)(nil).SaveTotals), totals)
+}
+
+// Transaction mocks base method.
+func (m *MockSpendingRepository) Transaction(categoryIds []uint64, fn func(repository.SpendingRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", categoryIds, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockSpendingRepositoryMockRecorder) Transaction(categoryIds, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockSpendingRepository)(nil).Transaction), categoryIds, fn)
+}
+
+// MockHouseholdRepository is a mock of HouseholdRepository interface.
+type MockHouseholdRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHouseholdRepositoryMockRecorder
+}
+
+// MockHouseholdRepositoryMockRecorder is the mock recorder for MockHouseholdRepository.
+type MockHouseholdRepositoryMockRecorder struct {
+	mock *MockHouseholdRepository
+}
+
+// NewMockHouseholdRepository creates a new mock instance.
+func NewMockHouseholdRepository(ctrl *gomock.Controller) *MockHouseholdRepository {
+	mock := &MockHouseholdRepository{ctrl: ctrl}
+	mock.recorder = &MockHouseholdRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHouseholdRepository) EXPECT() *MockHouseholdRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockHouseholdRepository) AcceptInvitation(invitation *entity.HouseholdInvitation, member *entity.HouseholdMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", invitation, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockHouseholdRepositoryMockRecorder) AcceptInvitation(invitation, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockHouseholdRepository)(nil).AcceptInvitation), invitation, member)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockHouseholdRepository) CreateHousehold(household *entity.Household) (*entity.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", household)
+	ret0, _ := ret[0].(*entity.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockHouseholdRepositoryMockRecorder) CreateHousehold(household any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockHouseholdRepository)(nil).CreateHousehold), household)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockHouseholdRepository) CreateInvitation(invitation *entity.HouseholdInvitation) (*entity.HouseholdInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", invitation)
+	ret0, _ := ret[0].(*entity.HouseholdInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockHouseholdRepositoryMockRecorder) CreateInvitation(invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockHouseholdRepository)(nil).CreateInvitation), invitation)
+}
+
+// GetHouseholdById mocks base method.
+func (m *MockHouseholdRepository) GetHouseholdById(id uint64) (*entity.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdById", id)
+	ret0, _ := ret[0].(*entity.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdById indicates an expected call of GetHouseholdById.
+func (mr *MockHouseholdRepositoryMockRecorder) GetHouseholdById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdById", reflect.TypeOf((*MockHouseholdRepository)(nil).GetHouseholdById), id)
+}
+
+// GetHouseholdsByUserId mocks base method.
+func (m *MockHouseholdRepository) GetHouseholdsByUserId(userId uint64) ([]entity.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdsByUserId", userId)
+	ret0, _ := ret[0].([]entity.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdsByUserId indicates an expected call of GetHouseholdsByUserId.
+func (mr *MockHouseholdRepositoryMockRecorder) GetHouseholdsByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdsByUserId", reflect.TypeOf((*MockHouseholdRepository)(nil).GetHouseholdsByUserId), userId)
+}
+
+// GetInvitationByToken mocks base method.
+func (m *MockHouseholdRepository) GetInvitationByToken(token string) (*entity.HouseholdInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationByToken", token)
+	ret0, _ := ret[0].(*entity.HouseholdInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationByToken indicates an expected call of GetInvitationByToken.
+func (mr *MockHouseholdRepositoryMockRecorder) GetInvitationByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByToken", reflect.TypeOf((*MockHouseholdRepository)(nil).GetInvitationByToken), token)
+}
+
+// GetMember mocks base method.
+func (m *MockHouseholdRepository) GetMember(householdId, userId uint64) (*entity.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", householdId, userId)
+	ret0, _ := ret[0].(*entity.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockHouseholdRepositoryMockRecorder) GetMember(householdId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockHouseholdRepository)(nil).GetMember), householdId, userId)
+}
+
+// GetMembers mocks base method.
+func (m *MockHouseholdRepository) GetMembers(householdId uint64) ([]entity.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", householdId)
+	ret0, _ := ret[0].([]entity.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockHouseholdRepositoryMockRecorder) GetMembers(householdId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockHouseholdRepository)(nil).GetMembers), householdId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockHouseholdRepository) RemoveMember(householdId, userId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", householdId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockHouseholdRepositoryMockRecorder) RemoveMember(householdId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockHouseholdRepository)(nil).RemoveMember), householdId, userId)
+}
+
+// MockCategoryAccountRepository is a mock of CategoryAccountRepository interface.
+type MockCategoryAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryAccountRepositoryMockRecorder
+}
+
+// MockCategoryAccountRepositoryMockRecorder is the mock recorder for MockCategoryAccountRepository.
+type MockCategoryAccountRepositoryMockRecorder struct {
+	mock *MockCategoryAccountRepository
+}
+
+// NewMockCategoryAccountRepository creates a new mock instance.
+func NewMockCategoryAccountRepository(ctrl *gomock.Controller) *MockCategoryAccountRepository {
+	mock := &MockCategoryAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockCategoryAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryAccountRepository) EXPECT() *MockCategoryAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccountId mocks base method.
+func (m *MockCategoryAccountRepository) DeleteAccountId(categoryId uint64, accountId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountId", categoryId, accountId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccountId indicates an expected call of DeleteAccountId.
+func (mr *MockCategoryAccountRepositoryMockRecorder) DeleteAccountId(categoryId, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountId", reflect.TypeOf((*MockCategoryAccountRepository)(nil).DeleteAccountId), categoryId, accountId)
+}
+
+// GetAccountIds mocks base method.
+func (m *MockCategoryAccountRepository) GetAccountIds(categoryId uint64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountIds", categoryId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountIds indicates an expected call of GetAccountIds.
+func (mr *MockCategoryAccountRepositoryMockRecorder) GetAccountIds(categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountIds", reflect.TypeOf((*MockCategoryAccountRepository)(nil).GetAccountIds), categoryId)
+}
+
+// SetAccountIds mocks base method.
+func (m *MockCategoryAccountRepository) SetAccountIds(categoryId uint64, accountIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountIds", categoryId, accountIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccountIds indicates an expected call of SetAccountIds.
+func (mr *MockCategoryAccountRepositoryMockRecorder) SetAccountIds(categoryId, accountIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountIds", reflect.TypeOf((*MockCategoryAccountRepository)(nil).SetAccountIds), categoryId, accountIds)
+}
+
+// MockTagRepository is a mock of TagRepository interface.
+type MockTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryMockRecorder
+}
+
+// MockTagRepositoryMockRecorder is the mock recorder for MockTagRepository.
+type MockTagRepositoryMockRecorder struct {
+	mock *MockTagRepository
+}
+
+// NewMockTagRepository creates a new mock instance.
+func NewMockTagRepository(ctrl *gomock.Controller) *MockTagRepository {
+	mock := &MockTagRepository{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepository) EXPECT() *MockTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignTag mocks base method.
+func (m *MockTagRepository) AssignTag(categoryId, tagId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignTag", categoryId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignTag indicates an expected call of AssignTag.
+func (mr *MockTagRepositoryMockRecorder) AssignTag(categoryId, tagId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockTagRepository)(nil).AssignTag), categoryId, tagId)
+}
+
+// CreateSmartGroup mocks base method.
+func (m *MockTagRepository) CreateSmartGroup(group *entity.SmartGroup) (*entity.SmartGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSmartGroup", group)
+	ret0, _ := ret[0].(*entity.SmartGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSmartGroup indicates an expected call of CreateSmartGroup.
+func (mr *MockTagRepositoryMockRecorder) CreateSmartGroup(group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSmartGroup", reflect.TypeOf((*MockTagRepository)(nil).CreateSmartGroup), group)
+}
+
+// CreateTag mocks base method.
+func (m *MockTagRepository) CreateTag(tag *entity.Tag) (*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", tag)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTagRepositoryMockRecorder) CreateTag(tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTagRepository)(nil).CreateTag), tag)
+}
+
+// DeleteSmartGroup mocks base method.
+func (m *MockTagRepository) DeleteSmartGroup(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSmartGroup", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSmartGroup indicates an expected call of DeleteSmartGroup.
+func (mr *MockTagRepositoryMockRecorder) DeleteSmartGroup(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSmartGroup", reflect.TypeOf((*MockTagRepository)(nil).DeleteSmartGroup), id)
+}
+
+// DeleteTag mocks base method.
+func (m *MockTagRepository) DeleteTag(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTagRepositoryMockRecorder) DeleteTag(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagRepository)(nil).DeleteTag), id)
+}
+
+// GetCategoryTags mocks base method.
+func (m *MockTagRepository) GetCategoryTags(userId, categoryId uint64) ([]entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTags", userId, categoryId)
+	ret0, _ := ret[0].([]entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTags indicates an expected call of GetCategoryTags.
+func (mr *MockTagRepositoryMockRecorder) GetCategoryTags(userId, categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTags", reflect.TypeOf((*MockTagRepository)(nil).GetCategoryTags), userId, categoryId)
+}
+
+// GetSmartGroupById mocks base method.
+func (m *MockTagRepository) GetSmartGroupById(id uint64) (*entity.SmartGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartGroupById", id)
+	ret0, _ := ret[0].(*entity.SmartGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmartGroupById indicates an expected call of GetSmartGroupById.
+func (mr *MockTagRepositoryMockRecorder) GetSmartGroupById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartGroupById", reflect.TypeOf((*MockTagRepository)(nil).GetSmartGroupById), id)
+}
+
+// GetSmartGroupsByUserId mocks base method.
+func (m *MockTagRepository) GetSmartGroupsByUserId(userId uint64) ([]entity.SmartGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartGroupsByUserId", userId)
+	ret0, _ := ret[0].([]entity.SmartGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmartGroupsByUserId indicates an expected call of GetSmartGroupsByUserId.
+func (mr *MockTagRepositoryMockRecorder) GetSmartGroupsByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartGroupsByUserId", reflect.TypeOf((*MockTagRepository)(nil).GetSmartGroupsByUserId), userId)
+}
+
+// GetTagById mocks base method.
+func (m *MockTagRepository) GetTagById(id uint64) (*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagById", id)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagById indicates an expected call of GetTagById.
+func (mr *MockTagRepositoryMockRecorder) GetTagById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagById", reflect.TypeOf((*MockTagRepository)(nil).GetTagById), id)
+}
+
+// GetTagsByUserId mocks base method.
+func (m *MockTagRepository) GetTagsByUserId(userId uint64) ([]entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagsByUserId", userId)
+	ret0, _ := ret[0].([]entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagsByUserId indicates an expected call of GetTagsByUserId.
+func (mr *MockTagRepositoryMockRecorder) GetTagsByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsByUserId", reflect.TypeOf((*MockTagRepository)(nil).GetTagsByUserId), userId)
+}
+
+// UnassignTag mocks base method.
+func (m *MockTagRepository) UnassignTag(categoryId, tagId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignTag", categoryId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignTag indicates an expected call of UnassignTag.
+func (mr *MockTagRepositoryMockRecorder) UnassignTag(categoryId, tagId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignTag", reflect.TypeOf((*MockTagRepository)(nil).UnassignTag), categoryId, tagId)
+}
+
+// UpdateSmartGroup mocks base method.
+func (m *MockTagRepository) UpdateSmartGroup(group *entity.SmartGroup) (*entity.SmartGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSmartGroup", group)
+	ret0, _ := ret[0].(*entity.SmartGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSmartGroup indicates an expected call of UpdateSmartGroup.
+func (mr *MockTagRepositoryMockRecorder) UpdateSmartGroup(group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSmartGroup", reflect.TypeOf((*MockTagRepository)(nil).UpdateSmartGroup), group)
+}
+
+// UpdateTag mocks base method.
+func (m *MockTagRepository) UpdateTag(tag *entity.Tag) (*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", tag)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockTagRepositoryMockRecorder) UpdateTag(tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockTagRepository)(nil).UpdateTag), tag)
+}
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockAuditRepository) Append(entry *entity.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockAuditRepositoryMockRecorder) Append(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockAuditRepository)(nil).Append), entry)
+}
+
+// ForEachEntry mocks base method.
+func (m *MockAuditRepository) ForEachEntry(start, end time.Time, batchSize int, fn func(entity.AuditEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachEntry", start, end, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachEntry indicates an expected call of ForEachEntry.
+func (mr *MockAuditRepositoryMockRecorder) ForEachEntry(start, end, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachEntry", reflect.TypeOf((*MockAuditRepository)(nil).ForEachEntry), start, end, batchSize, fn)
+}
+
+// GetEntry mocks base method.
+func (m *MockAuditRepository) GetEntry(chain, seq uint64) (*entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", chain, seq)
+	ret0, _ := ret[0].(*entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockAuditRepositoryMockRecorder) GetEntry(chain, seq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockAuditRepository)(nil).GetEntry), chain, seq)
+}
+
+// MockTaxRepository is a mock of TaxRepository interface.
+type MockTaxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRepositoryMockRecorder
+}
+
+// MockTaxRepositoryMockRecorder is the mock recorder for MockTaxRepository.
+type MockTaxRepositoryMockRecorder struct {
+	mock *MockTaxRepository
+}
+
+// NewMockTaxRepository creates a new mock instance.
+func NewMockTaxRepository(ctrl *gomock.Controller) *MockTaxRepository {
+	mock := &MockTaxRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRepository) EXPECT() *MockTaxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteTax mocks base method.
+func (m *MockTaxRepository) DeleteTax(categoryId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTax", categoryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTax indicates an expected call of DeleteTax.
+func (mr *MockTaxRepositoryMockRecorder) DeleteTax(categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTax", reflect.TypeOf((*MockTaxRepository)(nil).DeleteTax), categoryId)
+}
+
+// GetTaxByCategoryId mocks base method.
+func (m *MockTaxRepository) GetTaxByCategoryId(categoryId uint64) (*entity.CategoryTax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxByCategoryId", categoryId)
+	ret0, _ := ret[0].(*entity.CategoryTax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxByCategoryId indicates an expected call of GetTaxByCategoryId.
+func (mr *MockTaxRepositoryMockRecorder) GetTaxByCategoryId(categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxByCategoryId", reflect.TypeOf((*MockTaxRepository)(nil).GetTaxByCategoryId), categoryId)
+}
+
+// GetTaxesByUserId mocks base method.
+func (m *MockTaxRepository) GetTaxesByUserId(userId uint64) ([]entity.CategoryTax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxesByUserId", userId)
+	ret0, _ := ret[0].([]entity.CategoryTax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxesByUserId indicates an expected call of GetTaxesByUserId.
+func (mr *MockTaxRepositoryMockRecorder) GetTaxesByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxesByUserId", reflect.TypeOf((*MockTaxRepository)(nil).GetTaxesByUserId), userId)
+}
+
+// SaveTax mocks base method.
+func (m *MockTaxRepository) SaveTax(tax *entity.CategoryTax) (*entity.CategoryTax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTax", tax)
+	ret0, _ := ret[0].(*entity.CategoryTax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTax indicates an expected call of SaveTax.
+func (mr *MockTaxRepositoryMockRecorder) SaveTax(tax any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryBelongsToUser", reflect.TypeOf((*MockCategoryRepository)(nil).CategoryBelongsToUser), id, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTax", reflect.TypeOf((*MockTaxRepository)(nil).SaveTax), tax)
 }
@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+)
+
+type ruleRepository struct {
+	db *gorm.DB
+}
+
+func NewRuleRepository(db *gorm.DB) repository.RuleRepository {
+	return &ruleRepository{db: db}
+}
+
+func (w *ruleRepository) RuleBelongsToUser(id, userId uint64) bool {
+	rule, err := w.GetRuleById(id)
+	if err != nil || rule == nil {
+		return false
+	}
+	return rule.UserId == userId
+}
+
+func (w *ruleRepository) GetRuleById(id uint64) (*entity.Rule, error) {
+	rule := &entity.Rule{}
+	result := w.db.First(rule, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rule, nil
+}
+
+func (w *ruleRepository) GetRulesByUserId(userId uint64) ([]entity.Rule, error) {
+	var rules []entity.Rule
+	result := w.db.
+		Where("user_id = ?", userId).
+		Order("priority, id").
+		Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+func (w *ruleRepository) CreateRule(rule *entity.Rule) (*entity.Rule, error) {
+	if err := w.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (w *ruleRepository) UpdateRule(rule *entity.Rule) (*entity.Rule, error) {
+	err := w.db.Save(rule).Error
+	return rule, err
+}
+
+func (w *ruleRepository) DeleteRule(id uint64) error {
+	return w.db.Delete(&entity.Rule{}, id).Error
+}
@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type spendingRepository struct {
+	db *gorm.DB
+}
+
+func NewSpendingRepository(db *gorm.DB) repository.SpendingRepository {
+	return &spendingRepository{db: db}
+}
+
+func (w *spendingRepository) Transaction(categoryIds []uint64, fn func(spendingRepository repository.SpendingRepository) error) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := advisoryLocks(tx, spendingLockNamespace, categoryIds...); err != nil {
+			return err
+		}
+		return fn(&spendingRepository{db: tx})
+	})
+}
+
+func (w *spendingRepository) SaveTotals(totals []entity.SpendingTotal) error {
+	if len(totals) == 0 {
+		return nil
+	}
+	return w.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&totals).Error
+}
+
+func (w *spendingRepository) GetTotals(categoryIds []uint64, start, end time.Time) ([]entity.SpendingTotal, error) {
+	var totals []entity.SpendingTotal
+	if len(categoryIds) == 0 {
+		return totals, nil
+	}
+	result := w.db.
+		Where("category_id IN ? AND period_start < ? AND period_end > ?", categoryIds, end, start).
+		Order("period_start").
+		Find(&totals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return totals, nil
+}
+
+func (w *spendingRepository) GetCategoryTotals(categoryId uint64) ([]entity.SpendingTotal, error) {
+	var totals []entity.SpendingTotal
+	result := w.db.
+		Where("category_id = ?", categoryId).
+		Order("period_start").
+		Find(&totals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return totals, nil
+}
@@ -0,0 +1,140 @@
+package repo
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) repository.TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (w *tagRepository) GetTagById(id uint64) (*entity.Tag, error) {
+	tag := &entity.Tag{}
+	result := w.db.First(tag, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tag, nil
+}
+
+func (w *tagRepository) GetTagsByUserId(userId uint64) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	result := w.db.
+		Where("user_id = ?", userId).
+		Order("name").
+		Find(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tags, nil
+}
+
+func (w *tagRepository) CreateTag(tag *entity.Tag) (*entity.Tag, error) {
+	if err := w.db.Create(tag).Error; err != nil {
+		return nil, translateTagError(err)
+	}
+	return tag, nil
+}
+
+func (w *tagRepository) UpdateTag(tag *entity.Tag) (*entity.Tag, error) {
+	if err := w.db.Save(tag).Error; err != nil {
+		return nil, translateTagError(err)
+	}
+	return tag, nil
+}
+
+func (w *tagRepository) DeleteTag(id uint64) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_id = ?", id).Delete(&entity.CategoryTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Tag{}, id).Error
+	})
+}
+
+func (w *tagRepository) GetCategoryTags(userId, categoryId uint64) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	result := w.db.
+		Where("user_id = ?", userId).
+		Where("id IN (?)", w.db.Model(&entity.CategoryTag{}).Select("tag_id").Where("category_id = ?", categoryId)).
+		Order("name").
+		Find(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tags, nil
+}
+
+func (w *tagRepository) AssignTag(categoryId, tagId uint64) error {
+	return w.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.CategoryTag{CategoryId: categoryId, TagId: tagId}).Error
+}
+
+func (w *tagRepository) UnassignTag(categoryId, tagId uint64) error {
+	return w.db.
+		Where("category_id = ? AND tag_id = ?", categoryId, tagId).
+		Delete(&entity.CategoryTag{}).Error
+}
+
+func (w *tagRepository) GetSmartGroupById(id uint64) (*entity.SmartGroup, error) {
+	group := &entity.SmartGroup{}
+	result := w.db.First(group, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return group, nil
+}
+
+func (w *tagRepository) GetSmartGroupsByUserId(userId uint64) ([]entity.SmartGroup, error) {
+	var groups []entity.SmartGroup
+	result := w.db.
+		Where("user_id = ?", userId).
+		Order("name").
+		Find(&groups)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return groups, nil
+}
+
+func (w *tagRepository) CreateSmartGroup(group *entity.SmartGroup) (*entity.SmartGroup, error) {
+	if err := w.db.Create(group).Error; err != nil {
+		return nil, translateTagError(err)
+	}
+	return group, nil
+}
+
+func (w *tagRepository) UpdateSmartGroup(group *entity.SmartGroup) (*entity.SmartGroup, error) {
+	if err := w.db.Save(group).Error; err != nil {
+		return nil, translateTagError(err)
+	}
+	return group, nil
+}
+
+func (w *tagRepository) DeleteSmartGroup(id uint64) error {
+	return w.db.Delete(&entity.SmartGroup{}, id).Error
+}
+
+func translateTagError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		switch pgErr.ConstraintName {
+		case entity.TagNameIndex:
+			return serviceerror.TagAlreadyExists
+		case entity.SmartGroupNameIndex:
+			return serviceerror.SmartGroupAlreadyExists
+		}
+	}
+	return err
+}
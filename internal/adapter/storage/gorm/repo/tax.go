@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type taxRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRepository(db *gorm.DB) repository.TaxRepository {
+	return &taxRepository{db: db}
+}
+
+func (w *taxRepository) GetTaxByCategoryId(categoryId uint64) (*entity.CategoryTax, error) {
+	tax := &entity.CategoryTax{}
+	result := w.db.First(tax, categoryId)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tax, nil
+}
+
+func (w *taxRepository) GetTaxesByUserId(userId uint64) ([]entity.CategoryTax, error) {
+	var taxes []entity.CategoryTax
+	result := w.db.
+		Where("category_id IN (?)", accessibleCategoryIds(w.db, userId)).
+		Order("category_id").
+		Find(&taxes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return taxes, nil
+}
+
+func (w *taxRepository) SaveTax(tax *entity.CategoryTax) (*entity.CategoryTax, error) {
+	err := w.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(tax).Error
+	if err != nil {
+		return nil, err
+	}
+	return tax, nil
+}
+
+func (w *taxRepository) DeleteTax(categoryId uint64) error {
+	return w.db.Delete(&entity.CategoryTax{}, categoryId).Error
+}
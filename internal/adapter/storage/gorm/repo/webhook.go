@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (w *webhookRepository) WebhookBelongsToUser(id, userId uint64) bool {
+	webhook, err := w.GetWebhookById(id)
+	if err != nil || webhook == nil {
+		return false
+	}
+	return webhook.UserId == userId
+}
+
+func (w *webhookRepository) GetWebhookById(id uint64) (*entity.Webhook, error) {
+	webhook := &entity.Webhook{}
+	result := w.db.First(webhook, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return webhook, nil
+}
+
+func (w *webhookRepository) GetWebhooksByUserId(userId uint64) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	result := w.db.
+		Where("user_id = ?", userId).
+		Order("id").
+		Find(&webhooks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return webhooks, nil
+}
+
+func (w *webhookRepository) GetEnabledWebhooksByUserId(userId uint64) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	result := w.db.
+		Where("user_id = ? AND enabled", userId).
+		Order("id").
+		Find(&webhooks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return webhooks, nil
+}
+
+func (w *webhookRepository) CreateWebhook(webhook *entity.Webhook) (*entity.Webhook, error) {
+	if err := w.db.Create(webhook).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (w *webhookRepository) UpdateWebhook(webhook *entity.Webhook) (*entity.Webhook, error) {
+	err := w.db.Save(webhook).Error
+	return webhook, err
+}
+
+func (w *webhookRepository) DeleteWebhook(id uint64) error {
+	return w.db.Delete(&entity.Webhook{}, id).Error
+}
+
+// RegisterFailure increments the consecutive failures counter and disables
+// the webhook once the counter reaches disableAfter.
+func (w *webhookRepository) RegisterFailure(id uint64, disableAfter int) error {
+	return w.db.Model(&entity.Webhook{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"consecutive_failures": gorm.Expr("consecutive_failures + 1"),
+			"enabled":              gorm.Expr("enabled AND consecutive_failures + 1 < ?", disableAfter),
+		}).Error
+}
+
+func (w *webhookRepository) ResetFailures(id uint64) error {
+	return w.db.Model(&entity.Webhook{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		Update("consecutive_failures", 0).Error
+}
+
+func (w *webhookRepository) CreateDelivery(delivery *entity.WebhookDelivery) error {
+	return w.db.Create(delivery).Error
+}
+
+func (w *webhookRepository) GetDeliveriesByWebhookId(webhookId uint64, limit int) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	result := w.db.
+		Where("webhook_id = ?", webhookId).
+		Order("id desc").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+type idempotencyKeyId struct {
+	userId uint64
+	key    string
+}
+
+type idempotencyRepository struct {
+	mu   sync.Mutex
+	keys map[idempotencyKeyId]entity.IdempotencyKey
+}
+
+// NewIdempotencyRepository creates a repository that keeps idempotency keys in memory.
+// Keys are not shared between replicas and are lost on restart.
+func NewIdempotencyRepository() repository.IdempotencyRepository {
+	return &idempotencyRepository{keys: make(map[idempotencyKeyId]entity.IdempotencyKey)}
+}
+
+func (r *idempotencyRepository) CreateKey(key *entity.IdempotencyKey) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyKeyId{userId: key.UserId, key: key.Key}
+	if existing, ok := r.keys[id]; ok && existing.ExpiresAt.After(key.CreatedAt) {
+		return false, nil
+	}
+	r.keys[id] = *key
+	return true, nil
+}
+
+func (r *idempotencyRepository) GetKey(userId uint64, key string) (*entity.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.keys[idempotencyKeyId{userId: userId, key: key}]
+	if !ok || !existing.ExpiresAt.After(time.Now()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &existing, nil
+}
+
+func (r *idempotencyRepository) CompleteKey(key *entity.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyKeyId{userId: key.UserId, key: key.Key}
+	existing, ok := r.keys[id]
+	if !ok {
+		return nil
+	}
+	existing.Completed = true
+	existing.StatusCode = key.StatusCode
+	existing.Headers = key.Headers
+	existing.Body = key.Body
+	r.keys[id] = existing
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteKey(userId uint64, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, idempotencyKeyId{userId: userId, key: key})
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredKeys(before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, key := range r.keys {
+		if !key.ExpiresAt.After(before) {
+			delete(r.keys, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type spendingTotalId struct {
+	userId      uint64
+	categoryId  uint64
+	currency    string
+	periodStart int64
+	periodEnd   int64
+}
+
+type spendingRepository struct {
+	mu sync.RWMutex
+	// txMu serializes transactions of all categories, which is enough for a stand-in
+	txMu   sync.Mutex
+	totals map[spendingTotalId]entity.SpendingTotal
+	// path of the JSON file the totals are kept in, empty keeps them in memory only
+	path string
+}
+
+// NewSpendingRepository creates a repository that keeps spending totals in memory.
+// It is a stand-in for development, totals are lost on restart.
+func NewSpendingRepository() repository.SpendingRepository {
+	return &spendingRepository{totals: make(map[spendingTotalId]entity.SpendingTotal)}
+}
+
+// NewFileSpendingRepository creates a repository that loads spending totals from the JSON array
+// in the file and writes them back on every change. Missing file is created on the first change.
+// It suits a single replica, e.g. a local setup fed with exported totals.
+func NewFileSpendingRepository(path string) (repository.SpendingRepository, error) {
+	r := &spendingRepository{totals: make(map[spendingTotalId]entity.SpendingTotal), path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var totals []entity.SpendingTotal
+	if err = json.Unmarshal(data, &totals); err != nil {
+		return nil, err
+	}
+	for _, total := range totals {
+		r.totals[newSpendingTotalId(total)] = total
+	}
+	return r, nil
+}
+
+// Transaction runs fn holding the transaction lock. Changes are applied as they are made,
+// error doesn't roll them back.
+func (r *spendingRepository) Transaction(_ []uint64, fn func(spendingRepository repository.SpendingRepository) error) error {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+	return fn(r)
+}
+
+func (r *spendingRepository) SaveTotals(totals []entity.SpendingTotal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, total := range totals {
+		r.totals[newSpendingTotalId(total)] = total
+	}
+	if r.path == "" {
+		return nil
+	}
+	return r.writeFile()
+}
+
+func (r *spendingRepository) GetTotals(categoryIds []uint64, start, end time.Time) ([]entity.SpendingTotal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]entity.SpendingTotal, 0)
+	for _, total := range r.totals {
+		if slices.Contains(categoryIds, total.CategoryId) && total.PeriodStart.Before(end) && total.PeriodEnd.After(start) {
+			result = append(result, total)
+		}
+	}
+	sortTotals(result)
+	return result, nil
+}
+
+func (r *spendingRepository) GetCategoryTotals(categoryId uint64) ([]entity.SpendingTotal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]entity.SpendingTotal, 0)
+	for _, total := range r.totals {
+		if total.CategoryId == categoryId {
+			result = append(result, total)
+		}
+	}
+	sortTotals(result)
+	return result, nil
+}
+
+// writeFile replaces the file through a temporary one, so readers never see a partial file.
+func (r *spendingRepository) writeFile() error {
+	totals := make([]entity.SpendingTotal, 0, len(r.totals))
+	for _, total := range r.totals {
+		totals = append(totals, total)
+	}
+	sortTotals(totals)
+	data, err := json.MarshalIndent(totals, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), r.path)
+}
+
+func newSpendingTotalId(total entity.SpendingTotal) spendingTotalId {
+	return spendingTotalId{
+		userId:      total.UserId,
+		categoryId:  total.CategoryId,
+		currency:    total.Currency,
+		periodStart: total.PeriodStart.UnixNano(),
+		periodEnd:   total.PeriodEnd.UnixNano(),
+	}
+}
+
+func sortTotals(totals []entity.SpendingTotal) {
+	sort.Slice(totals, func(i, j int) bool {
+		if !totals[i].PeriodStart.Equal(totals[j].PeriodStart) {
+			return totals[i].PeriodStart.Before(totals[j].PeriodStart)
+		}
+		if totals[i].UserId != totals[j].UserId {
+			return totals[i].UserId < totals[j].UserId
+		}
+		return totals[i].CategoryId < totals[j].CategoryId
+	})
+}
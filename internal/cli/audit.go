@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/khivuksergey/portmonetka.category/config"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/audit"
+	"github.com/spf13/viper"
+	"io"
+	"os"
+	"time"
+)
+
+const auditUsage = "usage: audit verify|export [-config file] [-from time] [-to time] [-out file]"
+
+// RunAudit verifies or exports the audit log straight from the database, so it works
+// when the service is down. Verify fails when the chain is broken.
+func RunAudit(args []string, stdout io.Writer) error {
+	if len(args) == 0 || (args[0] != "verify" && args[0] != "export") {
+		return errors.New(auditUsage)
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet("audit "+command, flag.ContinueOnError)
+	configPath := flags.String("config", "config.json", "configuration file")
+	from := flags.String("from", "", "RFC 3339 start of the range, inclusive")
+	to := flags.String("to", "", "RFC 3339 end of the range, exclusive")
+	out := flags.String("out", "", "export file, standard output when empty")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	start, end, err := parseRange(*from, *to)
+	if err != nil {
+		return err
+	}
+
+	viper.AutomaticEnv()
+	cfg := config.LoadConfiguration(*configPath)
+	// the schema belongs to the service, the tool only reads it
+	db, err := gorm.OpenDbManager(cfg.DB)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	auditService := audit.NewAuditService(db.InitRepositoryManager())
+
+	if command == "export" {
+		w := stdout
+		if *out != "" {
+			file, err := os.Create(*out)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			w = file
+		}
+		return auditService.Export(start, end, w)
+	}
+
+	verification, err := auditService.Verify(start, end)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(verification); err != nil {
+		return err
+	}
+	if !verification.Valid {
+		return fmt.Errorf("audit log is broken at entry %d of chain %d: %s",
+			*verification.BrokenSeq, *verification.BrokenChain, verification.Reason)
+	}
+	return nil
+}
+
+func parseRange(from, to string) (start, end time.Time, err error) {
+	if from != "" {
+		if start, err = time.Parse(time.RFC3339, from); err != nil {
+			return start, end, fmt.Errorf("from: %w", err)
+		}
+	}
+	if to != "" {
+		if end, err = time.Parse(time.RFC3339, to); err != nil {
+			return start, end, fmt.Errorf("to: %w", err)
+		}
+	}
+	return start, end, nil
+}
@@ -0,0 +1,16 @@
+package event
+
+import "github.com/khivuksergey/portmonetka.category/internal/model"
+
+//go:generate mockgen -source=event.go -destination=mock/mock_event.go -package=mock
+type Publisher interface {
+	Publish(event model.Event)
+}
+
+// Broadcaster delivers published events to subscribers of the user.
+// Multi-replica deployments should provide an implementation backed by a shared bus.
+type Broadcaster interface {
+	Publisher
+	Subscribe(userId uint64) (events <-chan model.Event, unsubscribe func())
+	Close() error
+}
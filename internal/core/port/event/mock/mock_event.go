@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event.go -destination=mock/mock_event.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	model "github.com/khivuksergey/portmonetka.category/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(event model.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), event)
+}
+
+// MockBroadcaster is a mock of Broadcaster interface.
+type MockBroadcaster struct {
+	ctrl     *gomock.Controller
+	recorder *MockBroadcasterMockRecorder
+}
+
+// MockBroadcasterMockRecorder is the mock recorder for MockBroadcaster.
+type MockBroadcasterMockRecorder struct {
+	mock *MockBroadcaster
+}
+
+// NewMockBroadcaster creates a new mock instance.
+func NewMockBroadcaster(ctrl *gomock.Controller) *MockBroadcaster {
+	mock := &MockBroadcaster{ctrl: ctrl}
+	mock.recorder = &MockBroadcasterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBroadcaster) EXPECT() *MockBroadcasterMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockBroadcaster) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockBroadcasterMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockBroadcaster)(nil).Close))
+}
+
+// Publish mocks base method.
+func (m *MockBroadcaster) Publish(event model.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockBroadcasterMockRecorder) Publish(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockBroadcaster)(nil).Publish), event)
+}
+
+// Subscribe mocks base method.
+func (m *MockBroadcaster) Subscribe(userId uint64) (<-chan model.Event, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userId)
+	ret0, _ := ret[0].(<-chan model.Event)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBroadcasterMockRecorder) Subscribe(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBroadcaster)(nil).Subscribe), userId)
+}
@@ -0,0 +1,16 @@
+package importer
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"io"
+)
+
+// Importer reads categories from a file of another application.
+type Importer interface {
+	Format() model.ImportFormat
+	// Detect reports whether the beginning of the file looks like this format
+	Detect(head []byte) bool
+	// Parse reads at most maxRows categories, a file with more fails with serviceerror.TooManyImportRows
+	// without reading the rest
+	Parse(r io.Reader, maxRows int) ([]model.CategoryCreateDTO, error)
+}
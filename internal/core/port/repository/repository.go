@@ -2,19 +2,189 @@ package repository
 
 import (
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"time"
 )
 
 type Manager struct {
-	Category CategoryRepository
+	Category    CategoryRepository
+	Webhook     WebhookRepository
+	Idempotency IdempotencyRepository
+	MccMapping  MccMappingRepository
+	Rule        RuleRepository
+	Budget      BudgetRepository
+	Spending    SpendingRepository
+	Tax         TaxRepository
+	Household   HouseholdRepository
+	Account     CategoryAccountRepository
+	Tag         TagRepository
+	Audit       AuditRepository
 }
 
 //go:generate mockgen -source=repository.go -destination=../../../adapter/storage/gorm/repo/mock/mock_repository.go -package=mock
 type CategoryRepository interface {
-	ExistsWithName(userId uint64, name string) bool
-	CategoryBelongsToUser(id, userId uint64) bool
+	// Transaction runs fn with the repository bound to a single transaction, error rolls it back
+	Transaction(fn func(categoryRepository CategoryRepository) error) error
+	// UserCanAccessCategory reports whether the category, live or deleted, is user's personal one or the user
+	// is a member of its household with a role allowing the required one
+	UserCanAccessCategory(id, userId uint64, role entity.HouseholdRole) bool
 	GetCategoryById(id uint64) (*entity.Category, error)
+	// GetCategoryByIdUnscoped finds the category even when it is deleted
+	GetCategoryByIdUnscoped(id uint64) (*entity.Category, error)
+	// GetCategoryByExternalId finds the category the user can access by the external id,
+	// user's personal category first when a household one has the same external id
+	GetCategoryByExternalId(userId uint64, externalId string) (*entity.Category, error)
+	// HasTransferCategory reports whether the user has a live transfer category
+	HasTransferCategory(userId uint64) (bool, error)
+	// GetCategoriesByUserId returns categories the user can access: personal ones and ones of user's households
 	GetCategoriesByUserId(userId uint64) ([]entity.Category, error)
+	// GetCategoriesByAccountId returns categories the user can access linked to the account or to no account
+	GetCategoriesByAccountId(userId uint64, accountId string) ([]entity.Category, error)
+	// GetCategoriesByFilter returns categories the user can access matching the smart group filter
+	GetCategoriesByFilter(userId uint64, filter entity.GroupFilter) ([]entity.Category, error)
+	// ForEachCategoryByUserId calls fn for every category the user can access, loading them batchSize at a time
+	ForEachCategoryByUserId(userId uint64, batchSize int, fn func(category entity.Category) error) error
+	// GetChangedCategories returns categories of user's change feed ordered by feed position after
+	// (sinceSeq, sinceId), including categories of households the user joined or left since then
+	GetChangedCategories(userId, sinceSeq, sinceId uint64, limit int) ([]entity.CategoryChange, error)
+	// CreateCategory, UpdateCategory, RevertCategory and DeleteCategory record the change
+	// in the category history with the user who made it as the actor
 	CreateCategory(category *entity.Category) (*entity.Category, error)
-	UpdateCategory(category *entity.Category) (*entity.Category, error)
-	DeleteCategory(id uint64) error
+	UpdateCategory(category *entity.Category, expectedVersion, actorId uint64) (*entity.Category, error)
+	RevertCategory(category *entity.Category, expectedVersion, actorId uint64) (*entity.Category, error)
+	DeleteCategory(id, expectedVersion, actorId uint64) error
+	// GetCategoryHistory returns changes of the category, the latest first
+	GetCategoryHistory(categoryId uint64) ([]entity.CategoryHistory, error)
+	// GetCategoryVersion returns the category as it was at the version
+	GetCategoryVersion(categoryId, version uint64) (*entity.Category, error)
+	// GetCategoriesAsOf returns categories the user can access as they were at the time
+	GetCategoriesAsOf(userId uint64, asOf time.Time) ([]entity.Category, error)
+}
+
+type WebhookRepository interface {
+	WebhookBelongsToUser(id, userId uint64) bool
+	GetWebhookById(id uint64) (*entity.Webhook, error)
+	GetWebhooksByUserId(userId uint64) ([]entity.Webhook, error)
+	GetEnabledWebhooksByUserId(userId uint64) ([]entity.Webhook, error)
+	CreateWebhook(webhook *entity.Webhook) (*entity.Webhook, error)
+	UpdateWebhook(webhook *entity.Webhook) (*entity.Webhook, error)
+	DeleteWebhook(id uint64) error
+	RegisterFailure(id uint64, disableAfter int) error
+	ResetFailures(id uint64) error
+	CreateDelivery(delivery *entity.WebhookDelivery) error
+	GetDeliveriesByWebhookId(webhookId uint64, limit int) ([]entity.WebhookDelivery, error)
+}
+
+type IdempotencyRepository interface {
+	// CreateKey reserves the key unless the user has an unexpired key with the same value
+	CreateKey(key *entity.IdempotencyKey) (bool, error)
+	GetKey(userId uint64, key string) (*entity.IdempotencyKey, error)
+	CompleteKey(key *entity.IdempotencyKey) error
+	DeleteKey(userId uint64, key string) error
+	DeleteExpiredKeys(before time.Time) error
+}
+
+type MccMappingRepository interface {
+	MccMappingBelongsToUser(id, userId uint64) bool
+	GetMccMappingById(id uint64) (*entity.MccMapping, error)
+	GetMccMappingsByUserId(userId uint64) ([]entity.MccMapping, error)
+	// GetMccMappingsByMcc returns user's mappings whose range contains the code
+	GetMccMappingsByMcc(userId uint64, mcc string) ([]entity.MccMapping, error)
+	CreateMccMapping(mapping *entity.MccMapping) (*entity.MccMapping, error)
+	UpdateMccMapping(mapping *entity.MccMapping) (*entity.MccMapping, error)
+	DeleteMccMapping(id uint64) error
+}
+
+type RuleRepository interface {
+	RuleBelongsToUser(id, userId uint64) bool
+	GetRuleById(id uint64) (*entity.Rule, error)
+	GetRulesByUserId(userId uint64) ([]entity.Rule, error)
+	CreateRule(rule *entity.Rule) (*entity.Rule, error)
+	UpdateRule(rule *entity.Rule) (*entity.Rule, error)
+	DeleteRule(id uint64) error
+}
+
+type BudgetRepository interface {
+	GetBudgetById(id uint64) (*entity.Budget, error)
+	GetBudgetsByCategoryId(categoryId uint64) ([]entity.Budget, error)
+	// GetBudgetsByUserId returns budgets of the live categories the user can access, whoever created them
+	GetBudgetsByUserId(userId uint64) ([]entity.Budget, error)
+	CreateBudget(budget *entity.Budget) (*entity.Budget, error)
+	UpdateBudget(budget *entity.Budget) (*entity.Budget, error)
+	DeleteBudget(id uint64) error
+}
+
+// SpendingRepository stores spending totals pushed by the transaction service.
+type SpendingRepository interface {
+	// Transaction runs fn with the repository bound to a single transaction holding the locks of the totals
+	// of the categories, error rolls it back
+	Transaction(categoryIds []uint64, fn func(spendingRepository SpendingRepository) error) error
+	// SaveTotals replaces the totals of the same category, currency and period
+	SaveTotals(totals []entity.SpendingTotal) error
+	// GetTotals returns totals of the categories pushed by any user, so household categories sum
+	// the spending of all members, with periods overlapping from start inclusive to end exclusive
+	GetTotals(categoryIds []uint64, start, end time.Time) ([]entity.SpendingTotal, error)
+	// GetCategoryTotals returns all totals of the category
+	GetCategoryTotals(categoryId uint64) ([]entity.SpendingTotal, error)
+}
+
+type HouseholdRepository interface {
+	GetHouseholdById(id uint64) (*entity.Household, error)
+	// GetHouseholdsByUserId returns households the user is a member of
+	GetHouseholdsByUserId(userId uint64) ([]entity.Household, error)
+	// CreateHousehold creates the household with its owner as the first member
+	CreateHousehold(household *entity.Household) (*entity.Household, error)
+	GetMember(householdId, userId uint64) (*entity.HouseholdMember, error)
+	GetMembers(householdId uint64) ([]entity.HouseholdMember, error)
+	RemoveMember(householdId, userId uint64) error
+	CreateInvitation(invitation *entity.HouseholdInvitation) (*entity.HouseholdInvitation, error)
+	GetInvitationByToken(token string) (*entity.HouseholdInvitation, error)
+	// AcceptInvitation marks the invitation accepted and adds the member
+	AcceptInvitation(invitation *entity.HouseholdInvitation, member *entity.HouseholdMember) error
+}
+
+// CategoryAccountRepository stores links of categories to accounts, ids of the accounts are opaque.
+type CategoryAccountRepository interface {
+	GetAccountIds(categoryId uint64) ([]string, error)
+	// SetAccountIds replaces the accounts linked to the category, none makes it unscoped
+	SetAccountIds(categoryId uint64, accountIds []string) error
+	DeleteAccountId(categoryId uint64, accountId string) error
+}
+
+// TagRepository stores user's tags, their assignments to categories and smart groups.
+type TagRepository interface {
+	GetTagById(id uint64) (*entity.Tag, error)
+	GetTagsByUserId(userId uint64) ([]entity.Tag, error)
+	CreateTag(tag *entity.Tag) (*entity.Tag, error)
+	UpdateTag(tag *entity.Tag) (*entity.Tag, error)
+	// DeleteTag deletes the tag with its assignments
+	DeleteTag(id uint64) error
+	// GetCategoryTags returns user's tags assigned to the category
+	GetCategoryTags(userId, categoryId uint64) ([]entity.Tag, error)
+	// AssignTag assigns the tag to the category, assigning it again does nothing
+	AssignTag(categoryId, tagId uint64) error
+	UnassignTag(categoryId, tagId uint64) error
+	GetSmartGroupById(id uint64) (*entity.SmartGroup, error)
+	GetSmartGroupsByUserId(userId uint64) ([]entity.SmartGroup, error)
+	CreateSmartGroup(group *entity.SmartGroup) (*entity.SmartGroup, error)
+	UpdateSmartGroup(group *entity.SmartGroup) (*entity.SmartGroup, error)
+	DeleteSmartGroup(id uint64) error
+}
+
+// AuditRepository is the append-only store of the audit log.
+type AuditRepository interface {
+	// Append sets the chain, sequence number, time and hashes of the entry chaining it to the last one of the caller
+	Append(entry *entity.AuditEntry) error
+	GetEntry(chain, seq uint64) (*entity.AuditEntry, error)
+	// ForEachEntry calls fn for entries created from start inclusive to end exclusive in the order they were recorded,
+	// loading them batchSize at a time. Zero start or end leaves the range open.
+	ForEachEntry(start, end time.Time, batchSize int, fn func(entry entity.AuditEntry) error) error
+}
+
+type TaxRepository interface {
+	GetTaxByCategoryId(categoryId uint64) (*entity.CategoryTax, error)
+	// GetTaxesByUserId returns tax attributes of the live categories the user can access, whoever set them
+	GetTaxesByUserId(userId uint64) ([]entity.CategoryTax, error)
+	// SaveTax creates or replaces tax attributes of the category
+	SaveTax(tax *entity.CategoryTax) (*entity.CategoryTax, error)
+	DeleteTax(categoryId uint64) error
 }
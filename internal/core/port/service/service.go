@@ -3,15 +3,174 @@ package service
 import (
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
 	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.category/internal/reference"
+	"io"
+	"time"
 )
 
 type Manager struct {
-	Category CategoryService
+	Category    CategoryService
+	Webhook     WebhookService
+	ChangeFeed  ChangeFeedService
+	Stream      StreamService
+	Idempotency IdempotencyService
+	Export      ExportService
+	Import      ImportService
+	Mcc         MccService
+	Rules       RulesService
+	Budget      BudgetService
+	Goal        GoalService
+	Tax         TaxService
+	Household   HouseholdService
+	Account     AccountService
+	Tag         TagService
+	SmartGroup  SmartGroupService
+	Audit       AuditService
 }
 
 type CategoryService interface {
 	GetCategoriesByUserId(userId uint64) ([]entity.Category, error)
+	// GetCategoriesByAccountId returns user's categories shown for the account: linked to it or to no account
+	GetCategoriesByAccountId(userId uint64, accountId string) ([]entity.Category, error)
+	GetCategory(userId, id uint64) (*entity.Category, error)
 	CreateCategory(categoryCreateDTO model.CategoryCreateDTO) (*entity.Category, error)
 	UpdateCategory(categoryUpdateDTO model.CategoryUpdateDTO) (*entity.Category, error)
+	PatchCategory(categoryPatchDTO model.CategoryPatchDTO) (*entity.Category, error)
+	// PutCategory creates or replaces the category with the external id, created reports which one happened
+	PutCategory(categoryPutDTO model.CategoryPutDTO) (category *entity.Category, created bool, err error)
 	DeleteCategory(categoryDeleteDTO model.CategoryDeleteDTO) error
+	// GetCategoryHistory returns changes of the category, the latest first
+	GetCategoryHistory(userId, id uint64) ([]entity.CategoryHistory, error)
+	// GetCategoriesAsOf returns user's categories as they were at the time
+	GetCategoriesAsOf(userId uint64, asOf time.Time) ([]entity.Category, error)
+	// RevertCategory rolls the category back to a version from its history
+	RevertCategory(categoryRevertDTO model.CategoryRevertDTO) (*entity.Category, error)
+	// SuggestCategories ranks user's categories and category templates by similarity to the query
+	SuggestCategories(userId uint64, query string, limit int) ([]model.CategorySuggestion, error)
+	// SimilarCategories returns user's categories with names too close to the name
+	SimilarCategories(userId uint64, name string) ([]model.CategorySuggestion, error)
+}
+
+type ExportService interface {
+	ExportCategories(userId uint64, format model.ExportFormat, w io.Writer) error
+}
+
+type ImportService interface {
+	ImportCategories(categoryImportDTO model.CategoryImportDTO) (*model.CategoryImportReport, error)
+}
+
+type MccService interface {
+	GetMccMappings(userId uint64) ([]entity.MccMapping, error)
+	CreateMccMapping(mccMappingCreateDTO model.MccMappingCreateDTO) (*entity.MccMapping, error)
+	UpdateMccMapping(mccMappingUpdateDTO model.MccMappingUpdateDTO) (*entity.MccMapping, error)
+	DeleteMccMapping(mccMappingDeleteDTO model.MccMappingDeleteDTO) error
+	ResolveMcc(mccResolveDTO model.MccResolveDTO) (*model.MccResolution, error)
+}
+
+type RulesService interface {
+	GetRules(userId uint64) ([]entity.Rule, error)
+	CreateRule(ruleCreateDTO model.RuleCreateDTO) (*entity.Rule, error)
+	UpdateRule(ruleUpdateDTO model.RuleUpdateDTO) (*entity.Rule, error)
+	DeleteRule(ruleDeleteDTO model.RuleDeleteDTO) error
+	// Classify returns the classification of every transaction in the order of the batch
+	Classify(classifyDTO model.ClassifyDTO) ([]model.Classification, error)
+}
+
+// AccountService manages links limiting categories to accounts.
+type AccountService interface {
+	GetCategoryAccounts(userId, categoryId uint64) ([]string, error)
+	SetCategoryAccounts(categoryAccountsDTO model.CategoryAccountsDTO) ([]string, error)
+	DeleteCategoryAccount(userId, categoryId uint64, accountId string) error
+}
+
+type TagService interface {
+	GetTags(userId uint64) ([]entity.Tag, error)
+	CreateTag(tagCreateDTO model.TagCreateDTO) (*entity.Tag, error)
+	UpdateTag(tagUpdateDTO model.TagUpdateDTO) (*entity.Tag, error)
+	DeleteTag(tagDeleteDTO model.TagDeleteDTO) error
+	// GetCategoryTags returns user's tags assigned to the category
+	GetCategoryTags(userId, categoryId uint64) ([]entity.Tag, error)
+	AssignTag(categoryTagDTO model.CategoryTagDTO) error
+	UnassignTag(categoryTagDTO model.CategoryTagDTO) error
+}
+
+type SmartGroupService interface {
+	GetSmartGroups(userId uint64) ([]entity.SmartGroup, error)
+	CreateSmartGroup(groupCreateDTO model.SmartGroupCreateDTO) (*entity.SmartGroup, error)
+	UpdateSmartGroup(groupUpdateDTO model.SmartGroupUpdateDTO) (*entity.SmartGroup, error)
+	DeleteSmartGroup(groupDeleteDTO model.SmartGroupDeleteDTO) error
+	// GetGroupCategories returns categories matching the group filter
+	GetGroupCategories(userId, id uint64) ([]entity.Category, error)
+}
+
+type TaxService interface {
+	GetTaxCodes(jurisdiction string) ([]reference.TaxCode, error)
+	GetCategoryTax(userId, categoryId uint64) (*entity.CategoryTax, error)
+	SetCategoryTax(categoryTaxDTO model.CategoryTaxDTO) (*entity.CategoryTax, error)
+	DeleteCategoryTax(userId, categoryId uint64) error
+	// GetTaxSummary groups user's categories by tax code, jurisdiction is optional
+	GetTaxSummary(userId uint64, jurisdiction string) ([]model.TaxSummaryLine, error)
+}
+
+type HouseholdService interface {
+	GetHouseholds(userId uint64) ([]entity.Household, error)
+	CreateHousehold(householdCreateDTO model.HouseholdCreateDTO) (*entity.Household, error)
+	GetMembers(userId, householdId uint64) ([]entity.HouseholdMember, error)
+	CreateInvitation(invitationCreateDTO model.HouseholdInvitationCreateDTO) (*entity.HouseholdInvitation, error)
+	AcceptInvitation(invitationAcceptDTO model.HouseholdInvitationAcceptDTO) (*entity.HouseholdMember, error)
+	// RemoveMember removes the member by the owner or the member leaving the household
+	RemoveMember(memberDeleteDTO model.HouseholdMemberDeleteDTO) error
+}
+
+type GoalService interface {
+	GetGoalProgress(userId, categoryId uint64) (*model.GoalProgress, error)
+}
+
+type BudgetService interface {
+	GetBudgets(userId, categoryId uint64) ([]entity.Budget, error)
+	CreateBudget(budgetCreateDTO model.BudgetCreateDTO) (*entity.Budget, error)
+	UpdateBudget(budgetUpdateDTO model.BudgetUpdateDTO) (*entity.Budget, error)
+	DeleteBudget(budgetDeleteDTO model.BudgetDeleteDTO) error
+	GetBudgetStatus(budgetStatusDTO model.BudgetStatusDTO) ([]model.BudgetStatus, error)
+	// RecordSpending saves spending totals and emits events for crossed budget thresholds
+	RecordSpending(spendingRecordDTO model.SpendingRecordDTO) error
+}
+
+// AuditService keeps the hash-chained audit log of mutating API calls.
+type AuditService interface {
+	Record(entry *entity.AuditEntry) error
+	// Verify checks the chain of entries created from start inclusive to end exclusive,
+	// zero start or end leaves the range open
+	Verify(start, end time.Time) (*model.AuditVerification, error)
+	// Export writes entries of the range to w as JSON lines
+	Export(start, end time.Time, w io.Writer) error
+}
+
+type ChangeFeedService interface {
+	GetChanges(userId uint64, token string) (*model.CategoryChangesDTO, error)
+	Token(userId, seq uint64) string
+}
+
+type StreamService interface {
+	Subscribe(userId uint64) (events <-chan model.Event, unsubscribe func())
+	Message(event model.Event) model.StreamMessage
+	Replay(userId uint64, lastEventId string) ([]model.StreamMessage, error)
+}
+
+type WebhookService interface {
+	GetWebhooksByUserId(userId uint64) ([]entity.Webhook, error)
+	CreateWebhook(webhookCreateDTO model.WebhookCreateDTO) (*entity.Webhook, error)
+	UpdateWebhook(webhookUpdateDTO model.WebhookUpdateDTO) (*entity.Webhook, error)
+	DeleteWebhook(webhookDeleteDTO model.WebhookDeleteDTO) error
+	GetDeliveries(webhookId, userId uint64) ([]entity.WebhookDelivery, error)
+	Publish(event model.Event)
+	Shutdown() error
+}
+
+type IdempotencyService interface {
+	// Begin reserves the key for the request. It returns the stored response when the request was already
+	// completed, nil when the caller should handle the request and then Complete or Release the key.
+	Begin(userId uint64, key, fingerprint string) (*entity.IdempotencyKey, error)
+	Complete(userId uint64, key string, statusCode int, headers map[string]string, body []byte) error
+	Release(userId uint64, key string) error
 }
@@ -0,0 +1,44 @@
+// Package access checks what users can do with categories: personal categories are available
+// to their owner only, household categories to the household members according to their role.
+package access
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+)
+
+// CheckCategory returns nil when the user can access the category with the required role:
+// viewer to read it, editor to change it.
+func CheckCategory(categoryRepository repository.CategoryRepository, category *entity.Category, userId uint64, role entity.HouseholdRole) error {
+	if category.HouseholdId == nil {
+		if category.UserId != userId {
+			return serviceerror.CategoryDoesntBelongToUser
+		}
+		return nil
+	}
+	if categoryRepository.UserCanAccessCategory(category.Id, userId, role) {
+		return nil
+	}
+	if role != entity.RoleViewer && categoryRepository.UserCanAccessCategory(category.Id, userId, entity.RoleViewer) {
+		return serviceerror.HouseholdEditorRequired
+	}
+	return serviceerror.CategoryDoesntBelongToUser
+}
+
+// Recipients are the users who see changes of the category: the author of a personal category
+// or the members of the household. The author is returned when members can't be loaded.
+func Recipients(householdRepository repository.HouseholdRepository, category *entity.Category) []uint64 {
+	if category.HouseholdId == nil {
+		return []uint64{category.UserId}
+	}
+	members, err := householdRepository.GetMembers(*category.HouseholdId)
+	if err != nil || len(members) == 0 {
+		return []uint64{category.UserId}
+	}
+	userIds := make([]uint64, len(members))
+	for i, member := range members {
+		userIds[i] = member.UserId
+	}
+	return userIds
+}
@@ -0,0 +1,78 @@
+package account
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"sort"
+)
+
+type account struct {
+	accountRepository  repository.CategoryAccountRepository
+	categoryRepository repository.CategoryRepository
+}
+
+func NewAccountService(repositoryManager *repository.Manager) service.AccountService {
+	return &account{
+		accountRepository:  repositoryManager.Account,
+		categoryRepository: repositoryManager.Category,
+	}
+}
+
+func (a *account) GetCategoryAccounts(userId, categoryId uint64) ([]string, error) {
+	if err := a.checkCategory(userId, categoryId, entity.RoleViewer); err != nil {
+		return nil, err
+	}
+	return a.accountRepository.GetAccountIds(categoryId)
+}
+
+// SetCategoryAccounts replaces the links of the category, duplicate ids are linked once.
+func (a *account) SetCategoryAccounts(categoryAccountsDTO model.CategoryAccountsDTO) ([]string, error) {
+	if err := a.checkCategory(categoryAccountsDTO.UserId, categoryAccountsDTO.CategoryId, entity.RoleEditor); err != nil {
+		return nil, err
+	}
+	accountIds := make([]string, 0, len(categoryAccountsDTO.AccountIds))
+	seen := make(map[string]bool, len(categoryAccountsDTO.AccountIds))
+	for _, accountId := range categoryAccountsDTO.AccountIds {
+		if err := validateAccountId(accountId); err != nil {
+			return nil, err
+		}
+		if !seen[accountId] {
+			seen[accountId] = true
+			accountIds = append(accountIds, accountId)
+		}
+	}
+	sort.Strings(accountIds)
+	if err := a.accountRepository.SetAccountIds(categoryAccountsDTO.CategoryId, accountIds); err != nil {
+		return nil, err
+	}
+	return accountIds, nil
+}
+
+func (a *account) DeleteCategoryAccount(userId, categoryId uint64, accountId string) error {
+	if err := validateAccountId(accountId); err != nil {
+		return err
+	}
+	if err := a.checkCategory(userId, categoryId, entity.RoleEditor); err != nil {
+		return err
+	}
+	return a.accountRepository.DeleteAccountId(categoryId, accountId)
+}
+
+func (a *account) checkCategory(userId, categoryId uint64, role entity.HouseholdRole) error {
+	category, err := a.categoryRepository.GetCategoryById(categoryId)
+	if err != nil {
+		return serviceerror.CategoryDoesntExist
+	}
+	return access.CheckCategory(a.categoryRepository, category, userId, role)
+}
+
+func validateAccountId(accountId string) error {
+	if accountId == "" || len(accountId) > entity.MaxAccountIdLength {
+		return serviceerror.InvalidAccountId
+	}
+	return nil
+}
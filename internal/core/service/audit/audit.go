@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"gorm.io/gorm"
+	"io"
+	"time"
+)
+
+const batchSize = 500
+
+// errChainBroken stops the iteration at the first entry failing the check.
+var errChainBroken = errors.New("audit chain is broken")
+
+type audit struct {
+	auditRepository repository.AuditRepository
+}
+
+func NewAuditService(repositoryManager *repository.Manager) service.AuditService {
+	return &audit{
+		auditRepository: repositoryManager.Audit,
+	}
+}
+
+func (a *audit) Record(entry *entity.AuditEntry) error {
+	return a.auditRepository.Append(entry)
+}
+
+// Verify recomputes hashes of the entries and checks each links to the previous one of its chain without gaps.
+// The range may start in the middle of a chain, its first entry is then linked to the entry before it.
+func (a *audit) Verify(start, end time.Time) (*model.AuditVerification, error) {
+	if err := checkRange(start, end); err != nil {
+		return nil, err
+	}
+	verification := &model.AuditVerification{Valid: true, Chains: map[uint64]*model.AuditChainHead{}}
+	prevs := map[uint64]*entity.AuditEntry{}
+	err := a.auditRepository.ForEachEntry(start, end, batchSize, func(entry entity.AuditEntry) error {
+		head, ok := verification.Chains[entry.Chain]
+		if !ok {
+			head = &model.AuditChainHead{FirstSeq: entry.Seq}
+			verification.Chains[entry.Chain] = head
+			prev, err := a.predecessor(entry, start)
+			if err != nil {
+				return err
+			}
+			prevs[entry.Chain] = prev
+		}
+		if reason := checkLink(prevs[entry.Chain], entry); reason != "" {
+			verification.Valid = false
+			verification.BrokenChain, verification.BrokenSeq = &entry.Chain, &entry.Seq
+			verification.Reason = reason
+			return errChainBroken
+		}
+		verification.Entries++
+		head.LastSeq, head.LastHash = entry.Seq, entry.Hash
+		prevs[entry.Chain] = &entry
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+	return verification, nil
+}
+
+// predecessor returns the entry the first entry of the range links to, nil for the chain start.
+// A missing predecessor is reported by checkLink as a gap.
+func (a *audit) predecessor(first entity.AuditEntry, start time.Time) (*entity.AuditEntry, error) {
+	if first.Seq == 1 {
+		return nil, nil
+	}
+	if start.IsZero() {
+		return &entity.AuditEntry{}, nil
+	}
+	prev, err := a.auditRepository.GetEntry(first.Chain, first.Seq-1)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.AuditEntry{}, nil
+	}
+	return prev, err
+}
+
+// checkLink returns why the entry doesn't follow prev, empty when it does.
+func checkLink(prev *entity.AuditEntry, entry entity.AuditEntry) string {
+	switch {
+	case prev == nil && entry.PrevHash != "":
+		return "first entry links to another one"
+	case prev != nil && entry.Seq != prev.Seq+1:
+		return fmt.Sprintf("entries before %d are missing", entry.Seq)
+	case prev != nil && entry.PrevHash != prev.Hash:
+		return "previous hash doesn't match"
+	case entry.ChainHash() != entry.Hash:
+		return "entry was modified"
+	default:
+		return ""
+	}
+}
+
+// Export writes the entries as JSON lines in chain order.
+func (a *audit) Export(start, end time.Time, w io.Writer) error {
+	if err := checkRange(start, end); err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	err := a.auditRepository.ForEachEntry(start, end, batchSize, func(entry entity.AuditEntry) error {
+		return encoder.Encode(entry)
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func checkRange(start, end time.Time) error {
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return serviceerror.InvalidAuditRange
+	}
+	return nil
+}
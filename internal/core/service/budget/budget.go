@@ -0,0 +1,177 @@
+package budget
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/event"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"sort"
+	"time"
+)
+
+type budget struct {
+	budgetRepository    repository.BudgetRepository
+	categoryRepository  repository.CategoryRepository
+	spendingRepository  repository.SpendingRepository
+	householdRepository repository.HouseholdRepository
+	publisher           event.Publisher
+	cfg                 config.BudgetConfig
+}
+
+func NewBudgetService(repositoryManager *repository.Manager, publisher event.Publisher, cfg config.BudgetConfig) service.BudgetService {
+	if len(cfg.Thresholds) == 0 {
+		cfg.Thresholds = config.DefaultBudgetConfig.Thresholds
+	}
+	cfg.Thresholds = append([]int(nil), cfg.Thresholds...)
+	sort.Ints(cfg.Thresholds)
+	return &budget{
+		budgetRepository:    repositoryManager.Budget,
+		categoryRepository:  repositoryManager.Category,
+		spendingRepository:  repositoryManager.Spending,
+		householdRepository: repositoryManager.Household,
+		publisher:           publisher,
+		cfg:                 cfg,
+	}
+}
+
+func (b *budget) GetBudgets(userId, categoryId uint64) ([]entity.Budget, error) {
+	if _, err := b.getCategory(userId, categoryId, entity.RoleViewer); err != nil {
+		return nil, err
+	}
+	return b.budgetRepository.GetBudgetsByCategoryId(categoryId)
+}
+
+// CreateBudget adds a spending limit to the expense category.
+// Categories are flat, so a budget limits its own category only.
+func (b *budget) CreateBudget(budgetCreateDTO model.BudgetCreateDTO) (*entity.Budget, error) {
+	category, err := b.getCategory(budgetCreateDTO.UserId, budgetCreateDTO.CategoryId, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if category.Type != entity.Expense {
+		return nil, serviceerror.BudgetRequiresExpenseCategory
+	}
+
+	newBudget := &entity.Budget{
+		UserId:        budgetCreateDTO.UserId,
+		CategoryId:    budgetCreateDTO.CategoryId,
+		Amount:        budgetCreateDTO.Amount,
+		Currency:      budgetCreateDTO.Currency,
+		Period:        budgetCreateDTO.Period,
+		EndDate:       date(budgetCreateDTO.EndDate),
+		Rollover:      entity.RolloverNone,
+		RolloverLimit: budgetCreateDTO.RolloverLimit,
+	}
+	if budgetCreateDTO.StartDate != nil {
+		newBudget.StartDate = *date(budgetCreateDTO.StartDate)
+	} else {
+		newBudget.StartDate = PeriodStart(newBudget.Period, time.Now())
+	}
+	if budgetCreateDTO.Rollover != nil {
+		newBudget.Rollover = *budgetCreateDTO.Rollover
+	}
+	if budgetCreateDTO.Period == entity.Custom && budgetCreateDTO.StartDate == nil {
+		return nil, serviceerror.InvalidBudgetPeriod
+	}
+	if err = validateBudget(newBudget); err != nil {
+		return nil, err
+	}
+	return b.budgetRepository.CreateBudget(newBudget)
+}
+
+func (b *budget) UpdateBudget(budgetUpdateDTO model.BudgetUpdateDTO) (*entity.Budget, error) {
+	budgetToUpdate, err := b.getBudget(budgetUpdateDTO.UserId, budgetUpdateDTO.CategoryId, budgetUpdateDTO.Id)
+	if err != nil {
+		return nil, err
+	}
+	if budgetUpdateDTO.Amount != nil {
+		budgetToUpdate.Amount = *budgetUpdateDTO.Amount
+	}
+	if budgetUpdateDTO.Currency != nil {
+		budgetToUpdate.Currency = *budgetUpdateDTO.Currency
+	}
+	if budgetUpdateDTO.Period != nil {
+		budgetToUpdate.Period = *budgetUpdateDTO.Period
+	}
+	if budgetUpdateDTO.StartDate != nil {
+		budgetToUpdate.StartDate = *date(budgetUpdateDTO.StartDate)
+	}
+	if budgetUpdateDTO.EndDate != nil {
+		budgetToUpdate.EndDate = date(budgetUpdateDTO.EndDate)
+	}
+	if budgetUpdateDTO.Rollover != nil {
+		budgetToUpdate.Rollover = *budgetUpdateDTO.Rollover
+	}
+	if budgetUpdateDTO.RolloverLimit != nil {
+		budgetToUpdate.RolloverLimit = budgetUpdateDTO.RolloverLimit
+	}
+	if err = validateBudget(budgetToUpdate); err != nil {
+		return nil, err
+	}
+	return b.budgetRepository.UpdateBudget(budgetToUpdate)
+}
+
+func (b *budget) DeleteBudget(budgetDeleteDTO model.BudgetDeleteDTO) error {
+	if _, err := b.getBudget(budgetDeleteDTO.UserId, budgetDeleteDTO.CategoryId, budgetDeleteDTO.Id); err != nil {
+		return err
+	}
+	return b.budgetRepository.DeleteBudget(budgetDeleteDTO.Id)
+}
+
+func (b *budget) getCategory(userId, categoryId uint64, role entity.HouseholdRole) (*entity.Category, error) {
+	category, err := b.categoryRepository.GetCategoryById(categoryId)
+	if err != nil {
+		return nil, serviceerror.CategoryDoesntExist
+	}
+	if err = access.CheckCategory(b.categoryRepository, category, userId, role); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// getBudget returns the budget of the category from the request path.
+// Budgets of household categories can be changed by any editor of the household.
+func (b *budget) getBudget(userId, categoryId, id uint64) (*entity.Budget, error) {
+	found, err := b.budgetRepository.GetBudgetById(id)
+	if err != nil || found.CategoryId != categoryId {
+		return nil, serviceerror.BudgetDoesntExist
+	}
+	if found.UserId == userId {
+		return found, nil
+	}
+	category, err := b.categoryRepository.GetCategoryById(categoryId)
+	if err != nil || category.HouseholdId == nil {
+		return nil, serviceerror.BudgetDoesntBelongToUser
+	}
+	if err = access.CheckCategory(b.categoryRepository, category, userId, entity.RoleEditor); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func validateBudget(budget *entity.Budget) error {
+	if !budget.Amount.IsPositive() || budget.RolloverLimit != nil && budget.RolloverLimit.IsNegative() {
+		return serviceerror.InvalidBudgetAmount
+	}
+	if budget.Period == entity.Custom && budget.EndDate == nil {
+		return serviceerror.InvalidBudgetPeriod
+	}
+	if budget.EndDate != nil && !budget.EndDate.After(budget.StartDate) {
+		return serviceerror.InvalidBudgetPeriod
+	}
+	return nil
+}
+
+// date drops the time of day, budgets work with calendar dates in UTC.
+func date(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	year, month, day := t.UTC().Date()
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
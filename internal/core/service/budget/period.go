@@ -0,0 +1,90 @@
+package budget
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"time"
+)
+
+// PeriodStart returns the start of the calendar week, month or year containing t,
+// weeks start on Monday. Custom period starts on t itself.
+func PeriodStart(period entity.BudgetPeriod, t time.Time) time.Time {
+	day := *date(&t)
+	switch period {
+	case entity.Weekly:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case entity.Monthly:
+		return day.AddDate(0, 0, 1-day.Day())
+	case entity.Yearly:
+		return day.AddDate(0, 0, 1-day.YearDay())
+	default:
+		return day
+	}
+}
+
+// periodIndex returns the number of the budget period containing t counting from zero,
+// false when the budget isn't active at t.
+func periodIndex(budget entity.Budget, t time.Time) (int, bool) {
+	if t.Before(budget.StartDate) {
+		return 0, false
+	}
+	if end := budgetEnd(budget); end != nil && !t.Before(*end) {
+		return 0, false
+	}
+
+	var k int
+	switch budget.Period {
+	case entity.Weekly:
+		return int(t.Sub(budget.StartDate).Hours()/24) / 7, true
+	case entity.Monthly:
+		k = (t.Year()-budget.StartDate.Year())*12 + int(t.Month()) - int(budget.StartDate.Month())
+	case entity.Yearly:
+		k = t.Year() - budget.StartDate.Year()
+	default:
+		return 0, true
+	}
+	if start, _ := periodBounds(budget, k); start.After(t) {
+		k--
+	}
+	return k, true
+}
+
+// periodBounds returns the k-th period of the budget from start inclusive to end exclusive.
+// Monthly and yearly periods keep the day of the start date, clamped to the length of the month.
+func periodBounds(budget entity.Budget, k int) (start, end time.Time) {
+	switch budget.Period {
+	case entity.Weekly:
+		start, end = budget.StartDate.AddDate(0, 0, 7*k), budget.StartDate.AddDate(0, 0, 7*(k+1))
+	case entity.Monthly:
+		start, end = addMonths(budget.StartDate, k), addMonths(budget.StartDate, k+1)
+	case entity.Yearly:
+		start, end = addMonths(budget.StartDate, 12*k), addMonths(budget.StartDate, 12*(k+1))
+	default:
+		start, end = budget.StartDate, budget.StartDate
+	}
+	if last := budgetEnd(budget); last != nil && (budget.Period == entity.Custom || end.After(*last)) {
+		end = *last
+	}
+	return start, end
+}
+
+// budgetEnd is the day after the budget end date.
+func budgetEnd(budget entity.Budget) *time.Time {
+	if budget.EndDate == nil {
+		return nil
+	}
+	end := budget.EndDate.AddDate(0, 0, 1)
+	return &end
+}
+
+func addMonths(t time.Time, months int) time.Time {
+	month := int(t.Month()) - 1 + months
+	year := t.Year() + month/12
+	month %= 12
+	if month < 0 {
+		month += 12
+		year--
+	}
+	firstDay := time.Date(year, time.Month(month+1), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1).Day()
+	return firstDay.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
@@ -0,0 +1,254 @@
+package budget
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// budgetPeriod is the k-th period of the budget.
+type budgetPeriod struct {
+	budget entity.Budget
+	k      int
+}
+
+// GetBudgetStatus returns the progress of user's budgets in their periods containing the date.
+// Budgets of household categories count the spending of all members.
+func (b *budget) GetBudgetStatus(budgetStatusDTO model.BudgetStatusDTO) ([]model.BudgetStatus, error) {
+	categories, err := b.categories(budgetStatusDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := b.budgetRepository.GetBudgetsByUserId(budgetStatusDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := make([]budgetPeriod, 0, len(budgets))
+	for _, budget := range budgets {
+		if _, ok := categories[budget.CategoryId]; !ok {
+			continue
+		}
+		if budgetStatusDTO.Period != "" && budget.Period != budgetStatusDTO.Period {
+			continue
+		}
+		if k, ok := periodIndex(budget, budgetStatusDTO.Date); ok {
+			periods = append(periods, budgetPeriod{budget: budget, k: k})
+		}
+	}
+
+	statuses := make([]model.BudgetStatus, 0, len(periods))
+	if len(periods) == 0 {
+		return statuses, nil
+	}
+	totals, err := getTotals(b.spendingRepository, periods)
+	if err != nil {
+		return nil, err
+	}
+	for _, period := range periods {
+		statuses = append(statuses, period.status(totals, categories))
+	}
+	return statuses, nil
+}
+
+// RecordSpending saves the totals pushed by the transaction service and emits
+// budget.threshold_crossed event for every threshold the budget periods of the totals crossed.
+// Events of household categories are sent to every member.
+func (b *budget) RecordSpending(spendingRecordDTO model.SpendingRecordDTO) error {
+	userId := spendingRecordDTO.UserId
+	categories, err := b.categories(userId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	totals := make([]entity.SpendingTotal, 0, len(spendingRecordDTO.Totals))
+	categoryIds := make([]uint64, 0, len(spendingRecordDTO.Totals))
+	for _, total := range spendingRecordDTO.Totals {
+		if _, ok := categories[total.CategoryId]; !ok {
+			return serviceerror.CategoryDoesntExist
+		}
+		if !total.PeriodEnd.After(total.PeriodStart) || total.Amount.IsNegative() {
+			return serviceerror.InvalidSpendingTotal
+		}
+		totals = append(totals, entity.SpendingTotal{
+			UserId:      userId,
+			CategoryId:  total.CategoryId,
+			Currency:    total.Currency,
+			PeriodStart: total.PeriodStart.UTC(),
+			PeriodEnd:   total.PeriodEnd.UTC(),
+			Amount:      total.Amount,
+			UpdatedAt:   now,
+		})
+		categoryIds = append(categoryIds, total.CategoryId)
+	}
+
+	periods, err := b.affectedPeriods(userId, totals)
+	if err != nil {
+		return err
+	}
+
+	// totals of the categories are locked, so concurrent records, also of other household members,
+	// can't interleave between the snapshots and cross the same threshold twice or not at all
+	var crossings []model.BudgetThresholdEvent
+	err = b.spendingRepository.Transaction(categoryIds, func(spendingRepository repository.SpendingRepository) error {
+		if len(periods) == 0 {
+			return spendingRepository.SaveTotals(totals)
+		}
+		before, err := getTotals(spendingRepository, periods)
+		if err != nil {
+			return err
+		}
+		if err = spendingRepository.SaveTotals(totals); err != nil {
+			return err
+		}
+		after, err := getTotals(spendingRepository, periods)
+		if err != nil {
+			return err
+		}
+
+		for _, period := range periods {
+			previous := period.status(before, categories)
+			current := period.status(after, categories)
+			for _, threshold := range b.cfg.Thresholds {
+				percentage := decimal.NewFromInt(int64(threshold))
+				if previous.Percentage.LessThan(percentage) && current.Percentage.GreaterThanOrEqual(percentage) {
+					crossings = append(crossings, model.BudgetThresholdEvent{
+						Threshold: threshold,
+						Status:    current,
+					})
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, crossing := range crossings {
+		category := categories[crossing.Status.CategoryId]
+		for _, recipientId := range access.Recipients(b.householdRepository, &category) {
+			b.publisher.Publish(model.NewEvent(model.BudgetThresholdCrossed, recipientId, crossing))
+		}
+	}
+	return nil
+}
+
+// affectedPeriods returns the budget periods the totals fall into.
+func (b *budget) affectedPeriods(userId uint64, totals []entity.SpendingTotal) ([]budgetPeriod, error) {
+	budgets, err := b.budgetRepository.GetBudgetsByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	type periodId struct {
+		budgetId uint64
+		k        int
+	}
+	seen := make(map[periodId]bool)
+	periods := make([]budgetPeriod, 0)
+	for _, budget := range budgets {
+		for _, total := range totals {
+			if total.CategoryId != budget.CategoryId || total.Currency != budget.Currency {
+				continue
+			}
+			k, ok := periodIndex(budget, total.PeriodStart)
+			if !ok || seen[periodId{budget.Id, k}] {
+				continue
+			}
+			seen[periodId{budget.Id, k}] = true
+			periods = append(periods, budgetPeriod{budget: budget, k: k})
+		}
+	}
+	return periods, nil
+}
+
+// getTotals loads the totals of all the periods and the periods before them the rollover is taken from.
+func getTotals(spendingRepository repository.SpendingRepository, periods []budgetPeriod) ([]entity.SpendingTotal, error) {
+	var start, end time.Time
+	categoryIds := make([]uint64, len(periods))
+	for i, period := range periods {
+		categoryIds[i] = period.budget.CategoryId
+		_, periodEnd := periodBounds(period.budget, period.k)
+		if i == 0 || period.budget.StartDate.Before(start) {
+			start = period.budget.StartDate
+		}
+		if i == 0 || periodEnd.After(end) {
+			end = periodEnd
+		}
+	}
+	return spendingRepository.GetTotals(categoryIds, start, end)
+}
+
+// categories returns the categories the user can access by id.
+func (b *budget) categories(userId uint64) (map[uint64]entity.Category, error) {
+	categories, err := b.categoryRepository.GetCategoriesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[uint64]entity.Category, len(categories))
+	for _, category := range categories {
+		byId[category.Id] = category
+	}
+	return byId, nil
+}
+
+// status evaluates the period against the totals. The limit of a rollover budget is its amount
+// plus what was carried through every previous period, the carry is capped by the rollover limit.
+func (p budgetPeriod) status(totals []entity.SpendingTotal, categories map[uint64]entity.Category) model.BudgetStatus {
+	limit := p.budget.Amount
+	if p.budget.Rollover != entity.RolloverNone {
+		carry := decimal.Zero
+		for i := 0; i < p.k; i++ {
+			start, end := periodBounds(p.budget, i)
+			carry = p.budget.Amount.Add(carry).Sub(p.spent(totals, start, end))
+			if p.budget.Rollover == entity.RolloverSurplus && carry.IsNegative() {
+				carry = decimal.Zero
+			}
+			if cap := p.budget.RolloverLimit; cap != nil && carry.Abs().GreaterThan(*cap) {
+				carry = cap.Mul(decimal.NewFromInt(int64(carry.Sign())))
+			}
+		}
+		limit = limit.Add(carry)
+	}
+
+	start, end := periodBounds(p.budget, p.k)
+	spent := p.spent(totals, start, end)
+	// nothing spent is 0% of any limit, spending against no limit is over it
+	percentage := decimal.Zero
+	if limit.IsPositive() {
+		percentage = spent.Div(limit).Mul(hundred).Round(2)
+	} else if spent.IsPositive() {
+		percentage = hundred
+	}
+	return model.BudgetStatus{
+		BudgetId:     p.budget.Id,
+		CategoryId:   p.budget.CategoryId,
+		CategoryName: categories[p.budget.CategoryId].Name,
+		Period:       p.budget.Period,
+		Currency:     p.budget.Currency,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Limit:        limit,
+		Spent:        spent,
+		Remaining:    limit.Sub(spent),
+		Percentage:   percentage,
+	}
+}
+
+// spent sums the totals of the budget category and currency that fall into the period.
+func (p budgetPeriod) spent(totals []entity.SpendingTotal, start, end time.Time) decimal.Decimal {
+	sum := decimal.Zero
+	for _, total := range totals {
+		if total.CategoryId == p.budget.CategoryId && total.Currency == p.budget.Currency && total.Within(start, end) {
+			sum = sum.Add(total.Amount)
+		}
+	}
+	return sum
+}
@@ -1,35 +1,105 @@
 package category
 
 import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
 	serviceerror "github.com/khivuksergey/portmonetka.category/error"
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/event"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/transfer"
 	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"gorm.io/gorm"
 )
 
 type category struct {
-	categoryRepository repository.CategoryRepository
+	categoryRepository  repository.CategoryRepository
+	householdRepository repository.HouseholdRepository
+	publisher           event.Publisher
+	validate            *validator.Validate
 }
 
-func NewCategoryService(repositoryManager *repository.Manager) service.CategoryService {
-	return &category{categoryRepository: repositoryManager.Category}
+func NewCategoryService(repositoryManager *repository.Manager, publisher event.Publisher) service.CategoryService {
+	return &category{
+		categoryRepository:  repositoryManager.Category,
+		householdRepository: repositoryManager.Household,
+		publisher:           publisher,
+		validate:            model.GetCategoryValidator(),
+	}
 }
 
 func (c *category) GetCategoriesByUserId(userId uint64) ([]entity.Category, error) {
 	return c.categoryRepository.GetCategoriesByUserId(userId)
 }
 
+// GetCategoriesByAccountId returns user's categories shown for the account.
+func (c *category) GetCategoriesByAccountId(userId uint64, accountId string) ([]entity.Category, error) {
+	if accountId == "" || len(accountId) > entity.MaxAccountIdLength {
+		return nil, serviceerror.InvalidAccountId
+	}
+	return c.categoryRepository.GetCategoriesByAccountId(userId, accountId)
+}
+
+func (c *category) GetCategory(userId, id uint64) (*entity.Category, error) {
+	category, err := c.categoryRepository.GetCategoryById(id)
+	if err != nil {
+		return nil, serviceerror.CategoryDoesntExist
+	}
+	if err = access.CheckCategory(c.categoryRepository, category, userId, entity.RoleViewer); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// CreateCategory creates user's personal category or, with the household id, the household one.
+// The first personal category comes with the transfer category.
 func (c *category) CreateCategory(categoryCreateDTO model.CategoryCreateDTO) (*entity.Category, error) {
-	if c.categoryRepository.ExistsWithName(categoryCreateDTO.UserId, categoryCreateDTO.Name) {
-		return nil, serviceerror.CategoryAlreadyExists
+	if categoryCreateDTO.HouseholdId != nil {
+		if err := c.checkHouseholdEditor(*categoryCreateDTO.HouseholdId, categoryCreateDTO.UserId); err != nil {
+			return nil, err
+		}
 	}
-	return c.categoryRepository.CreateCategory(&entity.Category{
+	categoryToCreate := &entity.Category{
 		UserId:      categoryCreateDTO.UserId,
+		HouseholdId: categoryCreateDTO.HouseholdId,
 		Name:        categoryCreateDTO.Name,
 		Description: categoryCreateDTO.Description,
 		Type:        categoryCreateDTO.Type,
+		ExternalId:  categoryCreateDTO.ExternalId,
+	}
+	categoryToCreate.SetTarget(categoryCreateDTO.TargetAmount, categoryCreateDTO.TargetDate, categoryCreateDTO.TargetCurrency)
+	if err := validateType(categoryToCreate); err != nil {
+		return nil, err
+	}
+	if categoryToCreate.HouseholdId != nil {
+		createdCategory, err := c.categoryRepository.CreateCategory(categoryToCreate)
+		if err != nil {
+			return nil, err
+		}
+		c.publish(model.CategoryCreated, createdCategory)
+		return createdCategory, nil
+	}
+
+	var createdCategory, transferCategory *entity.Category
+	err := c.categoryRepository.Transaction(func(categoryRepository repository.CategoryRepository) error {
+		var err error
+		if createdCategory, err = categoryRepository.CreateCategory(categoryToCreate); err != nil {
+			return err
+		}
+		transferCategory, err = transfer.Ensure(categoryRepository, categoryToCreate.UserId)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	if transferCategory != nil {
+		c.publish(model.CategoryCreated, transferCategory)
+	}
+	c.publish(model.CategoryCreated, createdCategory)
+	return createdCategory, nil
 }
 
 func (c *category) UpdateCategory(categoryUpdateDTO model.CategoryUpdateDTO) (*entity.Category, error) {
@@ -37,32 +107,182 @@ func (c *category) UpdateCategory(categoryUpdateDTO model.CategoryUpdateDTO) (*e
 	if err != nil {
 		return nil, serviceerror.CategoryDoesntExist
 	}
+	if err = access.CheckCategory(c.categoryRepository, categoryToUpdate, categoryUpdateDTO.UserId, entity.RoleEditor); err != nil {
+		return nil, err
+	}
+	expectedVersion := categoryToUpdate.Version
+	if categoryUpdateDTO.Version != nil && *categoryUpdateDTO.Version != expectedVersion {
+		return nil, serviceerror.CategoryVersionMismatch
+	}
+	if categoryUpdateDTO.Name != nil {
+		if err = c.checkSystemRename(categoryToUpdate, *categoryUpdateDTO.Name); err != nil {
+			return nil, err
+		}
+	}
 	err = c.validateUpdateCategoryAttributes(categoryToUpdate, categoryUpdateDTO)
 	if err != nil {
 		return nil, err
 	}
-	return c.categoryRepository.UpdateCategory(categoryToUpdate)
+	updatedCategory, err := c.categoryRepository.UpdateCategory(categoryToUpdate, expectedVersion, categoryUpdateDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	c.publish(model.CategoryUpdated, updatedCategory)
+	return updatedCategory, nil
+}
+
+// PatchCategory applies the patch to the category JSON and validates the result,
+// so clients can clear fields and every new writable field is patchable without a DTO change.
+func (c *category) PatchCategory(categoryPatchDTO model.CategoryPatchDTO) (*entity.Category, error) {
+	categoryToPatch, err := c.categoryRepository.GetCategoryById(categoryPatchDTO.Id)
+	if err != nil {
+		return nil, serviceerror.CategoryDoesntExist
+	}
+	if err = access.CheckCategory(c.categoryRepository, categoryToPatch, categoryPatchDTO.UserId, entity.RoleEditor); err != nil {
+		return nil, err
+	}
+	expectedVersion := categoryToPatch.Version
+	if categoryPatchDTO.Version != nil && *categoryPatchDTO.Version != expectedVersion {
+		return nil, serviceerror.CategoryVersionMismatch
+	}
+
+	patchedCategory, err := applyPatch(categoryToPatch, categoryPatchDTO.Type, categoryPatchDTO.Patch)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate.Struct(patchedCategory); err != nil {
+		return nil, err
+	}
+	patchedCategory.SetTarget(nil, patchedCategory.TargetDate, nil)
+	if err = validateType(patchedCategory); err != nil {
+		return nil, err
+	}
+	if err = c.checkSystemRename(categoryToPatch, patchedCategory.Name); err != nil {
+		return nil, err
+	}
+
+	updatedCategory, err := c.categoryRepository.UpdateCategory(patchedCategory, expectedVersion, categoryPatchDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	c.publish(model.CategoryUpdated, updatedCategory)
+	return updatedCategory, nil
+}
+
+// PutCategory replaces the category the user can edit found by the external id or creates user's personal one.
+func (c *category) PutCategory(categoryPutDTO model.CategoryPutDTO) (*entity.Category, bool, error) {
+	existingCategory, err := c.categoryRepository.GetCategoryByExternalId(categoryPutDTO.UserId, categoryPutDTO.ExternalId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		var createdCategory *entity.Category
+		createdCategory, err = c.CreateCategory(model.CategoryCreateDTO{
+			UserId:         categoryPutDTO.UserId,
+			Name:           categoryPutDTO.Name,
+			Description:    categoryPutDTO.Description,
+			Type:           categoryPutDTO.Type,
+			ExternalId:     &categoryPutDTO.ExternalId,
+			TargetAmount:   categoryPutDTO.TargetAmount,
+			TargetDate:     categoryPutDTO.TargetDate,
+			TargetCurrency: categoryPutDTO.TargetCurrency,
+		})
+		if !errors.Is(err, serviceerror.CategoryExternalIdExists) {
+			return createdCategory, err == nil, err
+		}
+		// created by a concurrent request, replace it. Not found means the external id is taken
+		// by user's category of a household the user can't access anymore
+		existingCategory, err = c.categoryRepository.GetCategoryByExternalId(categoryPutDTO.UserId, categoryPutDTO.ExternalId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, serviceerror.CategoryExternalIdExists
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err = access.CheckCategory(c.categoryRepository, existingCategory, categoryPutDTO.UserId, entity.RoleEditor); err != nil {
+		return nil, false, err
+	}
+
+	expectedVersion := existingCategory.Version
+	if categoryPutDTO.Version != nil && *categoryPutDTO.Version != expectedVersion {
+		return nil, false, serviceerror.CategoryVersionMismatch
+	}
+	if existingCategory.Type != categoryPutDTO.Type {
+		return nil, false, fmt.Errorf("%w: %s", serviceerror.ReadOnlyFieldChanged, "type")
+	}
+	if err = c.checkSystemRename(existingCategory, categoryPutDTO.Name); err != nil {
+		return nil, false, err
+	}
+	existingCategory.Name = categoryPutDTO.Name
+	existingCategory.Description = categoryPutDTO.Description
+	existingCategory.TargetAmount, existingCategory.TargetDate, existingCategory.TargetCurrency = nil, nil, nil
+	existingCategory.SetTarget(categoryPutDTO.TargetAmount, categoryPutDTO.TargetDate, categoryPutDTO.TargetCurrency)
+	if err = validateType(existingCategory); err != nil {
+		return nil, false, err
+	}
+
+	updatedCategory, err := c.categoryRepository.UpdateCategory(existingCategory, expectedVersion, categoryPutDTO.UserId)
+	if err != nil {
+		return nil, false, err
+	}
+	c.publish(model.CategoryUpdated, updatedCategory)
+	return updatedCategory, false, nil
 }
 
 func (c *category) DeleteCategory(categoryDeleteDTO model.CategoryDeleteDTO) error {
-	if !c.categoryRepository.CategoryBelongsToUser(categoryDeleteDTO.Id, categoryDeleteDTO.UserId) {
+	categoryToDelete, err := c.categoryRepository.GetCategoryById(categoryDeleteDTO.Id)
+	if err != nil {
 		return serviceerror.CategoryDoesntBelongToUser
 	}
-	return c.categoryRepository.DeleteCategory(categoryDeleteDTO.Id)
+	if err = access.CheckCategory(c.categoryRepository, categoryToDelete, categoryDeleteDTO.UserId, entity.RoleEditor); err != nil {
+		return err
+	}
+	if categoryToDelete.System {
+		return serviceerror.SystemCategoryCantBeDeleted
+	}
+	var expectedVersion uint64
+	if categoryDeleteDTO.Version != nil {
+		expectedVersion = *categoryDeleteDTO.Version
+	}
+	if err = c.categoryRepository.DeleteCategory(categoryDeleteDTO.Id, expectedVersion, categoryDeleteDTO.UserId); err != nil {
+		return err
+	}
+	for _, userId := range access.Recipients(c.householdRepository, categoryToDelete) {
+		c.publisher.Publish(model.NewEvent(model.CategoryDeleted, userId, categoryDeleteDTO))
+	}
+	return nil
+}
+
+func (c *category) checkHouseholdEditor(householdId, userId uint64) error {
+	member, err := c.householdRepository.GetMember(householdId, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return serviceerror.NotHouseholdMember
+	}
+	if err != nil {
+		return err
+	}
+	if !member.Role.Allows(entity.RoleEditor) {
+		return serviceerror.HouseholdEditorRequired
+	}
+	return nil
+}
+
+func (c *category) publish(eventType model.EventType, category *entity.Category) {
+	for _, userId := range access.Recipients(c.householdRepository, category) {
+		e := model.NewEvent(eventType, userId, category)
+		e.Sequence = category.ChangeSeq
+		c.publisher.Publish(e)
+	}
 }
 
 // TODO move attributes validation to validator
 func (c *category) validateUpdateCategoryAttributes(category *entity.Category, categoryUpdateDTO model.CategoryUpdateDTO) error {
-	if categoryUpdateDTO.Name == nil && categoryUpdateDTO.Description == nil {
+	if categoryUpdateDTO.Name == nil && categoryUpdateDTO.Description == nil &&
+		categoryUpdateDTO.TargetAmount == nil && categoryUpdateDTO.TargetDate == nil && categoryUpdateDTO.TargetCurrency == nil {
 		return serviceerror.AtLeastOneFieldIsRequired
 	}
 	if categoryUpdateDTO.Name != nil {
 		if len(*categoryUpdateDTO.Name) < 3 || len(*categoryUpdateDTO.Name) > 128 {
 			return serviceerror.CategoryNameLengthError
 		}
-		if c.categoryRepository.ExistsWithName(categoryUpdateDTO.UserId, *categoryUpdateDTO.Name) {
-			return serviceerror.CategoryAlreadyExists
-		}
 		category.Name = *categoryUpdateDTO.Name
 	}
 	if categoryUpdateDTO.Description != nil {
@@ -71,5 +291,6 @@ func (c *category) validateUpdateCategoryAttributes(category *entity.Category, c
 		}
 		category.Description = *categoryUpdateDTO.Description
 	}
-	return nil
+	category.SetTarget(categoryUpdateDTO.TargetAmount, categoryUpdateDTO.TargetDate, categoryUpdateDTO.TargetCurrency)
+	return validateType(category)
 }
@@ -0,0 +1,22 @@
+package category
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+)
+
+// validateType checks the attributes specific to the category type:
+// goal requires positive target amount, target date and currency, other types don't have them.
+func validateType(category *entity.Category) error {
+	if category.Type != entity.Goal {
+		if category.TargetAmount != nil || category.TargetDate != nil || category.TargetCurrency != nil {
+			return serviceerror.GoalTargetNotAllowed
+		}
+		return nil
+	}
+	if category.TargetAmount == nil || !category.TargetAmount.IsPositive() ||
+		category.TargetDate == nil || category.TargetCurrency == nil {
+		return serviceerror.InvalidGoalTarget
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package category
+
+import (
+	"errors"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetCategoryHistory returns changes of the category, the latest first. History of a deleted category
+// is available too, it ends with the deletion.
+func (c *category) GetCategoryHistory(userId, id uint64) ([]entity.CategoryHistory, error) {
+	category, err := c.categoryRepository.GetCategoryByIdUnscoped(id)
+	if err != nil {
+		return nil, serviceerror.CategoryDoesntExist
+	}
+	if err = access.CheckCategory(c.categoryRepository, category, userId, entity.RoleViewer); err != nil {
+		return nil, err
+	}
+	return c.categoryRepository.GetCategoryHistory(id)
+}
+
+// GetCategoriesAsOf returns categories the user can access now as they were at the time.
+func (c *category) GetCategoriesAsOf(userId uint64, asOf time.Time) ([]entity.Category, error) {
+	return c.categoryRepository.GetCategoriesAsOf(userId, asOf)
+}
+
+// RevertCategory rolls the category back to the name, description, external id and target it had
+// at the version. The revert is a new version, history after the reverted one is kept.
+func (c *category) RevertCategory(categoryRevertDTO model.CategoryRevertDTO) (*entity.Category, error) {
+	categoryToRevert, err := c.categoryRepository.GetCategoryById(categoryRevertDTO.Id)
+	if err != nil {
+		return nil, serviceerror.CategoryDoesntExist
+	}
+	if err = access.CheckCategory(c.categoryRepository, categoryToRevert, categoryRevertDTO.UserId, entity.RoleEditor); err != nil {
+		return nil, err
+	}
+	expectedVersion := categoryToRevert.Version
+	if categoryRevertDTO.ExpectedVersion != nil && *categoryRevertDTO.ExpectedVersion != expectedVersion {
+		return nil, serviceerror.CategoryVersionMismatch
+	}
+	if categoryRevertDTO.Version == expectedVersion {
+		return categoryToRevert, nil
+	}
+
+	version, err := c.categoryRepository.GetCategoryVersion(categoryRevertDTO.Id, categoryRevertDTO.Version)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, serviceerror.CategoryVersionDoesntExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkSystemRename(categoryToRevert, version.Name); err != nil {
+		return nil, err
+	}
+	categoryToRevert.Name = version.Name
+	categoryToRevert.Description = version.Description
+	categoryToRevert.ExternalId = version.ExternalId
+	categoryToRevert.TargetAmount, categoryToRevert.TargetDate, categoryToRevert.TargetCurrency = nil, nil, nil
+	categoryToRevert.SetTarget(version.TargetAmount, version.TargetDate, version.TargetCurrency)
+	if err = validateType(categoryToRevert); err != nil {
+		return nil, err
+	}
+
+	revertedCategory, err := c.categoryRepository.RevertCategory(categoryToRevert, expectedVersion, categoryRevertDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	c.publish(model.CategoryUpdated, revertedCategory)
+	return revertedCategory, nil
+}
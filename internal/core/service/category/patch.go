@@ -0,0 +1,72 @@
+package category
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+)
+
+// readOnlyFields are category JSON fields managed by the service.
+var readOnlyFields = []string{"id", "userId", "householdId", "type", "system", "version", "createdAt", "updatedAt"}
+
+func applyPatch(category *entity.Category, patchType model.PatchType, patch []byte) (*entity.Category, error) {
+	original, err := json.Marshal(category)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch patchType {
+	case model.MergePatch:
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case model.JSONPatch:
+		var operations jsonpatch.Patch
+		operations, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			patched, err = operations.Apply(original)
+		}
+	default:
+		err = fmt.Errorf("unsupported patch type %q", patchType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serviceerror.InvalidPatch, err)
+	}
+
+	if err = checkReadOnlyFields(original, patched); err != nil {
+		return nil, err
+	}
+
+	patchedCategory := &entity.Category{}
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(patchedCategory); err != nil {
+		return nil, fmt.Errorf("%w: %v", serviceerror.InvalidPatch, err)
+	}
+
+	// fields hidden from JSON are kept as is
+	patchedCategory.DeletedAt = category.DeletedAt
+	patchedCategory.CreatedSeq = category.CreatedSeq
+	patchedCategory.ChangeSeq = category.ChangeSeq
+
+	return patchedCategory, nil
+}
+
+func checkReadOnlyFields(original, patched []byte) error {
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(original, &before); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return fmt.Errorf("%w: %v", serviceerror.InvalidPatch, err)
+	}
+	for _, field := range readOnlyFields {
+		if !bytes.Equal(before[field], after[field]) {
+			return fmt.Errorf("%w: %s", serviceerror.ReadOnlyFieldChanged, field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package category
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.category/internal/reference"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// suggestThreshold is the lowest score of a suggestion
+	suggestThreshold = 0.4
+	// similarThreshold is the lowest similarity of a near-duplicate name
+	similarThreshold    = 0.75
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 50
+)
+
+// SuggestCategories ranks user's categories and templates by similarity to the query,
+// a name starting with the query ranks high so suggestions work while typing.
+// Templates the user already has a category for are left out.
+func (c *category) SuggestCategories(userId uint64, query string, limit int) ([]model.CategorySuggestion, error) {
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	limit = min(limit, maxSuggestLimit)
+
+	categories, err := c.categoryRepository.GetCategoriesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	q := newName(query)
+	suggestions := make([]model.CategorySuggestion, 0)
+	existing := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		name := newName(category.Name)
+		existing[name.normalized] = true
+		if score := q.suggestScore(name); score >= suggestThreshold {
+			suggestions = append(suggestions, newSuggestion(category, score))
+		}
+	}
+	for _, template := range reference.Templates() {
+		name := newName(template.Name)
+		if existing[name.normalized] {
+			continue
+		}
+		if score := q.suggestScore(name); score >= suggestThreshold {
+			suggestions = append(suggestions, model.CategorySuggestion{
+				Name:   template.Name,
+				Type:   template.Type,
+				Source: model.SuggestionTemplate,
+				Score:  score,
+			})
+		}
+	}
+
+	// stable sort keeps user's categories ahead of templates with the same score
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// SimilarCategories returns user's categories whose names are near-duplicates of the name.
+func (c *category) SimilarCategories(userId uint64, name string) ([]model.CategorySuggestion, error) {
+	categories, err := c.categoryRepository.GetCategoriesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	n := newName(name)
+	similar := make([]model.CategorySuggestion, 0)
+	for _, category := range categories {
+		if score := n.similarity(newName(category.Name)); score >= similarThreshold {
+			similar = append(similar, newSuggestion(category, score))
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Score > similar[j].Score
+	})
+	return similar, nil
+}
+
+func newSuggestion(category entity.Category, score float64) model.CategorySuggestion {
+	id := category.Id
+	return model.CategorySuggestion{
+		Name:       category.Name,
+		Type:       category.Type,
+		Source:     model.SuggestionCategory,
+		CategoryId: &id,
+		Score:      score,
+	}
+}
+
+// name is a category name prepared for comparison: lower case, punctuation dropped
+// and every word reduced to its singular form.
+type name struct {
+	normalized string
+	tokens     []string
+}
+
+func newName(s string) name {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, token := range fields {
+		fields[i] = singular(token)
+	}
+	return name{normalized: strings.Join(fields, " "), tokens: fields}
+}
+
+// similarity is the larger of normalized edit distance similarity and token overlap.
+func (n name) similarity(other name) float64 {
+	if n.normalized == "" || other.normalized == "" {
+		return 0
+	}
+	if n.normalized == other.normalized {
+		return 1
+	}
+	return max(editSimilarity(n.normalized, other.normalized), tokenOverlap(n.tokens, other.tokens))
+}
+
+// suggestScore adds prefix matching to similarity. A prefix scores at least as high as a one letter
+// typo in a short word, longer prefixes score higher.
+func (n name) suggestScore(other name) float64 {
+	score := n.similarity(other)
+	if n.normalized == "" {
+		return score
+	}
+	for _, token := range append([]string{other.normalized}, other.tokens...) {
+		if strings.HasPrefix(token, n.normalized) {
+			coverage := float64(utf8.RuneCountInString(n.normalized)) / float64(utf8.RuneCountInString(token))
+			score = max(score, 0.75+0.25*coverage)
+		}
+	}
+	return score
+}
+
+// singular drops the common English plural endings.
+func singular(token string) string {
+	switch {
+	case len(token) > 4 && strings.HasSuffix(token, "ies"):
+		return strings.TrimSuffix(token, "ies") + "y"
+	case strings.HasSuffix(token, "ss"):
+		return token
+	case len(token) > 3 && strings.HasSuffix(token, "s"):
+		return strings.TrimSuffix(token, "s")
+	default:
+		return token
+	}
+}
+
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// tokenOverlap is the Jaccard index of the word sets.
+func tokenOverlap(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, token := range a {
+		set[token] = true
+	}
+	union := len(set)
+	shared := 0
+	seen := make(map[string]bool, len(b))
+	for _, token := range b {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		if set[token] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
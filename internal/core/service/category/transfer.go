@@ -0,0 +1,25 @@
+package category
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"strings"
+)
+
+// checkSystemRename rejects renaming the system category to a name of another user's category
+// differing only in case, which the name index lets through.
+func (c *category) checkSystemRename(category *entity.Category, name string) error {
+	if !category.System || category.Name == name {
+		return nil
+	}
+	categories, err := c.categoryRepository.GetCategoriesByUserId(category.UserId)
+	if err != nil {
+		return err
+	}
+	for _, other := range categories {
+		if other.Id != category.Id && other.HouseholdId == nil && strings.EqualFold(other.Name, name) {
+			return serviceerror.CategoryAlreadyExists
+		}
+	}
+	return nil
+}
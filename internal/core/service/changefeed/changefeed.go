@@ -0,0 +1,152 @@
+package changefeed
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"math"
+	"time"
+)
+
+// tokenVersion 2 adds the category id to the position, version 1 tokens point after the whole sequence number.
+const tokenVersion = 2
+
+// afterSeq is the category id of a position after every category with the sequence number.
+// Ids are Postgres bigints, so it is the largest one.
+const afterSeq = math.MaxInt64
+
+type changeFeed struct {
+	categoryRepository repository.CategoryRepository
+	cfg                config.ChangeFeedConfig
+}
+
+// syncToken is the position of a client in user's change feed. Several categories can share
+// the feed sequence number when the user joins or leaves a household, id orders them.
+type syncToken struct {
+	userId   uint64
+	seq      uint64
+	id       uint64
+	issuedAt time.Time
+}
+
+func NewChangeFeedService(repositoryManager *repository.Manager, cfg config.ChangeFeedConfig) service.ChangeFeedService {
+	if cfg.Retention <= 0 {
+		cfg.Retention = config.DefaultChangeFeedConfig.Retention
+	}
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = config.DefaultChangeFeedConfig.PageSize
+	}
+	return &changeFeed{
+		categoryRepository: repositoryManager.Category,
+		cfg:                cfg,
+	}
+}
+
+// GetChanges returns categories changed since the token. Empty token returns all live categories.
+// Token older than the retention horizon requires the client to drop its cache and start over without token.
+func (f *changeFeed) GetChanges(userId uint64, token string) (*model.CategoryChangesDTO, error) {
+	since := syncToken{userId: userId}
+	if token != "" {
+		var err error
+		since, err = parseToken(token)
+		if err != nil || since.userId != userId {
+			return nil, serviceerror.InvalidSyncToken
+		}
+		if time.Since(since.issuedAt) > f.cfg.Retention {
+			return &model.CategoryChangesDTO{
+				Created:            []entity.Category{},
+				Updated:            []entity.Category{},
+				Deleted:            []model.CategoryTombstone{},
+				FullResyncRequired: true,
+			}, nil
+		}
+	}
+
+	categories, err := f.categoryRepository.GetChangedCategories(userId, since.seq, since.id, f.cfg.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &model.CategoryChangesDTO{
+		Created: []entity.Category{},
+		Updated: []entity.Category{},
+		Deleted: []model.CategoryTombstone{},
+	}
+	if len(categories) > f.cfg.PageSize {
+		categories = categories[:f.cfg.PageSize]
+		changes.HasMore = true
+	}
+
+	next := syncToken{userId: userId, seq: since.seq, id: since.id, issuedAt: time.Now()}
+	for _, change := range categories {
+		next.seq, next.id = change.FeedSeq, change.Id
+		category := change.Category
+		// the client has the category when it was created and the household joined before the token
+		seenBefore := category.CreatedSeq <= since.seq && change.JoinedSeq <= since.seq
+		switch {
+		case change.RevokedAt != nil && seenBefore:
+			// the user left the household, the category is gone for them
+			changes.Deleted = append(changes.Deleted, model.CategoryTombstone{
+				Id:        category.Id,
+				DeletedAt: *change.RevokedAt,
+			})
+		case change.RevokedAt != nil:
+		case category.DeletedAt.Valid && seenBefore:
+			changes.Deleted = append(changes.Deleted, model.CategoryTombstone{
+				Id:        category.Id,
+				DeletedAt: category.DeletedAt.Time,
+			})
+		case category.DeletedAt.Valid:
+			// created and deleted in between or before joining, client has never seen it
+		case !seenBefore:
+			changes.Created = append(changes.Created, category)
+		default:
+			changes.Updated = append(changes.Updated, category)
+		}
+	}
+	changes.Token = next.String()
+
+	return changes, nil
+}
+
+// Token returns the sync token pointing right after the change sequence number.
+func (f *changeFeed) Token(userId, seq uint64) string {
+	return syncToken{userId: userId, seq: seq, id: afterSeq, issuedAt: time.Now()}.String()
+}
+
+func (t syncToken) String() string {
+	raw := fmt.Sprintf("%d:%d:%d:%d:%d", tokenVersion, t.userId, t.seq, t.id, t.issuedAt.Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func parseToken(token string) (syncToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return syncToken{}, err
+	}
+	var version int
+	var issuedAt int64
+	t := syncToken{}
+	if _, err = fmt.Sscanf(string(raw), "%d:", &version); err != nil {
+		return syncToken{}, serviceerror.InvalidSyncToken
+	}
+	switch version {
+	case 1:
+		t.id = afterSeq
+		_, err = fmt.Sscanf(string(raw), "%d:%d:%d:%d", &version, &t.userId, &t.seq, &issuedAt)
+	case tokenVersion:
+		_, err = fmt.Sscanf(string(raw), "%d:%d:%d:%d:%d", &version, &t.userId, &t.seq, &t.id, &issuedAt)
+	default:
+		err = serviceerror.InvalidSyncToken
+	}
+	if err != nil {
+		return syncToken{}, serviceerror.InvalidSyncToken
+	}
+	t.issuedAt = time.Unix(issuedAt, 0)
+	return t, nil
+}
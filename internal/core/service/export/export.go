@@ -0,0 +1,89 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"io"
+)
+
+const batchSize = 500
+
+type export struct {
+	categoryRepository repository.CategoryRepository
+}
+
+func NewExportService(repositoryManager *repository.Manager) service.ExportService {
+	return &export{
+		categoryRepository: repositoryManager.Category,
+	}
+}
+
+// ExportCategories writes user's personal categories to w as they are loaded from the database.
+// System categories are left out, the service creates them itself, and so are household ones,
+// which import would turn into personal copies.
+func (e *export) ExportCategories(userId uint64, format model.ExportFormat, w io.Writer) error {
+	switch format {
+	case model.ExportCSV:
+		return e.exportCSV(userId, w)
+	case model.ExportJSON:
+		return e.exportJSON(userId, w)
+	default:
+		return serviceerror.InvalidExportFormat
+	}
+}
+
+func (e *export) exportCSV(userId uint64, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(model.CategoryRecordColumns); err != nil {
+		return err
+	}
+	err := e.categoryRepository.ForEachCategoryByUserId(userId, batchSize, func(category entity.Category) error {
+		if category.System || category.HouseholdId != nil {
+			return nil
+		}
+		return writer.Write(model.NewCategoryRecord(category).CSV())
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (e *export) exportJSON(userId uint64, w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	if _, err := writer.WriteString("["); err != nil {
+		return err
+	}
+	first := true
+	err := e.categoryRepository.ForEachCategoryByUserId(userId, batchSize, func(category entity.Category) error {
+		if category.System || category.HouseholdId != nil {
+			return nil
+		}
+		if !first {
+			if _, err := writer.WriteString(","); err != nil {
+				return err
+			}
+		}
+		first = false
+		record, err := json.Marshal(model.NewCategoryRecord(category))
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(record)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = writer.WriteString("]\n"); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
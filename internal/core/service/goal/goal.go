@@ -0,0 +1,70 @@
+package goal
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+type goal struct {
+	categoryRepository repository.CategoryRepository
+	spendingRepository repository.SpendingRepository
+}
+
+func NewGoalService(repositoryManager *repository.Manager) service.GoalService {
+	return &goal{
+		categoryRepository: repositoryManager.Category,
+		spendingRepository: repositoryManager.Spending,
+	}
+}
+
+// GetGoalProgress sums the contributions to the goal category pushed as spending totals.
+func (g *goal) GetGoalProgress(userId, categoryId uint64) (*model.GoalProgress, error) {
+	category, err := g.categoryRepository.GetCategoryById(categoryId)
+	if err != nil {
+		return nil, serviceerror.CategoryDoesntExist
+	}
+	if err = access.CheckCategory(g.categoryRepository, category, userId, entity.RoleViewer); err != nil {
+		return nil, err
+	}
+	if category.Type != entity.Goal || category.TargetAmount == nil || category.TargetDate == nil || category.TargetCurrency == nil {
+		return nil, serviceerror.CategoryIsNotGoal
+	}
+
+	totals, err := g.spendingRepository.GetCategoryTotals(categoryId)
+	if err != nil {
+		return nil, err
+	}
+	saved := decimal.Zero
+	for _, total := range totals {
+		if total.Currency == *category.TargetCurrency {
+			saved = saved.Add(total.Amount)
+		}
+	}
+
+	target := *category.TargetAmount
+	progress := &model.GoalProgress{
+		CategoryId:     category.Id,
+		Name:           category.Name,
+		TargetAmount:   target,
+		TargetCurrency: *category.TargetCurrency,
+		TargetDate:     *category.TargetDate,
+		Saved:          saved,
+		Remaining:      decimal.Max(target.Sub(saved), decimal.Zero),
+		Percentage:     saved.Div(target).Mul(hundred).Round(2),
+		Achieved:       saved.GreaterThanOrEqual(target),
+	}
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if days := int(category.TargetDate.Sub(today).Hours() / 24); days > 0 {
+		progress.DaysLeft = days
+	}
+	return progress, nil
+}
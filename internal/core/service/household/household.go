@@ -0,0 +1,150 @@
+package household
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+type household struct {
+	householdRepository repository.HouseholdRepository
+	cfg                 config.HouseholdConfig
+}
+
+func NewHouseholdService(repositoryManager *repository.Manager, cfg config.HouseholdConfig) service.HouseholdService {
+	if cfg.InvitationTTL <= 0 {
+		cfg.InvitationTTL = config.DefaultHouseholdConfig.InvitationTTL
+	}
+	return &household{
+		householdRepository: repositoryManager.Household,
+		cfg:                 cfg,
+	}
+}
+
+func (h *household) GetHouseholds(userId uint64) ([]entity.Household, error) {
+	return h.householdRepository.GetHouseholdsByUserId(userId)
+}
+
+// CreateHousehold creates the household owned by the user.
+func (h *household) CreateHousehold(householdCreateDTO model.HouseholdCreateDTO) (*entity.Household, error) {
+	return h.householdRepository.CreateHousehold(&entity.Household{
+		Name:    householdCreateDTO.Name,
+		OwnerId: householdCreateDTO.UserId,
+	})
+}
+
+func (h *household) GetMembers(userId, householdId uint64) ([]entity.HouseholdMember, error) {
+	if _, err := h.getMember(householdId, userId); err != nil {
+		return nil, err
+	}
+	return h.householdRepository.GetMembers(householdId)
+}
+
+// CreateInvitation lets the owner invite a viewer or an editor, the token is returned once.
+func (h *household) CreateInvitation(invitationCreateDTO model.HouseholdInvitationCreateDTO) (*entity.HouseholdInvitation, error) {
+	member, err := h.getMember(invitationCreateDTO.HouseholdId, invitationCreateDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if !member.Role.Allows(entity.RoleOwner) {
+		return nil, serviceerror.HouseholdOwnerRequired
+	}
+	if invitationCreateDTO.InviteeId != nil {
+		_, err = h.householdRepository.GetMember(invitationCreateDTO.HouseholdId, *invitationCreateDTO.InviteeId)
+		if err == nil {
+			return nil, serviceerror.AlreadyHouseholdMember
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	return h.householdRepository.CreateInvitation(&entity.HouseholdInvitation{
+		HouseholdId: invitationCreateDTO.HouseholdId,
+		InvitedBy:   invitationCreateDTO.UserId,
+		UserId:      invitationCreateDTO.InviteeId,
+		Role:        invitationCreateDTO.Role,
+		Token:       token,
+		ExpiresAt:   time.Now().Add(h.cfg.InvitationTTL),
+	})
+}
+
+// AcceptInvitation adds the user to the household with the role of the invitation.
+func (h *household) AcceptInvitation(invitationAcceptDTO model.HouseholdInvitationAcceptDTO) (*entity.HouseholdMember, error) {
+	invitation, err := h.householdRepository.GetInvitationByToken(invitationAcceptDTO.Token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, serviceerror.InvalidInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if invitation.AcceptedAt != nil || !now.Before(invitation.ExpiresAt) {
+		return nil, serviceerror.InvalidInvitation
+	}
+	if invitation.UserId != nil && *invitation.UserId != invitationAcceptDTO.UserId {
+		return nil, serviceerror.InvitationForAnotherUser
+	}
+
+	member := &entity.HouseholdMember{
+		HouseholdId: invitation.HouseholdId,
+		UserId:      invitationAcceptDTO.UserId,
+		Role:        invitation.Role,
+	}
+	invitation.AcceptedBy = &invitationAcceptDTO.UserId
+	invitation.AcceptedAt = &now
+	if err = h.householdRepository.AcceptInvitation(invitation, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember lets the owner remove other members and members leave the household themselves.
+func (h *household) RemoveMember(memberDeleteDTO model.HouseholdMemberDeleteDTO) error {
+	member, err := h.getMember(memberDeleteDTO.HouseholdId, memberDeleteDTO.UserId)
+	if err != nil {
+		return err
+	}
+	if memberDeleteDTO.MemberId == memberDeleteDTO.UserId {
+		if member.Role == entity.RoleOwner {
+			return serviceerror.HouseholdOwnerCantLeave
+		}
+		return h.householdRepository.RemoveMember(memberDeleteDTO.HouseholdId, memberDeleteDTO.MemberId)
+	}
+	if member.Role != entity.RoleOwner {
+		return serviceerror.HouseholdOwnerRequired
+	}
+	if _, err = h.getMember(memberDeleteDTO.HouseholdId, memberDeleteDTO.MemberId); err != nil {
+		return err
+	}
+	return h.householdRepository.RemoveMember(memberDeleteDTO.HouseholdId, memberDeleteDTO.MemberId)
+}
+
+// getMember returns the membership of the user, household existence is not revealed to non-members.
+func (h *household) getMember(householdId, userId uint64) (*entity.HouseholdMember, error) {
+	member, err := h.householdRepository.GetMember(householdId, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, serviceerror.NotHouseholdMember
+	}
+	return member, err
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
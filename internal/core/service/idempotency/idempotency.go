@@ -0,0 +1,100 @@
+package idempotency
+
+import (
+	"errors"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+const maxKeyLength = 255
+
+type idempotency struct {
+	idempotencyRepository repository.IdempotencyRepository
+	cfg                   config.IdempotencyConfig
+	mu                    sync.Mutex
+	purgedAt              time.Time
+}
+
+func NewIdempotencyService(repositoryManager *repository.Manager, cfg config.IdempotencyConfig) service.IdempotencyService {
+	if cfg.TTL <= 0 {
+		cfg.TTL = config.DefaultIdempotencyConfig.TTL
+	}
+	return &idempotency{
+		idempotencyRepository: repositoryManager.Idempotency,
+		cfg:                   cfg,
+		purgedAt:              time.Now(),
+	}
+}
+
+func (s *idempotency) Begin(userId uint64, key, fingerprint string) (*entity.IdempotencyKey, error) {
+	if key == "" || len(key) > maxKeyLength {
+		return nil, serviceerror.InvalidIdempotencyKey
+	}
+	s.purgeExpired()
+
+	now := time.Now()
+	reserved, err := s.idempotencyRepository.CreateKey(&entity.IdempotencyKey{
+		UserId:      userId,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.cfg.TTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	stored, err := s.idempotencyRepository.GetKey(userId, key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// expired or released right now, the client can retry
+		return nil, serviceerror.IdempotencyKeyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored.Fingerprint != fingerprint {
+		return nil, serviceerror.IdempotencyKeyReused
+	}
+	if !stored.Completed {
+		return nil, serviceerror.IdempotencyKeyInProgress
+	}
+	return stored, nil
+}
+
+func (s *idempotency) Complete(userId uint64, key string, statusCode int, headers map[string]string, body []byte) error {
+	return s.idempotencyRepository.CompleteKey(&entity.IdempotencyKey{
+		UserId:     userId,
+		Key:        key,
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       body,
+	})
+}
+
+// Release forgets the key of a failed request, so the client can retry it.
+func (s *idempotency) Release(userId uint64, key string) error {
+	return s.idempotencyRepository.DeleteKey(userId, key)
+}
+
+// purgeExpired deletes expired keys at most once per TTL.
+func (s *idempotency) purgeExpired() {
+	s.mu.Lock()
+	now := time.Now()
+	if now.Sub(s.purgedAt) < s.cfg.TTL {
+		s.mu.Unlock()
+		return
+	}
+	s.purgedAt = now
+	s.mu.Unlock()
+
+	_ = s.idempotencyRepository.DeleteExpiredKeys(now)
+}
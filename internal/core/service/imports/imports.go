@@ -0,0 +1,248 @@
+package imports
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/event"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/transfer"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	maxNameLength = 128
+	// detectSize is the length of the file beginning used for format detection
+	detectSize = 4 << 10
+)
+
+// errDryRun rolls back the import transaction after all changes were tried.
+var errDryRun = errors.New("dry run")
+
+type imports struct {
+	categoryRepository repository.CategoryRepository
+	publisher          event.Publisher
+	importers          []importer.Importer
+	cfg                config.ImportConfig
+	validate           *validator.Validate
+}
+
+// NewImportService creates the import service, importers are tried in order when the file format is not given.
+func NewImportService(repositoryManager *repository.Manager, publisher event.Publisher, importers []importer.Importer, cfg config.ImportConfig) service.ImportService {
+	if cfg.MaxRows <= 0 {
+		cfg.MaxRows = config.DefaultImportConfig.MaxRows
+	}
+	return &imports{
+		categoryRepository: repositoryManager.Category,
+		publisher:          publisher,
+		importers:          importers,
+		cfg:                cfg,
+		validate:           model.GetCategoryValidator(),
+	}
+}
+
+// ImportCategories validates every category of the file and applies them in one transaction,
+// the transfer category is created with them unless the user has one.
+// When any category is invalid nothing is imported and the report is returned with ImportHasInvalidRows.
+// Dry run makes the same changes and rolls them back.
+func (s *imports) ImportCategories(categoryImportDTO model.CategoryImportDTO) (*model.CategoryImportReport, error) {
+	conflict := categoryImportDTO.Conflict
+	switch conflict {
+	case "":
+		conflict = model.ConflictSkip
+	case model.ConflictSkip, model.ConflictRename, model.ConflictOverwrite:
+	default:
+		return nil, serviceerror.InvalidConflictPolicy
+	}
+
+	rows, err := s.parse(categoryImportDTO.Format, categoryImportDTO.File)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.CategoryImportReport{
+		DryRun: categoryImportDTO.DryRun,
+		Rows:   make([]model.CategoryImportRow, 0, len(rows)),
+	}
+
+	categories := make([]*entity.Category, len(rows))
+	for i, row := range rows {
+		category := &entity.Category{
+			UserId:      categoryImportDTO.UserId,
+			Name:        row.Name,
+			Description: row.Description,
+			Type:        row.Type,
+			ExternalId:  row.ExternalId,
+		}
+		category.SetTarget(row.TargetAmount, row.TargetDate, row.TargetCurrency)
+		err = s.validate.Struct(category)
+		switch {
+		case err != nil:
+		case category.Type == entity.Transfer:
+			err = serviceerror.TransferCategoryCantBeImported
+		case category.Type == entity.Goal && !category.TargetAmount.IsPositive():
+			err = serviceerror.InvalidGoalTarget
+		}
+		if err != nil {
+			report.Invalid++
+			report.Rows = append(report.Rows, model.CategoryImportRow{
+				Row:    i + 1,
+				Name:   row.Name,
+				Status: model.ImportInvalid,
+				Error:  err.Error(),
+			})
+			continue
+		}
+		categories[i] = category
+	}
+	if report.Invalid > 0 {
+		return report, serviceerror.ImportHasInvalidRows
+	}
+
+	var events []model.Event
+	err = s.categoryRepository.Transaction(func(categoryRepository repository.CategoryRepository) error {
+		existingCategories, err := categoryRepository.GetCategoriesByUserId(categoryImportDTO.UserId)
+		if err != nil {
+			return err
+		}
+		byName := make(map[string]*entity.Category, len(existingCategories)+len(categories))
+		for i := range existingCategories {
+			// imported categories are personal, names of household ones don't conflict
+			if existingCategories[i].HouseholdId == nil {
+				byName[existingCategories[i].Name] = &existingCategories[i]
+			}
+		}
+
+		for i, category := range categories {
+			row := model.CategoryImportRow{Row: i + 1, Name: category.Name}
+			existingCategory, conflicts := byName[category.Name]
+
+			switch {
+			case conflicts && conflict == model.ConflictSkip,
+				conflicts && conflict == model.ConflictOverwrite && !overwrites(existingCategory, category):
+				row.Status = model.ImportSkipped
+				row.CategoryId = existingCategory.Id
+				report.Skipped++
+
+			case conflicts && conflict == model.ConflictOverwrite:
+				existingCategory.Description = category.Description
+				if existingCategory.Type == entity.Goal && category.Type == entity.Goal {
+					existingCategory.TargetAmount, existingCategory.TargetDate, existingCategory.TargetCurrency = nil, nil, nil
+					existingCategory.SetTarget(category.TargetAmount, category.TargetDate, category.TargetCurrency)
+				}
+				updatedCategory, err := categoryRepository.UpdateCategory(existingCategory, existingCategory.Version, categoryImportDTO.UserId)
+				if err != nil {
+					return fmt.Errorf("row %d: %w", row.Row, err)
+				}
+				byName[updatedCategory.Name] = updatedCategory
+				events = append(events, categoryEvent(model.CategoryUpdated, updatedCategory))
+				row.Status = model.ImportUpdated
+				row.CategoryId = updatedCategory.Id
+				report.Updated++
+
+			default:
+				row.Status = model.ImportCreated
+				if conflicts {
+					category.Name = uniqueName(category.Name, byName)
+					row.Name = category.Name
+					row.Status = model.ImportRenamed
+				}
+				createdCategory, err := categoryRepository.CreateCategory(category)
+				if err != nil {
+					return fmt.Errorf("row %d: %w", row.Row, err)
+				}
+				byName[createdCategory.Name] = createdCategory
+				events = append(events, categoryEvent(model.CategoryCreated, createdCategory))
+				if !categoryImportDTO.DryRun {
+					row.CategoryId = createdCategory.Id
+				}
+				report.Created++
+			}
+
+			report.Rows = append(report.Rows, row)
+		}
+
+		transferCategory, err := transfer.Ensure(categoryRepository, categoryImportDTO.UserId)
+		if err != nil {
+			return err
+		}
+		if transferCategory != nil {
+			events = append(events, categoryEvent(model.CategoryCreated, transferCategory))
+		}
+
+		if categoryImportDTO.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errDryRun):
+	case err != nil:
+		return nil, err
+	default:
+		for _, e := range events {
+			s.publisher.Publish(e)
+		}
+	}
+
+	return report, nil
+}
+
+// parse reads the file with the importer of the format, or with the first importer that recognizes the file.
+func (s *imports) parse(format model.ImportFormat, file io.Reader) ([]model.CategoryCreateDTO, error) {
+	reader := bufio.NewReaderSize(file, detectSize)
+	head, _ := reader.Peek(detectSize)
+	for _, fileImporter := range s.importers {
+		if format == fileImporter.Format() || format == "" && fileImporter.Detect(head) {
+			return fileImporter.Parse(reader, s.cfg.MaxRows)
+		}
+	}
+	if format != "" {
+		return nil, fmt.Errorf("%w: unsupported format %q", serviceerror.InvalidImportFile, format)
+	}
+	return nil, fmt.Errorf("%w: unknown format", serviceerror.InvalidImportFile)
+}
+
+// overwrites tells whether overwriting the existing category with the imported one changes it:
+// the description, and the target when both are goals.
+func overwrites(existing, imported *entity.Category) bool {
+	if existing.Description != imported.Description {
+		return true
+	}
+	if existing.Type != entity.Goal || imported.Type != entity.Goal {
+		return false
+	}
+	return !existing.TargetAmount.Equal(*imported.TargetAmount) ||
+		!existing.TargetDate.Equal(*imported.TargetDate) ||
+		*existing.TargetCurrency != *imported.TargetCurrency
+}
+
+// uniqueName appends the first free " (n)" suffix, shortening the name to fit the length limit.
+func uniqueName(name string, taken map[string]*entity.Category) string {
+	for n := 2; ; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		base := name
+		for utf8.RuneCountInString(base)+len(suffix) > maxNameLength {
+			_, size := utf8.DecodeLastRuneInString(base)
+			base = base[:len(base)-size]
+		}
+		if _, ok := taken[base+suffix]; !ok {
+			return base + suffix
+		}
+	}
+}
+
+func categoryEvent(eventType model.EventType, category *entity.Category) model.Event {
+	e := model.NewEvent(eventType, category.UserId, category)
+	e.Sequence = category.ChangeSeq
+	return e
+}
@@ -1,13 +1,52 @@
 package service
 
 import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	eventadapter "github.com/khivuksergey/portmonetka.category/internal/adapter/event"
+	importeradapter "github.com/khivuksergey/portmonetka.category/internal/adapter/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/event"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/account"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/audit"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/budget"
 	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/changefeed"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/export"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/goal"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/household"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/idempotency"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/imports"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/mcc"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/rules"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/smartgroup"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/stream"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/tag"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/tax"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/webhook"
 )
 
-func NewServiceManager(repositoryManager *repository.Manager) *service.Manager {
+func NewServiceManager(repositoryManager *repository.Manager, broadcaster event.Broadcaster, cfg *config.Configuration) *service.Manager {
+	webhookService := webhook.NewWebhookService(repositoryManager, cfg.Webhook)
+	changeFeedService := changefeed.NewChangeFeedService(repositoryManager, cfg.ChangeFeed)
+	publisher := eventadapter.NewFanOutPublisher(broadcaster, webhookService)
 	return &service.Manager{
-		Category: category.NewCategoryService(repositoryManager),
+		Category:    category.NewCategoryService(repositoryManager, publisher),
+		Webhook:     webhookService,
+		ChangeFeed:  changeFeedService,
+		Stream:      stream.NewStreamService(broadcaster, changeFeedService),
+		Idempotency: idempotency.NewIdempotencyService(repositoryManager, cfg.Idempotency),
+		Export:      export.NewExportService(repositoryManager),
+		Import:      imports.NewImportService(repositoryManager, publisher, importeradapter.Default(), cfg.Import),
+		Mcc:         mcc.NewMccService(repositoryManager),
+		Rules:       rules.NewRulesService(repositoryManager, cfg.Rules),
+		Budget:      budget.NewBudgetService(repositoryManager, publisher, cfg.Budget),
+		Goal:        goal.NewGoalService(repositoryManager),
+		Tax:         tax.NewTaxService(repositoryManager),
+		Household:   household.NewHouseholdService(repositoryManager, cfg.Household),
+		Account:     account.NewAccountService(repositoryManager),
+		Tag:         tag.NewTagService(repositoryManager),
+		SmartGroup:  smartgroup.NewSmartGroupService(repositoryManager),
+		Audit:       audit.NewAuditService(repositoryManager),
 	}
 }
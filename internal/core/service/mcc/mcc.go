@@ -0,0 +1,141 @@
+package mcc
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.category/internal/reference"
+	"sort"
+	"strings"
+)
+
+type mcc struct {
+	mccMappingRepository repository.MccMappingRepository
+	categoryRepository   repository.CategoryRepository
+}
+
+func NewMccService(repositoryManager *repository.Manager) service.MccService {
+	return &mcc{
+		mccMappingRepository: repositoryManager.MccMapping,
+		categoryRepository:   repositoryManager.Category,
+	}
+}
+
+func (m *mcc) GetMccMappings(userId uint64) ([]entity.MccMapping, error) {
+	return m.mccMappingRepository.GetMccMappingsByUserId(userId)
+}
+
+func (m *mcc) CreateMccMapping(mccMappingCreateDTO model.MccMappingCreateDTO) (*entity.MccMapping, error) {
+	mccRange, ok := reference.ParseMccRange(mccMappingCreateDTO.Mcc)
+	if !ok {
+		return nil, serviceerror.InvalidMcc
+	}
+	if err := m.checkCategory(mccMappingCreateDTO.CategoryId, mccMappingCreateDTO.UserId); err != nil {
+		return nil, err
+	}
+	return m.mccMappingRepository.CreateMccMapping(&entity.MccMapping{
+		UserId:     mccMappingCreateDTO.UserId,
+		MccFrom:    mccRange.From,
+		MccTo:      mccRange.To,
+		CategoryId: mccMappingCreateDTO.CategoryId,
+	})
+}
+
+func (m *mcc) UpdateMccMapping(mccMappingUpdateDTO model.MccMappingUpdateDTO) (*entity.MccMapping, error) {
+	mappingToUpdate, err := m.mccMappingRepository.GetMccMappingById(mccMappingUpdateDTO.Id)
+	if err != nil {
+		return nil, serviceerror.MccMappingDoesntExist
+	}
+	if mappingToUpdate.UserId != mccMappingUpdateDTO.UserId {
+		return nil, serviceerror.MccMappingDoesntBelongToUser
+	}
+	if mccMappingUpdateDTO.Mcc != nil {
+		mccRange, ok := reference.ParseMccRange(*mccMappingUpdateDTO.Mcc)
+		if !ok {
+			return nil, serviceerror.InvalidMcc
+		}
+		mappingToUpdate.MccFrom, mappingToUpdate.MccTo = mccRange.From, mccRange.To
+	}
+	if mccMappingUpdateDTO.CategoryId != nil {
+		if err = m.checkCategory(*mccMappingUpdateDTO.CategoryId, mccMappingUpdateDTO.UserId); err != nil {
+			return nil, err
+		}
+		mappingToUpdate.CategoryId = *mccMappingUpdateDTO.CategoryId
+	}
+	return m.mccMappingRepository.UpdateMccMapping(mappingToUpdate)
+}
+
+func (m *mcc) DeleteMccMapping(mccMappingDeleteDTO model.MccMappingDeleteDTO) error {
+	if !m.mccMappingRepository.MccMappingBelongsToUser(mccMappingDeleteDTO.Id, mccMappingDeleteDTO.UserId) {
+		return serviceerror.MccMappingDoesntBelongToUser
+	}
+	return m.mccMappingRepository.DeleteMccMapping(mccMappingDeleteDTO.Id)
+}
+
+// ResolveMcc finds user's category for the merchant category code. The narrowest user's mapping wins,
+// the newest one among equally narrow. Without a mapping the code falls back to its reference template
+// and the user's category with the same name.
+func (m *mcc) ResolveMcc(mccResolveDTO model.MccResolveDTO) (*model.MccResolution, error) {
+	if !reference.ValidMcc(mccResolveDTO.Mcc) {
+		return nil, serviceerror.InvalidMcc
+	}
+	resolution := &model.MccResolution{Mcc: mccResolveDTO.Mcc, Source: model.MccSourceNone}
+	if merchantCategory, ok := reference.LookupMcc(mccResolveDTO.Mcc); ok {
+		resolution.Description = merchantCategory.Description
+		resolution.Template = merchantCategory.Template
+	}
+
+	mappings, err := m.mccMappingRepository.GetMccMappingsByMcc(mccResolveDTO.UserId, mccResolveDTO.Mcc)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(mappings, func(i, j int) bool {
+		wi, wj := mappingWidth(mappings[i]), mappingWidth(mappings[j])
+		if wi != wj {
+			return wi < wj
+		}
+		return mappings[i].Id > mappings[j].Id
+	})
+	for _, mapping := range mappings {
+		category, err := m.categoryRepository.GetCategoryById(mapping.CategoryId)
+		if err != nil || access.CheckCategory(m.categoryRepository, category, mccResolveDTO.UserId, entity.RoleViewer) != nil {
+			// category was deleted or left the household after the mapping was created
+			continue
+		}
+		resolution.Source = model.MccSourceMapping
+		resolution.MappingId = &mapping.Id
+		resolution.Category = category
+		return resolution, nil
+	}
+
+	if resolution.Template == "" {
+		return resolution, nil
+	}
+	categories, err := m.categoryRepository.GetCategoriesByUserId(mccResolveDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range categories {
+		if strings.EqualFold(categories[i].Name, resolution.Template) {
+			resolution.Source = model.MccSourceTemplate
+			resolution.Category = &categories[i]
+			break
+		}
+	}
+	return resolution, nil
+}
+
+func (m *mcc) checkCategory(categoryId, userId uint64) error {
+	category, err := m.categoryRepository.GetCategoryById(categoryId)
+	if err != nil {
+		return serviceerror.CategoryDoesntExist
+	}
+	return access.CheckCategory(m.categoryRepository, category, userId, entity.RoleEditor)
+}
+
+func mappingWidth(mapping entity.MccMapping) int {
+	return reference.MccRange{From: mapping.MccFrom, To: mapping.MccTo}.Width()
+}
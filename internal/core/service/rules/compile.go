@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/shopspring/decimal"
+	"regexp"
+	"strings"
+)
+
+const maxValueLength = 512
+
+// transaction is the descriptor prepared once for evaluation of all rules.
+type transaction struct {
+	description string
+	lower       string
+	amount      decimal.Decimal
+}
+
+type predicate func(t *transaction) bool
+
+type compiledRule struct {
+	id         uint64
+	categoryId uint64
+	match      predicate
+}
+
+// compiler turns condition trees into predicates and counts their nodes against the limit.
+type compiler struct {
+	maxConditions int
+	conditions    int
+}
+
+func compile(rule entity.Rule, maxConditions int) (compiledRule, error) {
+	c := &compiler{maxConditions: maxConditions}
+	match, err := c.compile(rule.Condition)
+	if err != nil {
+		return compiledRule{}, err
+	}
+	return compiledRule{id: rule.Id, categoryId: rule.CategoryId, match: match}, nil
+}
+
+func (c *compiler) compile(condition entity.RuleCondition) (predicate, error) {
+	c.conditions++
+	if c.conditions > c.maxConditions {
+		return nil, invalid("condition has more than %d nodes", c.maxConditions)
+	}
+
+	switch condition.Op {
+	case entity.RuleAnd, entity.RuleOr:
+		return c.compileGroup(condition)
+	case entity.RuleContains, entity.RuleEquals, entity.RulePrefix, entity.RuleRegex:
+		if len(condition.Conditions) > 0 || condition.Min != nil || condition.Max != nil {
+			return nil, invalid("%s accepts only value", condition.Op)
+		}
+		return compileText(condition)
+	case entity.RuleAmount:
+		if len(condition.Conditions) > 0 || condition.Value != "" {
+			return nil, invalid("amount accepts only min and max")
+		}
+		return compileAmount(condition)
+	default:
+		return nil, invalid("unknown operator %q", condition.Op)
+	}
+}
+
+func (c *compiler) compileGroup(condition entity.RuleCondition) (predicate, error) {
+	if len(condition.Conditions) == 0 {
+		return nil, invalid("%s requires conditions", condition.Op)
+	}
+	if condition.Value != "" || condition.Min != nil || condition.Max != nil {
+		return nil, invalid("%s accepts only conditions", condition.Op)
+	}
+	children := make([]predicate, 0, len(condition.Conditions))
+	for _, child := range condition.Conditions {
+		match, err := c.compile(child)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, match)
+	}
+	if condition.Op == entity.RuleAnd {
+		return func(t *transaction) bool {
+			for _, match := range children {
+				if !match(t) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	}
+	return func(t *transaction) bool {
+		for _, match := range children {
+			if match(t) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func compileText(condition entity.RuleCondition) (predicate, error) {
+	if condition.Value == "" {
+		return nil, invalid("%s requires value", condition.Op)
+	}
+	if len(condition.Value) > maxValueLength {
+		return nil, invalid("value must be at most %d symbols long", maxValueLength)
+	}
+
+	if condition.Op == entity.RuleRegex {
+		expr := condition.Value
+		if !condition.CaseSensitive {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, invalid("invalid regex: %v", err)
+		}
+		return func(t *transaction) bool { return re.MatchString(t.description) }, nil
+	}
+
+	value := condition.Value
+	text := func(t *transaction) string { return t.description }
+	if !condition.CaseSensitive {
+		value = strings.ToLower(value)
+		text = func(t *transaction) string { return t.lower }
+	}
+	switch condition.Op {
+	case entity.RuleContains:
+		return func(t *transaction) bool { return strings.Contains(text(t), value) }, nil
+	case entity.RulePrefix:
+		return func(t *transaction) bool { return strings.HasPrefix(text(t), value) }, nil
+	default:
+		return func(t *transaction) bool { return text(t) == value }, nil
+	}
+}
+
+func compileAmount(condition entity.RuleCondition) (predicate, error) {
+	if condition.Min == nil && condition.Max == nil {
+		return nil, invalid("amount requires min or max")
+	}
+	low, high := decimal.Zero, condition.Max
+	if condition.Min != nil {
+		low = *condition.Min
+	}
+	if low.IsNegative() || (high != nil && low.GreaterThanOrEqual(*high)) {
+		return nil, invalid("amount range must be non-negative with min less than max")
+	}
+	return func(t *transaction) bool {
+		amount := t.amount.Abs()
+		return amount.GreaterThanOrEqual(low) && (high == nil || amount.LessThan(*high))
+	}, nil
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", serviceerror.InvalidRuleCondition, fmt.Sprintf(format, args...))
+}
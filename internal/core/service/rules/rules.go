@@ -0,0 +1,217 @@
+package rules
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"strings"
+	"sync"
+	"time"
+)
+
+type rules struct {
+	ruleRepository     repository.RuleRepository
+	categoryRepository repository.CategoryRepository
+	cfg                config.RulesConfig
+	cache              map[uint64]cachedRules
+	// generations count invalidations of user's rules, a load started before one is not cached
+	generations map[uint64]uint64
+	nextSweep   time.Time
+	mu          sync.Mutex
+}
+
+// cachedRules are user's enabled rules compiled in evaluation order.
+type cachedRules struct {
+	rules     []compiledRule
+	expiresAt time.Time
+}
+
+func NewRulesService(repositoryManager *repository.Manager, cfg config.RulesConfig) service.RulesService {
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = config.DefaultRulesConfig.CacheTTL
+	}
+	if cfg.MaxConditions <= 0 {
+		cfg.MaxConditions = config.DefaultRulesConfig.MaxConditions
+	}
+	return &rules{
+		ruleRepository:     repositoryManager.Rule,
+		categoryRepository: repositoryManager.Category,
+		cfg:                cfg,
+		cache:              make(map[uint64]cachedRules),
+		generations:        make(map[uint64]uint64),
+	}
+}
+
+func (r *rules) GetRules(userId uint64) ([]entity.Rule, error) {
+	return r.ruleRepository.GetRulesByUserId(userId)
+}
+
+func (r *rules) CreateRule(ruleCreateDTO model.RuleCreateDTO) (*entity.Rule, error) {
+	rule := &entity.Rule{
+		UserId:     ruleCreateDTO.UserId,
+		Name:       ruleCreateDTO.Name,
+		Priority:   ruleCreateDTO.Priority,
+		CategoryId: ruleCreateDTO.CategoryId,
+		Condition:  ruleCreateDTO.Condition,
+		Enabled:    ruleCreateDTO.Enabled == nil || *ruleCreateDTO.Enabled,
+	}
+	if _, err := compile(*rule, r.cfg.MaxConditions); err != nil {
+		return nil, err
+	}
+	if err := r.checkCategory(rule.CategoryId, rule.UserId); err != nil {
+		return nil, err
+	}
+	createdRule, err := r.ruleRepository.CreateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(rule.UserId)
+	return createdRule, nil
+}
+
+func (r *rules) UpdateRule(ruleUpdateDTO model.RuleUpdateDTO) (*entity.Rule, error) {
+	ruleToUpdate, err := r.ruleRepository.GetRuleById(ruleUpdateDTO.Id)
+	if err != nil {
+		return nil, serviceerror.RuleDoesntExist
+	}
+	if ruleToUpdate.UserId != ruleUpdateDTO.UserId {
+		return nil, serviceerror.RuleDoesntBelongToUser
+	}
+	if ruleUpdateDTO.Name != nil {
+		ruleToUpdate.Name = *ruleUpdateDTO.Name
+	}
+	if ruleUpdateDTO.Priority != nil {
+		ruleToUpdate.Priority = *ruleUpdateDTO.Priority
+	}
+	if ruleUpdateDTO.Enabled != nil {
+		ruleToUpdate.Enabled = *ruleUpdateDTO.Enabled
+	}
+	if ruleUpdateDTO.Condition != nil {
+		ruleToUpdate.Condition = *ruleUpdateDTO.Condition
+		if _, err = compile(*ruleToUpdate, r.cfg.MaxConditions); err != nil {
+			return nil, err
+		}
+	}
+	if ruleUpdateDTO.CategoryId != nil {
+		if err = r.checkCategory(*ruleUpdateDTO.CategoryId, ruleUpdateDTO.UserId); err != nil {
+			return nil, err
+		}
+		ruleToUpdate.CategoryId = *ruleUpdateDTO.CategoryId
+	}
+	updatedRule, err := r.ruleRepository.UpdateRule(ruleToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ruleToUpdate.UserId)
+	return updatedRule, nil
+}
+
+func (r *rules) DeleteRule(ruleDeleteDTO model.RuleDeleteDTO) error {
+	if !r.ruleRepository.RuleBelongsToUser(ruleDeleteDTO.Id, ruleDeleteDTO.UserId) {
+		return serviceerror.RuleDoesntBelongToUser
+	}
+	if err := r.ruleRepository.DeleteRule(ruleDeleteDTO.Id); err != nil {
+		return err
+	}
+	r.invalidate(ruleDeleteDTO.UserId)
+	return nil
+}
+
+// Classify evaluates user's rules against every transaction of the batch. Rules targeting
+// categories deleted since are skipped, so the next matching rule wins.
+func (r *rules) Classify(classifyDTO model.ClassifyDTO) ([]model.Classification, error) {
+	compiled, err := r.compiled(classifyDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := r.categoryRepository.GetCategoriesByUserId(classifyDTO.UserId)
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[uint64]bool, len(categories))
+	for _, category := range categories {
+		live[category.Id] = true
+	}
+
+	classifications := make([]model.Classification, len(classifyDTO.Transactions))
+	for i, descriptor := range classifyDTO.Transactions {
+		classifications[i].Id = descriptor.Id
+		t := &transaction{
+			description: descriptor.Description,
+			lower:       strings.ToLower(descriptor.Description),
+			amount:      descriptor.Amount,
+		}
+		for _, rule := range compiled {
+			if !live[rule.categoryId] || !rule.match(t) {
+				continue
+			}
+			classifications[i].CategoryId = &rule.categoryId
+			classifications[i].RuleId = &rule.id
+			break
+		}
+	}
+	return classifications, nil
+}
+
+// compiled returns user's enabled rules from the cache, compiling them on a miss.
+// Stored rules that no longer compile, e.g. after the limits were lowered, are ignored.
+func (r *rules) compiled(userId uint64) ([]compiledRule, error) {
+	now := time.Now()
+	r.mu.Lock()
+	cached, ok := r.cache[userId]
+	generation := r.generations[userId]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.rules, nil
+	}
+
+	stored, err := r.ruleRepository.GetRulesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	compiled := make([]compiledRule, 0, len(stored))
+	for _, rule := range stored {
+		if !rule.Enabled {
+			continue
+		}
+		if c, err := compile(rule, r.cfg.MaxConditions); err == nil {
+			compiled = append(compiled, c)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.After(r.nextSweep) {
+		for id, entry := range r.cache {
+			if now.After(entry.expiresAt) {
+				delete(r.cache, id)
+			}
+		}
+		r.nextSweep = now.Add(r.cfg.CacheTTL)
+	}
+	if r.generations[userId] != generation {
+		// the rules changed while they were loaded, the loaded ones may be stale
+		return compiled, nil
+	}
+	r.cache[userId] = cachedRules{rules: compiled, expiresAt: now.Add(r.cfg.CacheTTL)}
+	return compiled, nil
+}
+
+func (r *rules) invalidate(userId uint64) {
+	r.mu.Lock()
+	delete(r.cache, userId)
+	r.generations[userId]++
+	r.mu.Unlock()
+}
+
+func (r *rules) checkCategory(categoryId, userId uint64) error {
+	category, err := r.categoryRepository.GetCategoryById(categoryId)
+	if err != nil {
+		return serviceerror.CategoryDoesntExist
+	}
+	return access.CheckCategory(r.categoryRepository, category, userId, entity.RoleEditor)
+}
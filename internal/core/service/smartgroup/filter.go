@@ -0,0 +1,79 @@
+package smartgroup
+
+import (
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"sort"
+)
+
+const maxFilterNodes = 50
+
+// validator checks the filter tree and collects the tags it refers to.
+type validator struct {
+	nodes  int
+	tagIds map[uint64]bool
+}
+
+func validateFilter(filter entity.GroupFilter) (tagIds []uint64, err error) {
+	v := &validator{tagIds: make(map[uint64]bool)}
+	if err = v.validate(filter); err != nil {
+		return nil, err
+	}
+	for tagId := range v.tagIds {
+		tagIds = append(tagIds, tagId)
+	}
+	sort.Slice(tagIds, func(i, j int) bool { return tagIds[i] < tagIds[j] })
+	return tagIds, nil
+}
+
+func (v *validator) validate(filter entity.GroupFilter) error {
+	v.nodes++
+	if v.nodes > maxFilterNodes {
+		return invalid("filter has more than %d nodes", maxFilterNodes)
+	}
+
+	switch filter.Op {
+	case entity.FilterAnd, entity.FilterOr, entity.FilterNot:
+		if filter.TagId != 0 || filter.Type != "" {
+			return invalid("%s accepts only filters", filter.Op)
+		}
+		if len(filter.Filters) == 0 {
+			return invalid("%s requires filters", filter.Op)
+		}
+		if filter.Op == entity.FilterNot && len(filter.Filters) != 1 {
+			return invalid("not requires a single filter")
+		}
+		for _, child := range filter.Filters {
+			if err := v.validate(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case entity.FilterTag:
+		if filter.Type != "" || len(filter.Filters) > 0 {
+			return invalid("tag accepts only tagId")
+		}
+		if filter.TagId == 0 {
+			return invalid("tag requires tagId")
+		}
+		v.tagIds[filter.TagId] = true
+		return nil
+	case entity.FilterType:
+		if filter.TagId != 0 || len(filter.Filters) > 0 {
+			return invalid("type accepts only type")
+		}
+		switch filter.Type {
+		case entity.Income, entity.Expense, entity.Goal, entity.Transfer:
+			return nil
+		default:
+			return invalid("unknown category type %q", filter.Type)
+		}
+	default:
+		return invalid("unknown operator %q", filter.Op)
+	}
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", serviceerror.InvalidGroupFilter, fmt.Sprintf(format, args...))
+}
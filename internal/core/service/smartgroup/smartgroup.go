@@ -0,0 +1,98 @@
+package smartgroup
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+)
+
+type smartGroup struct {
+	tagRepository      repository.TagRepository
+	categoryRepository repository.CategoryRepository
+}
+
+func NewSmartGroupService(repositoryManager *repository.Manager) service.SmartGroupService {
+	return &smartGroup{
+		tagRepository:      repositoryManager.Tag,
+		categoryRepository: repositoryManager.Category,
+	}
+}
+
+func (s *smartGroup) GetSmartGroups(userId uint64) ([]entity.SmartGroup, error) {
+	return s.tagRepository.GetSmartGroupsByUserId(userId)
+}
+
+func (s *smartGroup) CreateSmartGroup(groupCreateDTO model.SmartGroupCreateDTO) (*entity.SmartGroup, error) {
+	if err := s.checkFilter(groupCreateDTO.UserId, groupCreateDTO.Filter); err != nil {
+		return nil, err
+	}
+	return s.tagRepository.CreateSmartGroup(&entity.SmartGroup{
+		UserId: groupCreateDTO.UserId,
+		Name:   groupCreateDTO.Name,
+		Filter: groupCreateDTO.Filter,
+	})
+}
+
+func (s *smartGroup) UpdateSmartGroup(groupUpdateDTO model.SmartGroupUpdateDTO) (*entity.SmartGroup, error) {
+	groupToUpdate, err := s.getGroup(groupUpdateDTO.UserId, groupUpdateDTO.Id)
+	if err != nil {
+		return nil, err
+	}
+	if groupUpdateDTO.Name != nil {
+		groupToUpdate.Name = *groupUpdateDTO.Name
+	}
+	if groupUpdateDTO.Filter != nil {
+		if err = s.checkFilter(groupUpdateDTO.UserId, *groupUpdateDTO.Filter); err != nil {
+			return nil, err
+		}
+		groupToUpdate.Filter = *groupUpdateDTO.Filter
+	}
+	return s.tagRepository.UpdateSmartGroup(groupToUpdate)
+}
+
+func (s *smartGroup) DeleteSmartGroup(groupDeleteDTO model.SmartGroupDeleteDTO) error {
+	if _, err := s.getGroup(groupDeleteDTO.UserId, groupDeleteDTO.Id); err != nil {
+		return err
+	}
+	return s.tagRepository.DeleteSmartGroup(groupDeleteDTO.Id)
+}
+
+// GetGroupCategories resolves the group filter against the categories the user can access now.
+func (s *smartGroup) GetGroupCategories(userId, id uint64) ([]entity.Category, error) {
+	group, err := s.getGroup(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.categoryRepository.GetCategoriesByFilter(userId, group.Filter)
+}
+
+func (s *smartGroup) getGroup(userId, id uint64) (*entity.SmartGroup, error) {
+	group, err := s.tagRepository.GetSmartGroupById(id)
+	if err != nil {
+		return nil, serviceerror.SmartGroupDoesntExist
+	}
+	if group.UserId != userId {
+		return nil, serviceerror.SmartGroupDoesntBelongToUser
+	}
+	return group, nil
+}
+
+// checkFilter validates the filter and rejects tags of other users.
+func (s *smartGroup) checkFilter(userId uint64, filter entity.GroupFilter) error {
+	tagIds, err := validateFilter(filter)
+	if err != nil {
+		return err
+	}
+	for _, tagId := range tagIds {
+		tag, err := s.tagRepository.GetTagById(tagId)
+		if err != nil {
+			return invalid("tag %d doesn't exist", tagId)
+		}
+		if tag.UserId != userId {
+			return serviceerror.TagDoesntBelongToUser
+		}
+	}
+	return nil
+}
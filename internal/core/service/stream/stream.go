@@ -0,0 +1,59 @@
+package stream
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/event"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+)
+
+type stream struct {
+	broadcaster       event.Broadcaster
+	changeFeedService service.ChangeFeedService
+}
+
+func NewStreamService(broadcaster event.Broadcaster, changeFeedService service.ChangeFeedService) service.StreamService {
+	return &stream{
+		broadcaster:       broadcaster,
+		changeFeedService: changeFeedService,
+	}
+}
+
+func (s *stream) Subscribe(userId uint64) (<-chan model.Event, func()) {
+	return s.broadcaster.Subscribe(userId)
+}
+
+// Message converts the event to server-sent event. Its id is a sync token, so the client
+// can resume the stream with Last-Event-ID or switch to the change feed.
+func (s *stream) Message(e model.Event) model.StreamMessage {
+	message := model.StreamMessage{Event: e.Type, Data: e}
+	if e.Sequence > 0 {
+		message.Id = s.changeFeedService.Token(e.UserId, e.Sequence)
+	}
+	return message
+}
+
+// Replay returns changes missed since the last received event as pages of the change feed.
+func (s *stream) Replay(userId uint64, lastEventId string) ([]model.StreamMessage, error) {
+	var messages []model.StreamMessage
+	token := lastEventId
+	for {
+		changes, err := s.changeFeedService.GetChanges(userId, token)
+		if err != nil {
+			return nil, err
+		}
+		if changes.FullResyncRequired {
+			return append(messages, model.StreamMessage{Event: model.Resync, Data: changes}), nil
+		}
+		if len(changes.Created)+len(changes.Updated)+len(changes.Deleted) > 0 {
+			messages = append(messages, model.StreamMessage{
+				Id:    changes.Token,
+				Event: model.CategoryChanges,
+				Data:  changes,
+			})
+		}
+		if !changes.HasMore {
+			return messages, nil
+		}
+		token = changes.Token
+	}
+}
@@ -0,0 +1,114 @@
+package tag
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"strings"
+)
+
+type tag struct {
+	tagRepository      repository.TagRepository
+	categoryRepository repository.CategoryRepository
+}
+
+func NewTagService(repositoryManager *repository.Manager) service.TagService {
+	return &tag{
+		tagRepository:      repositoryManager.Tag,
+		categoryRepository: repositoryManager.Category,
+	}
+}
+
+func (t *tag) GetTags(userId uint64) ([]entity.Tag, error) {
+	return t.tagRepository.GetTagsByUserId(userId)
+}
+
+func (t *tag) CreateTag(tagCreateDTO model.TagCreateDTO) (*entity.Tag, error) {
+	name, err := tagName(tagCreateDTO.Name)
+	if err != nil {
+		return nil, err
+	}
+	return t.tagRepository.CreateTag(&entity.Tag{
+		UserId: tagCreateDTO.UserId,
+		Name:   name,
+	})
+}
+
+func (t *tag) UpdateTag(tagUpdateDTO model.TagUpdateDTO) (*entity.Tag, error) {
+	tagToUpdate, err := t.getTag(tagUpdateDTO.UserId, tagUpdateDTO.Id)
+	if err != nil {
+		return nil, err
+	}
+	if tagToUpdate.Name, err = tagName(tagUpdateDTO.Name); err != nil {
+		return nil, err
+	}
+	return t.tagRepository.UpdateTag(tagToUpdate)
+}
+
+// DeleteTag deletes the tag and its assignments. Smart groups filtering by it stop matching it.
+func (t *tag) DeleteTag(tagDeleteDTO model.TagDeleteDTO) error {
+	if _, err := t.getTag(tagDeleteDTO.UserId, tagDeleteDTO.Id); err != nil {
+		return err
+	}
+	return t.tagRepository.DeleteTag(tagDeleteDTO.Id)
+}
+
+func (t *tag) GetCategoryTags(userId, categoryId uint64) ([]entity.Tag, error) {
+	if err := t.checkCategory(userId, categoryId); err != nil {
+		return nil, err
+	}
+	return t.tagRepository.GetCategoryTags(userId, categoryId)
+}
+
+// AssignTag labels the category with user's tag. Tags are personal, so viewers of household
+// categories can tag them too.
+func (t *tag) AssignTag(categoryTagDTO model.CategoryTagDTO) error {
+	if err := t.checkCategory(categoryTagDTO.UserId, categoryTagDTO.CategoryId); err != nil {
+		return err
+	}
+	if _, err := t.getTag(categoryTagDTO.UserId, categoryTagDTO.TagId); err != nil {
+		return err
+	}
+	return t.tagRepository.AssignTag(categoryTagDTO.CategoryId, categoryTagDTO.TagId)
+}
+
+func (t *tag) UnassignTag(categoryTagDTO model.CategoryTagDTO) error {
+	if err := t.checkCategory(categoryTagDTO.UserId, categoryTagDTO.CategoryId); err != nil {
+		return err
+	}
+	if _, err := t.getTag(categoryTagDTO.UserId, categoryTagDTO.TagId); err != nil {
+		return err
+	}
+	return t.tagRepository.UnassignTag(categoryTagDTO.CategoryId, categoryTagDTO.TagId)
+}
+
+func (t *tag) getTag(userId, id uint64) (*entity.Tag, error) {
+	found, err := t.tagRepository.GetTagById(id)
+	if err != nil {
+		return nil, serviceerror.TagDoesntExist
+	}
+	if found.UserId != userId {
+		return nil, serviceerror.TagDoesntBelongToUser
+	}
+	return found, nil
+}
+
+func (t *tag) checkCategory(userId, categoryId uint64) error {
+	category, err := t.categoryRepository.GetCategoryById(categoryId)
+	if err != nil {
+		return serviceerror.CategoryDoesntExist
+	}
+	return access.CheckCategory(t.categoryRepository, category, userId, entity.RoleViewer)
+}
+
+// tagName trims the name, tags differing only in surrounding spaces are the same tag.
+func tagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", serviceerror.TagNameRequired
+	}
+	return name, nil
+}
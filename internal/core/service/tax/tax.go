@@ -0,0 +1,186 @@
+package tax
+
+import (
+	"errors"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/access"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.category/internal/reference"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+type tax struct {
+	taxRepository      repository.TaxRepository
+	categoryRepository repository.CategoryRepository
+}
+
+func NewTaxService(repositoryManager *repository.Manager) service.TaxService {
+	return &tax{
+		taxRepository:      repositoryManager.Tax,
+		categoryRepository: repositoryManager.Category,
+	}
+}
+
+func (t *tax) GetTaxCodes(jurisdiction string) ([]reference.TaxCode, error) {
+	jurisdiction = strings.ToUpper(jurisdiction)
+	if jurisdiction != "" && !reference.ValidJurisdiction(jurisdiction) {
+		return nil, serviceerror.InvalidJurisdiction
+	}
+	return reference.TaxCodes(jurisdiction), nil
+}
+
+// GetCategoryTax returns tax attributes of the category, not deductible and unmapped when they were never set.
+func (t *tax) GetCategoryTax(userId, categoryId uint64) (*entity.CategoryTax, error) {
+	if _, err := t.getCategory(userId, categoryId, entity.RoleViewer); err != nil {
+		return nil, err
+	}
+	categoryTax, err := t.taxRepository.GetTaxByCategoryId(categoryId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.CategoryTax{CategoryId: categoryId, UserId: userId}, nil
+	}
+	return categoryTax, err
+}
+
+func (t *tax) SetCategoryTax(categoryTaxDTO model.CategoryTaxDTO) (*entity.CategoryTax, error) {
+	category, err := t.getCategory(categoryTaxDTO.UserId, categoryTaxDTO.CategoryId, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if category.Type != entity.Income && category.Type != entity.Expense {
+		return nil, serviceerror.TaxAttributesNotAllowed
+	}
+
+	categoryTax := &entity.CategoryTax{
+		CategoryId: category.Id,
+		UserId:     categoryTaxDTO.UserId,
+		Deductible: categoryTaxDTO.Deductible,
+		UpdatedAt:  time.Now(),
+	}
+	percentage := categoryTaxDTO.DeductiblePercentage
+	switch {
+	case !categoryTaxDTO.Deductible:
+		if percentage != nil && !percentage.IsZero() {
+			return nil, serviceerror.InvalidDeductible
+		}
+	case category.Type != entity.Expense:
+		return nil, serviceerror.InvalidDeductible
+	case percentage == nil:
+		categoryTax.DeductiblePercentage = hundred
+	case !percentage.IsPositive() || percentage.GreaterThan(hundred):
+		return nil, serviceerror.InvalidDeductible
+	default:
+		categoryTax.DeductiblePercentage = percentage.Round(2)
+	}
+
+	if (categoryTaxDTO.Jurisdiction == nil) != (categoryTaxDTO.TaxCode == nil) {
+		return nil, serviceerror.InvalidTaxCode
+	}
+	if categoryTaxDTO.Jurisdiction != nil {
+		jurisdiction := strings.ToUpper(*categoryTaxDTO.Jurisdiction)
+		if !reference.ValidJurisdiction(jurisdiction) {
+			return nil, serviceerror.InvalidJurisdiction
+		}
+		taxCode, ok := reference.GetTaxCode(jurisdiction, strings.ToUpper(*categoryTaxDTO.TaxCode))
+		if !ok || taxCode.Type != category.Type {
+			return nil, serviceerror.InvalidTaxCode
+		}
+		categoryTax.Jurisdiction = &taxCode.Jurisdiction
+		categoryTax.TaxCode = &taxCode.Code
+	}
+
+	return t.taxRepository.SaveTax(categoryTax)
+}
+
+func (t *tax) DeleteCategoryTax(userId, categoryId uint64) error {
+	if _, err := t.getCategory(userId, categoryId, entity.RoleEditor); err != nil {
+		return err
+	}
+	return t.taxRepository.DeleteTax(categoryId)
+}
+
+// GetTaxSummary lists the tax codes user's live categories are mapped to in the reference order,
+// followed by the deductible categories without a code.
+func (t *tax) GetTaxSummary(userId uint64, jurisdiction string) ([]model.TaxSummaryLine, error) {
+	jurisdiction = strings.ToUpper(jurisdiction)
+	if jurisdiction != "" && !reference.ValidJurisdiction(jurisdiction) {
+		return nil, serviceerror.InvalidJurisdiction
+	}
+	categories, err := t.categoryRepository.GetCategoriesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	taxes, err := t.taxRepository.GetTaxesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[uint64]entity.Category, len(categories))
+	for _, category := range categories {
+		byId[category.Id] = category
+	}
+
+	byCode := make(map[reference.TaxCode][]model.TaxSummaryCategory)
+	var unmapped []model.TaxSummaryCategory
+	for _, categoryTax := range taxes {
+		category, ok := byId[categoryTax.CategoryId]
+		if !ok {
+			continue
+		}
+		summaryCategory := model.TaxSummaryCategory{
+			CategoryId:           category.Id,
+			Name:                 category.Name,
+			Type:                 category.Type,
+			Deductible:           categoryTax.Deductible,
+			DeductiblePercentage: categoryTax.DeductiblePercentage,
+		}
+		if categoryTax.TaxCode == nil {
+			if categoryTax.Deductible {
+				unmapped = append(unmapped, summaryCategory)
+			}
+			continue
+		}
+		taxCode, ok := reference.GetTaxCode(*categoryTax.Jurisdiction, *categoryTax.TaxCode)
+		if ok && (jurisdiction == "" || taxCode.Jurisdiction == jurisdiction) {
+			byCode[taxCode] = append(byCode[taxCode], summaryCategory)
+		}
+	}
+
+	summary := make([]model.TaxSummaryLine, 0, len(byCode)+1)
+	for _, taxCode := range reference.TaxCodes(jurisdiction) {
+		if summaryCategories, ok := byCode[taxCode]; ok {
+			summary = append(summary, model.TaxSummaryLine{
+				Jurisdiction: taxCode.Jurisdiction,
+				TaxCode:      taxCode.Code,
+				Form:         taxCode.Form,
+				Line:         taxCode.Line,
+				Description:  taxCode.Description,
+				Categories:   summaryCategories,
+			})
+		}
+	}
+	if len(unmapped) > 0 {
+		summary = append(summary, model.TaxSummaryLine{
+			Description: "Deductible, not mapped to a tax code",
+			Categories:  unmapped,
+		})
+	}
+	return summary, nil
+}
+
+func (t *tax) getCategory(userId, categoryId uint64, role entity.HouseholdRole) (*entity.Category, error) {
+	category, err := t.categoryRepository.GetCategoryById(categoryId)
+	if err != nil {
+		return nil, serviceerror.CategoryDoesntExist
+	}
+	if err = access.CheckCategory(t.categoryRepository, category, userId, role); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
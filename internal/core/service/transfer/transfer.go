@@ -0,0 +1,55 @@
+// Package transfer keeps the system TRANSFER category of moves between user's own accounts.
+// The category is created along with the user's first personal category, by creating or importing
+// categories, so no read path writes: a user without personal categories has no transfer category yet.
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"strings"
+)
+
+// Ensure creates user's transfer category unless it exists and returns it, nil is returned when nothing
+// was created. It is meant to run in the transaction creating personal categories, so they are committed
+// together. The name gets a numeric suffix when a personal category is named like it.
+func Ensure(categoryRepository repository.CategoryRepository, userId uint64) (*entity.Category, error) {
+	exists, err := categoryRepository.HasTransferCategory(userId)
+	if err != nil || exists {
+		return nil, err
+	}
+
+	// the list is loaded once per user, when the first personal category is created
+	categories, err := categoryRepository.GetCategoriesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		if category.HouseholdId == nil {
+			names[strings.ToLower(category.Name)] = true
+		}
+	}
+	name := entity.TransferCategoryName
+	for i := 2; names[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s %d", entity.TransferCategoryName, i)
+	}
+
+	createdCategory, err := categoryRepository.CreateCategory(&entity.Category{
+		UserId:      userId,
+		Name:        name,
+		Description: "Moves between own accounts",
+		Type:        entity.Transfer,
+		System:      true,
+	})
+	if errors.Is(err, serviceerror.TransferCategoryExists) || errors.Is(err, serviceerror.CategoryAlreadyExists) {
+		// created by a concurrent request, or the name was taken by one and the next write retries
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return createdCategory, nil
+}
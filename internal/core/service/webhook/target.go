@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// checkUrl rejects webhook URLs that are not https or name a forbidden address literally.
+// Host names are checked again when deliveries connect, DNS can resolve them differently by then.
+func (w *webhook) checkUrl(rawUrl string) error {
+	if w.cfg.AllowInsecureTargets {
+		return nil
+	}
+	target, err := url.Parse(rawUrl)
+	if err != nil || target.Scheme != "https" {
+		return serviceerror.InsecureWebhookUrl
+	}
+	if ip := net.ParseIP(target.Hostname()); ip != nil && forbiddenAddress(ip) {
+		return serviceerror.InsecureWebhookUrl
+	}
+	return nil
+}
+
+// newClient creates the delivery client. Unless insecure targets are allowed, it connects to https URLs
+// only and refuses forbidden addresses at connect time, after DNS resolution, so a host name rebound
+// to an internal address after the webhook was saved can't be reached. Redirects are not followed.
+func newClient(timeout time.Duration, allowInsecureTargets bool) *http.Client {
+	if allowInsecureTargets {
+		return &http.Client{Timeout: timeout}
+	}
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || forbiddenAddress(ip) {
+				return fmt.Errorf("%w: %s", serviceerror.InsecureWebhookUrl, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the target
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkScheme keeps webhooks saved before https was required from being delivered over http.
+func (w *webhook) checkScheme(req *http.Request) error {
+	if !w.cfg.AllowInsecureTargets && req.URL.Scheme != "https" {
+		return serviceerror.InsecureWebhookUrl
+	}
+	return nil
+}
+
+// forbiddenAddress tells whether the address is loopback, private, link-local, e.g. cloud metadata
+// at 169.254.169.254, unspecified or multicast.
+func forbiddenAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
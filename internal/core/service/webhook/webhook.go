@@ -0,0 +1,252 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader  = "X-Portmonetka-Signature"
+	EventHeader      = "X-Portmonetka-Event"
+	DeliveryHeader   = "X-Portmonetka-Delivery"
+	deliveriesLimit  = 100
+	responseReadSize = 64 << 10
+)
+
+type webhook struct {
+	webhookRepository repository.WebhookRepository
+	client            *http.Client
+	cfg               config.WebhookConfig
+	wg                sync.WaitGroup
+	quit              chan struct{}
+	closed            bool
+	mu                sync.RWMutex
+}
+
+type payload struct {
+	Id string `json:"id"`
+	model.Event
+}
+
+func NewWebhookService(repositoryManager *repository.Manager, cfg config.WebhookConfig) service.WebhookService {
+	cfg = withDefaults(cfg)
+	return &webhook{
+		webhookRepository: repositoryManager.Webhook,
+		client:            newClient(cfg.Timeout, cfg.AllowInsecureTargets),
+		cfg:               cfg,
+		quit:              make(chan struct{}),
+	}
+}
+
+func (w *webhook) GetWebhooksByUserId(userId uint64) ([]entity.Webhook, error) {
+	return w.webhookRepository.GetWebhooksByUserId(userId)
+}
+
+func (w *webhook) CreateWebhook(webhookCreateDTO model.WebhookCreateDTO) (*entity.Webhook, error) {
+	if err := w.checkUrl(webhookCreateDTO.Url); err != nil {
+		return nil, err
+	}
+	return w.webhookRepository.CreateWebhook(&entity.Webhook{
+		UserId:  webhookCreateDTO.UserId,
+		Url:     webhookCreateDTO.Url,
+		Secret:  webhookCreateDTO.Secret,
+		Events:  webhookCreateDTO.Events,
+		Enabled: true,
+	})
+}
+
+func (w *webhook) UpdateWebhook(webhookUpdateDTO model.WebhookUpdateDTO) (*entity.Webhook, error) {
+	webhookToUpdate, err := w.webhookRepository.GetWebhookById(webhookUpdateDTO.Id)
+	if err != nil {
+		return nil, serviceerror.WebhookDoesntExist
+	}
+	if webhookToUpdate.UserId != webhookUpdateDTO.UserId {
+		return nil, serviceerror.WebhookDoesntBelongToUser
+	}
+	if webhookUpdateDTO.Url != nil {
+		if err = w.checkUrl(*webhookUpdateDTO.Url); err != nil {
+			return nil, err
+		}
+		webhookToUpdate.Url = *webhookUpdateDTO.Url
+	}
+	if webhookUpdateDTO.Secret != nil {
+		webhookToUpdate.Secret = *webhookUpdateDTO.Secret
+	}
+	if webhookUpdateDTO.Events != nil {
+		webhookToUpdate.Events = *webhookUpdateDTO.Events
+	}
+	if webhookUpdateDTO.Enabled != nil {
+		webhookToUpdate.Enabled = *webhookUpdateDTO.Enabled
+		if webhookToUpdate.Enabled {
+			webhookToUpdate.ConsecutiveFailures = 0
+		}
+	}
+	return w.webhookRepository.UpdateWebhook(webhookToUpdate)
+}
+
+func (w *webhook) DeleteWebhook(webhookDeleteDTO model.WebhookDeleteDTO) error {
+	if !w.webhookRepository.WebhookBelongsToUser(webhookDeleteDTO.Id, webhookDeleteDTO.UserId) {
+		return serviceerror.WebhookDoesntBelongToUser
+	}
+	return w.webhookRepository.DeleteWebhook(webhookDeleteDTO.Id)
+}
+
+func (w *webhook) GetDeliveries(webhookId, userId uint64) ([]entity.WebhookDelivery, error) {
+	if !w.webhookRepository.WebhookBelongsToUser(webhookId, userId) {
+		return nil, serviceerror.WebhookDoesntBelongToUser
+	}
+	return w.webhookRepository.GetDeliveriesByWebhookId(webhookId, deliveriesLimit)
+}
+
+// Publish delivers the event to every enabled webhook of the user in background.
+func (w *webhook) Publish(event model.Event) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		webhooks, err := w.webhookRepository.GetEnabledWebhooksByUserId(event.UserId)
+		if err != nil {
+			return
+		}
+		for _, hook := range webhooks {
+			if !hook.Subscribed(string(event.Type)) {
+				continue
+			}
+			w.wg.Add(1)
+			go func(hook entity.Webhook) {
+				defer w.wg.Done()
+				w.deliver(hook, event)
+			}(hook)
+		}
+	}()
+}
+
+// Shutdown stops pending retries and waits for in-flight deliveries to finish.
+func (w *webhook) Shutdown() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.quit)
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+	return nil
+}
+
+func (w *webhook) deliver(hook entity.Webhook, event model.Event) {
+	deliveryId := uuid.New().String()
+	body, err := json.Marshal(payload{Id: deliveryId, Event: event})
+	if err != nil {
+		return
+	}
+
+	backoff := w.cfg.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		statusCode, err := w.send(hook, event.Type, deliveryId, body)
+
+		delivery := &entity.WebhookDelivery{
+			WebhookId:  hook.Id,
+			DeliveryId: deliveryId,
+			Event:      string(event.Type),
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Success:    err == nil,
+			Duration:   time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		_ = w.webhookRepository.CreateDelivery(delivery)
+
+		if err == nil {
+			if hook.ConsecutiveFailures > 0 {
+				_ = w.webhookRepository.ResetFailures(hook.Id)
+			}
+			return
+		}
+		if attempt >= w.cfg.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-w.quit:
+			return
+		}
+		backoff = min(backoff*2, w.cfg.MaxBackoff)
+	}
+
+	_ = w.webhookRepository.RegisterFailure(hook.Id, w.cfg.DisableAfterFailures)
+}
+
+func (w *webhook) send(hook entity.Webhook, eventType model.EventType, deliveryId string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, hook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	if err = w.checkScheme(req); err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(eventType))
+	req.Header.Set(DeliveryHeader, deliveryId)
+	req.Header.Set(SignatureHeader, "sha256="+sign(hook.Secret, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, responseReadSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func withDefaults(cfg config.WebhookConfig) config.WebhookConfig {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = config.DefaultWebhookConfig.Timeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = config.DefaultWebhookConfig.MaxAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = config.DefaultWebhookConfig.InitialBackoff
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = max(config.DefaultWebhookConfig.MaxBackoff, cfg.InitialBackoff)
+	}
+	if cfg.DisableAfterFailures <= 0 {
+		cfg.DisableAfterFailures = config.DefaultWebhookConfig.DisableAfterFailures
+	}
+	return cfg
+}
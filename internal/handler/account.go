@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type AccountHandler struct {
+	accountService service.AccountService
+	logger         logger.Logger
+	validate       *validator.Validate
+}
+
+func NewAccountHandler(services *service.Manager, logger logger.Logger) *AccountHandler {
+	return &AccountHandler{
+		accountService: services.Account,
+		logger:         logger,
+		validate:       model.GetCategoryValidator(),
+	}
+}
+
+// GetCategoryAccounts retrieves accounts the category is linked to.
+//
+// @Tags Account
+// @Summary Get category accounts
+// @Description Gets ids of the accounts the category is shown for, the category is shown for all accounts when there are none
+// @ID get-category-accounts
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Success 200 {object} model.Response{data=[]string} "Category accounts retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/accounts [get]
+func (w AccountHandler) GetCategoryAccounts(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+
+	accountIds, err := w.accountService.GetCategoryAccounts(userId, categoryId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetAccounts, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetCategoryAccounts",
+		Message:     "Category accounts retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Category accounts retrieved",
+		Data:        accountIds,
+		RequestUuid: requestUuid,
+	})
+}
+
+// SetCategoryAccounts replaces accounts the category is linked to.
+//
+// @Tags Account
+// @Summary Set category accounts
+// @Description Replaces ids of the accounts the category is shown for. Account ids are opaque ids of the wallet service,
+// @Description an empty list shows the category for all accounts
+// @ID set-category-accounts
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param accounts body model.CategoryAccountsDTO true "Account ids"
+// @Success 200 {object} model.Response{data=[]string} "Category accounts set"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/accounts [put]
+func (w AccountHandler) SetCategoryAccounts(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	categoryAccountsDTO := &model.CategoryAccountsDTO{}
+
+	err := bindDtoValidate[model.CategoryAccountsDTO](c, w.validate, categoryAccountsDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	categoryAccountsDTO.UserId = userId
+	categoryAccountsDTO.CategoryId = categoryId
+
+	accountIds, err := w.accountService.SetCategoryAccounts(*categoryAccountsDTO)
+	if err != nil {
+		return accountError(serviceerror.CannotSetAccounts, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "SetCategoryAccounts",
+		Message:     "Category accounts set",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Category accounts set",
+		Data:        accountIds,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteCategoryAccount unlinks the category from the account.
+//
+// @Tags Account
+// @Summary Unlink category account
+// @Description Stops showing the category for the account, the category is shown for all accounts when it was the last one
+// @ID delete-category-account
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param accountId path string true "Account ID"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/accounts/{accountId} [delete]
+func (w AccountHandler) DeleteCategoryAccount(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	accountId := c.Param("accountId")
+
+	if err := w.accountService.DeleteCategoryAccount(userId, categoryId, accountId); err != nil {
+		return accountError(serviceerror.CannotDeleteAccount, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteCategoryAccount",
+		Message:     "Category account unlinked",
+		UserId:      &userId,
+		Data:        map[string]string{"categoryId": c.Param("categoryId"), "accountId": accountId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func accountError(message string, err error) error {
+	if errors.Is(err, serviceerror.InvalidAccountId) {
+		return common.NewValidationError(message, err)
+	}
+	return common.NewUnprocessableEntityError(message, err)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"slices"
+	"time"
+)
+
+type AuditHandler struct {
+	auditService service.AuditService
+	cfg          config.AuditConfig
+	logger       logger.Logger
+}
+
+func NewAuditHandler(services *service.Manager, cfg config.AuditConfig, logger logger.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: services.Audit,
+		cfg:          cfg,
+		logger:       logger,
+	}
+}
+
+// RequireAdmin lets through callers whose JWT subject is one of the configured administrators.
+func (w AuditHandler) RequireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		subject, ok := TokenSubject(c)
+		if !ok {
+			return common.NewAuthorizationError(serviceerror.AdminRequired.Error(), nil)
+		}
+		if !slices.Contains(w.cfg.AdminUserIds, subject) {
+			return echo.NewHTTPError(http.StatusForbidden, serviceerror.AdminRequired.Error())
+		}
+		c.Set("userId", subject)
+		return next(c)
+	}
+}
+
+// TokenSubject returns the subject of the JWT the request was verified with.
+func TokenSubject(c echo.Context) (uint64, bool) {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return 0, false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, false
+	}
+	sub, ok := claims["sub"].(float64)
+	if !ok {
+		return 0, false
+	}
+	return uint64(sub), true
+}
+
+// VerifyAuditLog checks integrity of the audit log.
+//
+// @Tags Audit
+// @Summary Verify audit log
+// @Description Recomputes hashes of the audit entries created in the range and checks each links to the previous one of its chain without gaps.
+// @Description Every user has a chain, calls without a user form chain 0. Without from and to the whole log is checked. Keep lastHash of the chains to detect later removal of the latest entries
+// @ID verify-audit-log
+// @Produce json
+// @Param from query string false "RFC 3339 start of the range, inclusive"
+// @Param to query string false "RFC 3339 end of the range, exclusive"
+// @Success 200 {object} model.Response{data=model.AuditVerification} "Audit log verified"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 401 {object} model.Response "Unauthorized"
+// @Failure 403 {object} model.Response "Not an administrator"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /admin/audit/verify [get]
+func (w AuditHandler) VerifyAuditLog(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	start, end, err := auditRange(c)
+	if err != nil {
+		return err
+	}
+
+	verification, err := w.auditService.Verify(start, end)
+	if errors.Is(err, serviceerror.InvalidAuditRange) {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotVerifyAudit, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "VerifyAuditLog",
+		Message:     "Audit log verified",
+		UserId:      &userId,
+		Data:        map[string]bool{"valid": verification.Valid},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Audit log verified",
+		Data:        verification,
+		RequestUuid: requestUuid,
+	})
+}
+
+// ExportAuditLog streams the audit log.
+//
+// @Tags Audit
+// @Summary Export audit log
+// @Description Downloads the audit entries created in the range as JSON lines in the order they were recorded. Hash of an entry is the hex SHA-256
+// @Description of its JSON with zero id, empty hash and UTC createdAt, prevHash is the hash of the previous entry of the same chain
+// @ID export-audit-log
+// @Produce application/x-ndjson
+// @Param from query string false "RFC 3339 start of the range, inclusive"
+// @Param to query string false "RFC 3339 end of the range, exclusive"
+// @Success 200 {array} entity.AuditEntry "Audit entries"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 401 {object} model.Response "Unauthorized"
+// @Failure 403 {object} model.Response "Not an administrator"
+// @Router /admin/audit/export [get]
+func (w AuditHandler) ExportAuditLog(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	start, end, err := auditRange(c)
+	if err != nil {
+		return err
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return common.NewValidationError(serviceerror.InvalidInputData, serviceerror.InvalidAuditRange)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="audit-%s.jsonl"`, time.Now().UTC().Format("20060102T150405Z")))
+	res.WriteHeader(http.StatusOK)
+
+	if err = w.auditService.Export(start, end, res); err != nil {
+		// the status is already sent, the client gets a truncated file
+		w.logger.Error(logger.LogMessage{
+			Action:      "ExportAuditLog",
+			Message:     serviceerror.CannotExportAudit,
+			UserId:      &userId,
+			Data:        err.Error(),
+			RequestUuid: requestUuid,
+		})
+		return nil
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "ExportAuditLog",
+		Message:     "Audit log exported",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return nil
+}
+
+// auditRange parses optional from and to query parameters.
+func auditRange(c echo.Context) (start, end time.Time, err error) {
+	if from := c.QueryParam("from"); from != "" {
+		if start, err = time.Parse(time.RFC3339, from); err != nil {
+			return start, end, common.NewValidationError(serviceerror.InvalidInputData, serviceerror.InvalidAuditRange)
+		}
+	}
+	if to := c.QueryParam("to"); to != "" {
+		if end, err = time.Parse(time.RFC3339, to); err != nil {
+			return start, end, common.NewValidationError(serviceerror.InvalidInputData, serviceerror.InvalidAuditRange)
+		}
+	}
+	return start, end, nil
+}
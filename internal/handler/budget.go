@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type BudgetHandler struct {
+	budgetService service.BudgetService
+	logger        logger.Logger
+	validate      *validator.Validate
+}
+
+func NewBudgetHandler(services *service.Manager, logger logger.Logger) *BudgetHandler {
+	return &BudgetHandler{
+		budgetService: services.Budget,
+		logger:        logger,
+		validate:      model.GetCategoryValidator(),
+	}
+}
+
+// GetBudgets retrieves budgets of the category.
+//
+// @Tags Budget
+// @Summary Get category budgets
+// @Description Gets spending limits of user's category
+// @ID get-budgets
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Success 200 {object} model.Response "Budgets retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/budgets [get]
+func (w BudgetHandler) GetBudgets(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+
+	budgets, err := w.budgetService.GetBudgets(userId, categoryId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetBudgets, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetBudgets",
+		Message:     "Budgets retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Budgets retrieved",
+		Data:        budgets,
+		RequestUuid: requestUuid,
+	})
+}
+
+// CreateBudget creates a new budget of the category.
+//
+// @Tags Budget
+// @Summary Create a new budget
+// @Description Adds a spending limit to user's expense category. Amounts are decimal strings.
+// @Description Weekly, monthly and yearly budgets repeat from the start date, one per category, period and currency. Custom budget runs from start to end date.
+// @Description Rollover carries the unspent amount (surplus) or the unspent amount and the overspending (full) to the next period, up to the rollover limit.
+// @ID create-budget
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param budget body model.BudgetCreateDTO true "Budget object to be created"
+// @Success 201 {object} model.Response "Budget created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/budgets [post]
+func (w BudgetHandler) CreateBudget(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	budgetCreateDTO := &model.BudgetCreateDTO{}
+
+	err := bindDtoValidate[model.BudgetCreateDTO](c, w.validate, budgetCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	budgetCreateDTO.UserId = userId
+	budgetCreateDTO.CategoryId = categoryId
+
+	budget, err := w.budgetService.CreateBudget(*budgetCreateDTO)
+	if err != nil {
+		return budgetError(serviceerror.CannotCreateBudget, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateBudget",
+		Message:     "Budget created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": budget.Id, "categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "Budget created",
+		Data:        budget,
+		RequestUuid: requestUuid,
+	})
+}
+
+// UpdateBudget updates the budget of the category.
+//
+// @Tags Budget
+// @Summary Update budget
+// @Description Updates budget's properties
+// @ID update-budget
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param budgetId path uint64 true "Budget ID"
+// @Param budget body model.BudgetUpdateDTO true "Budget update attributes"
+// @Success 200 {object} model.Response "Budget updated"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/budgets/{budgetId} [patch]
+func (w BudgetHandler) UpdateBudget(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	budgetId, _ := strconv.ParseUint(c.Param("budgetId"), 10, 64)
+	budgetUpdateDTO := &model.BudgetUpdateDTO{}
+
+	err := bindDtoValidate[model.BudgetUpdateDTO](c, w.validate, budgetUpdateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	budgetUpdateDTO.Id = budgetId
+	budgetUpdateDTO.UserId = userId
+	budgetUpdateDTO.CategoryId = categoryId
+
+	budget, err := w.budgetService.UpdateBudget(*budgetUpdateDTO)
+	if err != nil {
+		return budgetError(serviceerror.CannotUpdateBudget, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "UpdateBudget",
+		Message:     "Budget updated",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": budget.Id, "categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Budget updated",
+		Data:        budget,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteBudget deletes the budget of the category.
+//
+// @Tags Budget
+// @Summary Delete budget
+// @Description Deletes budget by the provided budget ID
+// @ID delete-budget
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param budgetId path uint64 true "Budget ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/budgets/{budgetId} [delete]
+func (w BudgetHandler) DeleteBudget(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	budgetId, _ := strconv.ParseUint(c.Param("budgetId"), 10, 64)
+
+	budgetDeleteDTO := model.BudgetDeleteDTO{
+		Id:         budgetId,
+		UserId:     userId,
+		CategoryId: categoryId,
+	}
+
+	if err := w.budgetService.DeleteBudget(budgetDeleteDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteBudget, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteBudget",
+		Message:     "Budget deleted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": budgetId, "categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetBudgetStatus retrieves progress of user's budgets.
+//
+// @Tags Budget
+// @Summary Get budget status
+// @Description Gets limit, spent and remaining amounts of user's budgets in their periods containing the date.
+// @Description The limit includes the amount carried over from the previous periods of rollover budgets.
+// @Description Spent amounts of household categories include the spending of all members.
+// @ID get-budget-status
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param period query string false "Budget period" Enums(weekly, monthly, yearly, custom)
+// @Param date query string false "Date (2006-01-02 or RFC 3339), now by default"
+// @Success 200 {object} model.Response "Budget status retrieved"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/budget-status [get]
+func (w BudgetHandler) GetBudgetStatus(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	budgetStatusDTO := model.BudgetStatusDTO{
+		UserId: userId,
+		Period: entity.BudgetPeriod(c.QueryParam("period")),
+		Date:   time.Now(),
+	}
+	switch budgetStatusDTO.Period {
+	case "", entity.Weekly, entity.Monthly, entity.Yearly, entity.Custom:
+	default:
+		return common.NewValidationError(serviceerror.InvalidInputData, errors.New("period must be one of weekly, monthly, yearly, custom"))
+	}
+	if param := c.QueryParam("date"); param != "" {
+		date, err := time.Parse(time.DateOnly, param)
+		if err != nil {
+			if date, err = time.Parse(time.RFC3339, param); err != nil {
+				return common.NewValidationError(serviceerror.InvalidInputData, errors.New("date must be a date or RFC 3339 timestamp"))
+			}
+		}
+		budgetStatusDTO.Date = date
+	}
+
+	statuses, err := w.budgetService.GetBudgetStatus(budgetStatusDTO)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetStatus, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetBudgetStatus",
+		Message:     "Budget status retrieved",
+		UserId:      &userId,
+		Data:        map[string]int{"count": len(statuses)},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Budget status retrieved",
+		Data:        statuses,
+		RequestUuid: requestUuid,
+	})
+}
+
+// RecordSpending saves spending totals of user's categories.
+//
+// @Tags Budget
+// @Summary Record spending
+// @Description Saves amounts spent in user's categories over the periods, pushed by the transaction service.
+// @Description A total replaces the previous one of the same category, currency and period.
+// @Description Emits budget.threshold_crossed event when a budget reaches one of the configured percentages,
+// @Description for household categories to every member.
+// @ID record-spending
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param spending body model.SpendingRecordDTO true "Spending totals"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/spending [post]
+func (w BudgetHandler) RecordSpending(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	spendingRecordDTO := &model.SpendingRecordDTO{}
+
+	err := bindDtoValidate[model.SpendingRecordDTO](c, w.validate, spendingRecordDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	spendingRecordDTO.UserId = userId
+
+	if err = w.budgetService.RecordSpending(*spendingRecordDTO); err != nil {
+		return budgetError(serviceerror.CannotRecordSpending, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "RecordSpending",
+		Message:     "Spending recorded",
+		UserId:      &userId,
+		Data:        map[string]int{"count": len(spendingRecordDTO.Totals)},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func budgetError(message string, err error) error {
+	if errors.Is(err, serviceerror.InvalidBudgetAmount) || errors.Is(err, serviceerror.InvalidBudgetPeriod) ||
+		errors.Is(err, serviceerror.InvalidSpendingTotal) {
+		return common.NewValidationError(message, err)
+	}
+	return common.NewUnprocessableEntityError(message, err)
+}
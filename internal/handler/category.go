@@ -1,28 +1,51 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/khivuksergey/portmonetka.category/config"
 	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
 	"github.com/khivuksergey/portmonetka.category/internal/model"
 	"github.com/khivuksergey/portmonetka.common"
 	"github.com/khivuksergey/webserver/logger"
 	"github.com/labstack/echo/v4"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
+const headerExportVersion = "X-Export-Version"
+
 type CategoryHandler struct {
-	categoryService service.CategoryService
-	logger          logger.Logger
-	validate        *validator.Validate
+	categoryService   service.CategoryService
+	changeFeedService service.ChangeFeedService
+	exportService     service.ExportService
+	importService     service.ImportService
+	cfg               config.CategoryConfig
+	importCfg         config.ImportConfig
+	logger            logger.Logger
+	validate          *validator.Validate
 }
 
-func NewCategoryHandler(services *service.Manager, logger logger.Logger) *CategoryHandler {
+func NewCategoryHandler(services *service.Manager, cfg config.CategoryConfig, importCfg config.ImportConfig, logger logger.Logger) *CategoryHandler {
+	if importCfg.MaxBytes <= 0 {
+		importCfg.MaxBytes = config.DefaultImportConfig.MaxBytes
+	}
 	return &CategoryHandler{
-		categoryService: services.Category,
-		logger:          logger,
-		validate:        model.GetCategoryValidator(),
+		categoryService:   services.Category,
+		changeFeedService: services.ChangeFeed,
+		exportService:     services.Export,
+		importService:     services.Import,
+		cfg:               cfg,
+		importCfg:         importCfg,
+		logger:            logger,
+		validate:          model.GetCategoryValidator(),
 	}
 }
 
@@ -30,19 +53,45 @@ func NewCategoryHandler(services *service.Manager, logger logger.Logger) *Catego
 //
 // @Tags Category
 // @Summary Get user's categories
-// @Description Gets user's categories
+// @Description Gets user's categories. The TRANSFER category of moves between own accounts is created along with the first
+// @Description personal category, it is flagged as system so reports can exclude it. With accountId only categories linked to the account
+// @Description and categories linked to no account are returned. With asOf the categories are returned as they were at the time
 // @ID get-categories
 // @Accept json
 // @Produce json
 // @Param userId path uint64 true "Authorized user ID"
+// @Param accountId query string false "Account ID"
+// @Param asOf query string false "RFC 3339 time, can't be combined with accountId"
+// @Param If-None-Match header string false "ETag of the cached categories list"
 // @Success 200 {object} model.Response "Categories retrieved"
+// @Header 200 {string} ETag "Weak entity tag of the categories list"
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} model.Response "Bad request"
 // @Failure 422 {object} model.Response "Unprocessable entity"
 // @Router /users/{userId}/categories [get]
 func (w CategoryHandler) GetCategories(c echo.Context) error {
 	requestUuid := c.Get(common.RequestUuidKey).(string)
 	userId := c.Get("userId").(uint64)
 
-	categories, err := w.categoryService.GetCategoriesByUserId(userId)
+	var categories []entity.Category
+	var err error
+	accountId, asOf := c.QueryParam("accountId"), c.QueryParam("asOf")
+	switch {
+	case asOf != "":
+		var asOfTime time.Time
+		asOfTime, err = time.Parse(time.RFC3339, asOf)
+		if err != nil || accountId != "" {
+			return common.NewValidationError(serviceerror.InvalidInputData, serviceerror.InvalidAsOf)
+		}
+		categories, err = w.categoryService.GetCategoriesAsOf(userId, asOfTime)
+	case accountId != "":
+		categories, err = w.categoryService.GetCategoriesByAccountId(userId, accountId)
+	default:
+		categories, err = w.categoryService.GetCategoriesByUserId(userId)
+	}
+	if errors.Is(err, serviceerror.InvalidAccountId) {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
 	if err != nil {
 		return common.NewUnprocessableEntityError(serviceerror.CannotGetCategories, err)
 	}
@@ -54,6 +103,12 @@ func (w CategoryHandler) GetCategories(c echo.Context) error {
 		RequestUuid: requestUuid,
 	})
 
+	etag := categoriesETag(categories)
+	c.Response().Header().Set(headerETag, etag)
+	if notModified(c, etag, time.Time{}) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
 	return c.JSON(http.StatusOK, model.Response{
 		Message:     "Categories retrieved",
 		Data:        categories,
@@ -61,18 +116,293 @@ func (w CategoryHandler) GetCategories(c echo.Context) error {
 	})
 }
 
+// GetCategory retrieves user's category by ID.
+//
+// @Tags Category
+// @Summary Get category
+// @Description Gets user's category by ID. Answers 304 when If-None-Match or If-Modified-Since show the cached category is fresh
+// @ID get-category
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param If-None-Match header string false "ETag of the cached category"
+// @Param If-Modified-Since header string false "Last-Modified of the cached category"
+// @Success 200 {object} model.Response{data=entity.Category} "Category retrieved"
+// @Header 200 {string} ETag "Category version"
+// @Header 200 {string} Last-Modified "Time of the last category update"
+// @Success 304 {string} string "Not modified"
+// @Failure 404 {object} model.Response "Category not found"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId} [get]
+func (w CategoryHandler) GetCategory(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, err := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, serviceerror.CategoryDoesntExist.Error())
+	}
+
+	category, err := w.categoryService.GetCategory(userId, categoryId)
+	if errors.Is(err, serviceerror.CategoryDoesntExist) || errors.Is(err, serviceerror.CategoryDoesntBelongToUser) {
+		// don't reveal categories of other users
+		return echo.NewHTTPError(http.StatusNotFound, serviceerror.CategoryDoesntExist.Error())
+	}
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetCategory, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetCategory",
+		Message:     "Category retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": category.Id},
+		RequestUuid: requestUuid,
+	})
+
+	etag := categoryETag(category)
+	c.Response().Header().Set(headerETag, etag)
+	c.Response().Header().Set(echo.HeaderLastModified, category.UpdatedAt.UTC().Format(http.TimeFormat))
+	if notModified(c, etag, category.UpdatedAt) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Category retrieved",
+		Data:        category,
+		RequestUuid: requestUuid,
+	})
+}
+
+// GetCategoryChanges retrieves user's categories changed since the sync token.
+//
+// @Tags Category
+// @Summary Get category changes
+// @Description Gets created, updated and deleted categories since the opaque sync token. Without token returns all categories.
+// @Description Keep requesting with the returned token while hasMore is true. When fullResyncRequired is true drop the local cache and request again without token.
+// @ID get-category-changes
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param since query string false "Sync token from the previous response"
+// @Success 200 {object} model.Response{data=model.CategoryChangesDTO} "Category changes retrieved"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/changes [get]
+func (w CategoryHandler) GetCategoryChanges(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	changes, err := w.changeFeedService.GetChanges(userId, c.QueryParam("since"))
+	if errors.Is(err, serviceerror.InvalidSyncToken) {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetChanges, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetCategoryChanges",
+		Message:     "Category changes retrieved",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Category changes retrieved",
+		Data:        changes,
+		RequestUuid: requestUuid,
+	})
+}
+
+// ExportCategories streams user's categories as a file.
+//
+// @Tags Category
+// @Summary Export categories
+// @Description Downloads all user's categories except the system ones as CSV or JSON array. Columns: id, externalId, name, type, description, targetAmount, targetDate, targetCurrency, createdAt, updatedAt
+// @ID export-categories
+// @Produce text/csv,json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param format query string false "File format" Enums(csv, json) default(csv)
+// @Success 200 {array} model.CategoryRecord "Categories file"
+// @Header 200 {string} X-Export-Version "Version of the export schema"
+// @Failure 400 {object} model.Response "Bad request"
+// @Router /users/{userId}/categories/export [get]
+func (w CategoryHandler) ExportCategories(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	format := model.ExportFormat(c.QueryParam("format"))
+	contentType := "text/csv; charset=utf-8"
+	switch format {
+	case "":
+		format = model.ExportCSV
+	case model.ExportCSV:
+	case model.ExportJSON:
+		contentType = echo.MIMEApplicationJSONCharsetUTF8
+	default:
+		return common.NewValidationError(serviceerror.InvalidInputData, serviceerror.InvalidExportFormat)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="categories.%s"`, format))
+	res.Header().Set(headerExportVersion, model.CategoryExportVersion)
+	res.WriteHeader(http.StatusOK)
+
+	if err := w.exportService.ExportCategories(userId, format, res); err != nil {
+		// the status is already sent, the client gets a truncated file
+		w.logger.Error(logger.LogMessage{
+			Action:      "ExportCategories",
+			Message:     serviceerror.CannotExport,
+			UserId:      &userId,
+			Data:        err.Error(),
+			RequestUuid: requestUuid,
+		})
+		return nil
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "ExportCategories",
+		Message:     "Categories exported",
+		UserId:      &userId,
+		Data:        map[string]model.ExportFormat{"format": format},
+		RequestUuid: requestUuid,
+	})
+
+	return nil
+}
+
+// ImportCategories creates user's categories from a file.
+//
+// @Tags Category
+// @Summary Import categories
+// @Description Imports categories from CSV with name, type, description, externalId and, for goals, targetAmount, targetDate and targetCurrency columns or from JSON array of such objects, export files are accepted.
+// @Description QIF files (!Type:Cat blocks), YNAB budget or register CSV and CoinKeeper transactions CSV exports are accepted too. The format is detected from the file content.
+// @Description The file is sent as the request body or as the file field of a multipart form. Every category is validated, when any is invalid nothing is imported.
+// @Description Conflict policy tells what to do when the name is taken: skip the category, rename it with a numeric suffix or overwrite the description.
+// @ID import-categories
+// @Accept text/csv,json,text/plain,mpfd
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param format query string false "File format, detected by default" Enums(csv, json, qif, ynab, coinkeeper)
+// @Param dryRun query bool false "Report the changes without applying them"
+// @Param conflict query string false "Conflict policy" Enums(skip, rename, overwrite) default(skip)
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Param file formData file false "Categories file"
+// @Success 200 {object} model.Response{data=model.CategoryImportReport} "Categories imported"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 413 {object} model.Response "Request body is too large"
+// @Failure 422 {object} model.Response{data=model.CategoryImportReport} "Invalid categories"
+// @Router /users/{userId}/categories/import [post]
+func (w CategoryHandler) ImportCategories(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	var dryRun bool
+	if param := c.QueryParam("dryRun"); param != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(param); err != nil {
+			return common.NewValidationError(serviceerror.InvalidInputData, err)
+		}
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, w.importCfg.MaxBytes)
+	file, err := getImportFile(c)
+	if isBodyTooLarge(err) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, serviceerror.RequestBodyTooLarge.Error())
+	}
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+	defer file.Close()
+
+	report, err := w.importService.ImportCategories(model.CategoryImportDTO{
+		UserId:   userId,
+		Format:   model.ImportFormat(c.QueryParam("format")),
+		File:     file,
+		DryRun:   dryRun,
+		Conflict: model.ConflictPolicy(c.QueryParam("conflict")),
+	})
+	switch {
+	case isBodyTooLarge(err):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, serviceerror.RequestBodyTooLarge.Error())
+	case errors.Is(err, serviceerror.ImportHasInvalidRows):
+		return c.JSON(http.StatusUnprocessableEntity, model.Response{
+			Message:     err.Error(),
+			Data:        report,
+			RequestUuid: requestUuid,
+		})
+	case errors.Is(err, serviceerror.InvalidImportFile),
+		errors.Is(err, serviceerror.TooManyImportRows),
+		errors.Is(err, serviceerror.InvalidConflictPolicy):
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	case err != nil:
+		return categoryError(serviceerror.CannotImport, err)
+	}
+
+	message := "Categories imported"
+	if dryRun {
+		message = "Categories import checked"
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:  "ImportCategories",
+		Message: message,
+		UserId:  &userId,
+		Data: map[string]int{
+			"created": report.Created,
+			"updated": report.Updated,
+			"skipped": report.Skipped,
+		},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     message,
+		Data:        report,
+		RequestUuid: requestUuid,
+	})
+}
+
+func isBodyTooLarge(err error) bool {
+	var maxBytesError *http.MaxBytesError
+	return errors.As(err, &maxBytesError)
+}
+
+// getImportFile returns the request body or the file of multipart upload.
+func getImportFile(c echo.Context) (io.ReadCloser, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if mediaType != echo.MIMEMultipartForm {
+		return c.Request().Body, nil
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	return fileHeader.Open()
+}
+
 // CreateCategory creates a new category for user.
 //
 // @Tags Category
 // @Summary Create a new category
-// @Description Creates a new category with the provided information
+// @Description Creates a new category with the provided information.
+// @Description GOAL category requires target amount, target date and target currency, other types must not have them.
+// @Description With warnSimilar user's categories with near-duplicate names are returned alongside the created category,
+// @Description in strict mode the category isn't created when it has near-duplicates.
 // @ID create-category
 // @Accept json
 // @Produce json
 // @Param userId path uint64 true "Authorized user ID"
+// @Param warnSimilar query string false "Near-duplicate names check, true is the same as warn" Enums(false, true, warn, strict)
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
 // @Param category body model.CategoryCreateDTO true "Category object to be created"
-// @Success 201 {object} model.Response "Category created"
+// @Success 201 {object} model.Response "Category created, with warnSimilar data is model.CategoryCreatedDTO"
+// @Header 201 {string} ETag "Category version"
 // @Failure 400 {object} model.Response "Bad request"
+// @Failure 409 {object} model.Response{data=[]model.CategorySuggestion} "Request with the same Idempotency-Key is in progress or similar categories exist in strict mode"
+// @Failure 413 {object} model.Response "Request body is too large"
 // @Failure 422 {object} model.Response "Unprocessable entity"
 // @Router /users/{userId}/categories [post]
 func (w CategoryHandler) CreateCategory(c echo.Context) error {
@@ -87,9 +417,29 @@ func (w CategoryHandler) CreateCategory(c echo.Context) error {
 
 	categoryCreateDTO.UserId = userId
 
+	similarMode, err := getSimilarMode(c)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	var similar []model.CategorySuggestion
+	if similarMode != model.SimilarOff {
+		similar, err = w.categoryService.SimilarCategories(userId, categoryCreateDTO.Name)
+		if err != nil {
+			return categoryError(serviceerror.CannotCreateCategory, err)
+		}
+		if similarMode == model.SimilarStrict && len(similar) > 0 {
+			return c.JSON(http.StatusConflict, model.Response{
+				Message:     serviceerror.SimilarCategoryExists.Error(),
+				Data:        similar,
+				RequestUuid: requestUuid,
+			})
+		}
+	}
+
 	category, err := w.categoryService.CreateCategory(*categoryCreateDTO)
 	if err != nil {
-		return common.NewUnprocessableEntityError(serviceerror.CannotCreateCategory, err)
+		return categoryError(serviceerror.CannotCreateCategory, err)
 	}
 
 	w.logger.Info(logger.LogMessage{
@@ -100,9 +450,85 @@ func (w CategoryHandler) CreateCategory(c echo.Context) error {
 		RequestUuid: requestUuid,
 	})
 
+	c.Response().Header().Set(headerETag, categoryETag(category))
+
+	var data any = category
+	if similarMode != model.SimilarOff {
+		data = model.CategoryCreatedDTO{Category: category, Similar: similar}
+	}
+
 	return c.JSON(http.StatusCreated, model.Response{
 		Message:     "Category created",
-		Data:        category,
+		Data:        data,
+		RequestUuid: requestUuid,
+	})
+}
+
+// getSimilarMode parses warnSimilar query param, boolean true means warn.
+func getSimilarMode(c echo.Context) (model.SimilarMode, error) {
+	param := c.QueryParam("warnSimilar")
+	switch mode := model.SimilarMode(param); mode {
+	case model.SimilarOff, model.SimilarWarn, model.SimilarStrict:
+		return mode, nil
+	}
+	warn, err := strconv.ParseBool(param)
+	if err != nil {
+		return "", serviceerror.InvalidSimilarMode
+	}
+	if warn {
+		return model.SimilarWarn, nil
+	}
+	return model.SimilarOff, nil
+}
+
+// SuggestCategories suggests categories for the name being typed.
+//
+// @Tags Category
+// @Summary Suggest categories
+// @Description Ranks user's categories and category templates by normalized edit distance and word overlap with the query.
+// @Description Names starting with the query rank high. Templates the user already has are left out.
+// @ID suggest-categories
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param q query string true "Category name being typed"
+// @Param limit query int false "Maximum number of suggestions, up to 50" default(10)
+// @Success 200 {object} model.Response{data=[]model.CategorySuggestion} "Categories suggested"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/suggest [get]
+func (w CategoryHandler) SuggestCategories(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if query == "" {
+		return common.NewValidationError(serviceerror.InvalidInputData, errors.New("q is required"))
+	}
+	var limit int
+	if param := c.QueryParam("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			return common.NewValidationError(serviceerror.InvalidInputData, errors.New("limit must be a positive number"))
+		}
+	}
+
+	suggestions, err := w.categoryService.SuggestCategories(userId, query, limit)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotSuggest, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "SuggestCategories",
+		Message:     "Categories suggested",
+		UserId:      &userId,
+		Data:        map[string]int{"count": len(suggestions)},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Categories suggested",
+		Data:        suggestions,
 		RequestUuid: requestUuid,
 	})
 }
@@ -111,15 +537,24 @@ func (w CategoryHandler) CreateCategory(c echo.Context) error {
 //
 // @Tags Category
 // @Summary Update category
-// @Description Updates category's properties
+// @Description Updates category's properties. Besides JSON with the changed fields accepts
+// @Description JSON Merge Patch (application/merge-patch+json) and JSON Patch (application/json-patch+json) applied to the category
 // @ID update-category
-// @Accept json
+// @Accept json,application/merge-patch+json,application/json-patch+json
 // @Produce json
 // @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param If-Match header string false "Category version from ETag"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
 // @Param category body model.CategoryUpdateDTO true "Category update attributes"
 // @Success 200 {object} model.Response "Category updated"
+// @Header 200 {string} ETag "Category version"
 // @Failure 400 {object} model.Response "Bad request"
+// @Failure 409 {object} model.Response "Request with the same Idempotency-Key is in progress"
+// @Failure 413 {object} model.Response "Request body is too large"
+// @Failure 412 {object} model.Response "Category version doesn't match"
 // @Failure 422 {object} model.Response "Unprocessable entity"
+// @Failure 428 {object} model.Response "If-Match header is required"
 // @Router /users/{userId}/categories/{categoryId} [patch]
 func (w CategoryHandler) UpdateCategory(c echo.Context) error {
 	requestUuid := c.Get(common.RequestUuidKey).(string)
@@ -127,17 +562,41 @@ func (w CategoryHandler) UpdateCategory(c echo.Context) error {
 	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
 	categoryUpdateDTO := &model.CategoryUpdateDTO{}
 
-	err := bindDtoValidate[model.CategoryUpdateDTO](c, w.validate, categoryUpdateDTO)
+	version, err := w.ifMatch(c)
 	if err != nil {
-		return common.NewValidationError(serviceerror.InvalidInputData, err)
+		return err
 	}
 
-	categoryUpdateDTO.Id = categoryId
-	categoryUpdateDTO.UserId = userId
+	var category *entity.Category
+	if patchType, ok := getPatchType(c); ok {
+		patch, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return common.NewValidationError(serviceerror.InvalidInputData, err)
+		}
+		category, err = w.categoryService.PatchCategory(model.CategoryPatchDTO{
+			Id:      categoryId,
+			UserId:  userId,
+			Type:    patchType,
+			Patch:   patch,
+			Version: version,
+		})
+		if err != nil {
+			return categoryError(serviceerror.CannotUpdateCategory, err)
+		}
+	} else {
+		err = bindDtoValidate[model.CategoryUpdateDTO](c, w.validate, categoryUpdateDTO)
+		if err != nil {
+			return common.NewValidationError(serviceerror.InvalidInputData, err)
+		}
 
-	category, err := w.categoryService.UpdateCategory(*categoryUpdateDTO)
-	if err != nil {
-		return common.NewUnprocessableEntityError(serviceerror.CannotUpdateCategory, err)
+		categoryUpdateDTO.Id = categoryId
+		categoryUpdateDTO.UserId = userId
+		categoryUpdateDTO.Version = version
+
+		category, err = w.categoryService.UpdateCategory(*categoryUpdateDTO)
+		if err != nil {
+			return categoryError(serviceerror.CannotUpdateCategory, err)
+		}
 	}
 
 	w.logger.Info(logger.LogMessage{
@@ -148,6 +607,8 @@ func (w CategoryHandler) UpdateCategory(c echo.Context) error {
 		RequestUuid: requestUuid,
 	})
 
+	c.Response().Header().Set(headerETag, categoryETag(category))
+
 	return c.JSON(http.StatusOK, model.Response{
 		Message:     "Category updated",
 		Data:        category,
@@ -155,19 +616,100 @@ func (w CategoryHandler) UpdateCategory(c echo.Context) error {
 	})
 }
 
+// PutCategory creates or replaces the category with the client supplied external ID.
+//
+// @Tags Category
+// @Summary Create or replace category by external ID
+// @Description Creates the category with the external ID or replaces name and description of the existing one. Type can't be changed.
+// @Description Household categories with the external ID are found too and replacing them requires the editor role
+// @ID put-category
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param externalId path string true "Client supplied category ID"
+// @Param If-Match header string false "Category version from ETag"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Param category body model.CategoryPutDTO true "Category object"
+// @Success 200 {object} model.Response "Category updated"
+// @Success 201 {object} model.Response "Category created"
+// @Header 200,201 {string} ETag "Category version"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 409 {object} model.Response "Request with the same Idempotency-Key is in progress"
+// @Failure 413 {object} model.Response "Request body is too large"
+// @Failure 412 {object} model.Response "Category version doesn't match"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Failure 428 {object} model.Response "If-Match header is required"
+// @Router /users/{userId}/categories/by-external-id/{externalId} [put]
+func (w CategoryHandler) PutCategory(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryPutDTO := &model.CategoryPutDTO{}
+
+	version, err := w.optionalIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	err = c.Bind(categoryPutDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	categoryPutDTO.UserId = userId
+	categoryPutDTO.ExternalId = c.Param("externalId")
+	categoryPutDTO.Version = version
+
+	if err = w.validate.Struct(categoryPutDTO); err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	category, created, err := w.categoryService.PutCategory(*categoryPutDTO)
+	if err != nil {
+		return categoryError(serviceerror.CannotUpsertCategory, err)
+	}
+
+	status, message := http.StatusOK, "Category updated"
+	if created {
+		status, message = http.StatusCreated, "Category created"
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "PutCategory",
+		Message:     message,
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": category.Id},
+		RequestUuid: requestUuid,
+	})
+
+	c.Response().Header().Set(headerETag, categoryETag(category))
+
+	return c.JSON(status, model.Response{
+		Message:     message,
+		Data:        category,
+		RequestUuid: requestUuid,
+	})
+}
+
 // DeleteCategory deletes the category by ID.
 //
 // @Tags Category
 // @Summary Delete category
-// @Description Deletes category by the provided category ID
+// @Description Deletes category by the provided category ID. System categories can't be deleted
 // @ID delete-category
 // @Accept json
 // @Produce json
 // @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param If-Match header string false "Category version from ETag"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
 // @Param category body model.CategoryDeleteDTO true "Category delete request"
 // @Success 204 {string} string "No content"
 // @Failure 400 {object} model.Response "Bad request"
+// @Failure 409 {object} model.Response "Request with the same Idempotency-Key is in progress"
+// @Failure 413 {object} model.Response "Request body is too large"
+// @Failure 412 {object} model.Response "Category version doesn't match"
 // @Failure 422 {object} model.Response "Unprocessable entity"
+// @Failure 428 {object} model.Response "If-Match header is required"
 // @Router /users/{userId}/categories/{categoryId} [delete]
 func (w CategoryHandler) DeleteCategory(c echo.Context) error {
 	requestUuid := c.Get(common.RequestUuidKey).(string)
@@ -175,16 +717,22 @@ func (w CategoryHandler) DeleteCategory(c echo.Context) error {
 	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
 	categoryDeleteDTO := &model.CategoryDeleteDTO{}
 
-	err := bindDtoValidate[model.CategoryDeleteDTO](c, w.validate, categoryDeleteDTO)
+	version, err := w.ifMatch(c)
+	if err != nil {
+		return err
+	}
+
+	err = bindDtoValidate[model.CategoryDeleteDTO](c, w.validate, categoryDeleteDTO)
 	if err != nil {
 		return common.NewValidationError(serviceerror.InvalidInputData, err)
 	}
 
 	categoryDeleteDTO.Id = categoryId
 	categoryDeleteDTO.UserId = userId
+	categoryDeleteDTO.Version = version
 
 	if err := w.categoryService.DeleteCategory(*categoryDeleteDTO); err != nil {
-		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteCategory, err)
+		return categoryError(serviceerror.CannotDeleteCategory, err)
 	}
 
 	w.logger.Info(logger.LogMessage{
@@ -198,6 +746,165 @@ func (w CategoryHandler) DeleteCategory(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// GetCategoryHistory retrieves changes of the category.
+//
+// @Tags Category
+// @Summary Get category history
+// @Description Gets creation, updates, reverts and deletion of the category with the user who made them
+// @Description and the category before and after each change, the latest first. History of a deleted category is available too
+// @ID get-category-history
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Success 200 {object} model.Response{data=[]entity.CategoryHistory} "Category history retrieved"
+// @Failure 404 {object} model.Response "Category not found"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/history [get]
+func (w CategoryHandler) GetCategoryHistory(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, err := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, serviceerror.CategoryDoesntExist.Error())
+	}
+
+	history, err := w.categoryService.GetCategoryHistory(userId, categoryId)
+	if errors.Is(err, serviceerror.CategoryDoesntExist) || errors.Is(err, serviceerror.CategoryDoesntBelongToUser) {
+		return echo.NewHTTPError(http.StatusNotFound, serviceerror.CategoryDoesntExist.Error())
+	}
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetHistory, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetCategoryHistory",
+		Message:     "Category history retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Category history retrieved",
+		Data:        history,
+		RequestUuid: requestUuid,
+	})
+}
+
+// RevertCategory rolls the category back to a version from its history.
+//
+// @Tags Category
+// @Summary Revert category
+// @Description Restores name, description, external id and goal target of the category from the version.
+// @Description The revert is recorded as a new version, the history after the version is kept
+// @ID revert-category
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param If-Match header string false "Category version from ETag"
+// @Param revert body model.CategoryRevertDTO true "Version to roll back to"
+// @Success 200 {object} model.Response{data=entity.Category} "Category reverted"
+// @Header 200 {string} ETag "Category version"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 404 {object} model.Response "Category version not found"
+// @Failure 412 {object} model.Response "Category version doesn't match"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Failure 428 {object} model.Response "If-Match header is required"
+// @Router /users/{userId}/categories/{categoryId}/revert [post]
+func (w CategoryHandler) RevertCategory(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	categoryRevertDTO := &model.CategoryRevertDTO{}
+
+	version, err := w.ifMatch(c)
+	if err != nil {
+		return err
+	}
+
+	err = bindDtoValidate[model.CategoryRevertDTO](c, w.validate, categoryRevertDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	categoryRevertDTO.Id = categoryId
+	categoryRevertDTO.UserId = userId
+	categoryRevertDTO.ExpectedVersion = version
+
+	category, err := w.categoryService.RevertCategory(*categoryRevertDTO)
+	if errors.Is(err, serviceerror.CategoryVersionDoesntExist) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return categoryError(serviceerror.CannotRevertCategory, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "RevertCategory",
+		Message:     "Category reverted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": category.Id, "version": categoryRevertDTO.Version},
+		RequestUuid: requestUuid,
+	})
+
+	c.Response().Header().Set(headerETag, categoryETag(category))
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Category reverted",
+		Data:        category,
+		RequestUuid: requestUuid,
+	})
+}
+
+// optionalIfMatch is ifMatch for requests that may create the category, so there is no version to require.
+func (w CategoryHandler) optionalIfMatch(c echo.Context) (*uint64, error) {
+	version, err := parseIfMatch(c, false)
+	if err != nil {
+		return nil, common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+	return version, nil
+}
+
+func (w CategoryHandler) ifMatch(c echo.Context) (*uint64, error) {
+	version, err := parseIfMatch(c, w.cfg.RequireIfMatch)
+	switch {
+	case errors.Is(err, serviceerror.IfMatchRequired):
+		return nil, echo.NewHTTPError(http.StatusPreconditionRequired, err.Error())
+	case err != nil:
+		return nil, common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+	return version, nil
+}
+
+// getPatchType returns the patch type when the request body is a patch document.
+func getPatchType(c echo.Context) (model.PatchType, bool) {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch patchType := model.PatchType(mediaType); patchType {
+	case model.MergePatch, model.JSONPatch:
+		return patchType, true
+	default:
+		return "", false
+	}
+}
+
+// categoryError maps category service errors to HTTP errors.
+func categoryError(message string, err error) error {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.Is(err, serviceerror.InvalidPatch),
+		errors.Is(err, serviceerror.ReadOnlyFieldChanged),
+		errors.Is(err, serviceerror.InvalidGoalTarget),
+		errors.Is(err, serviceerror.GoalTargetNotAllowed),
+		errors.As(err, &validationErrors):
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	case errors.Is(err, serviceerror.CategoryVersionMismatch):
+		return echo.NewHTTPError(http.StatusPreconditionFailed, fmt.Sprintf("%s: %v", message, err))
+	default:
+		return common.NewUnprocessableEntityError(message, err)
+	}
+}
+
 func bindDtoValidate[T any](c echo.Context, validate *validator.Validate, dto *T) error {
 	if err := c.Bind(dto); err != nil {
 		return err
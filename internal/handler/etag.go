@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	headerETag            = "ETag"
+	headerIfMatch         = "If-Match"
+	headerIfNoneMatch     = "If-None-Match"
+	headerIfModifiedSince = "If-Modified-Since"
+)
+
+// categoryETag is a strong entity tag of the category version.
+func categoryETag(category *entity.Category) string {
+	return fmt.Sprintf(`"%d"`, category.Version)
+}
+
+// categoriesETag is a weak entity tag of the list, it changes when any category is added, changed or removed.
+func categoriesETag(categories []entity.Category) string {
+	hash := sha256.New()
+	for _, category := range categories {
+		_, _ = fmt.Fprintf(hash, "%d:%d;", category.Id, category.Version)
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// parseIfMatch returns the category version from If-Match header.
+// Missing header or "*" return nil version, unless the header is required.
+func parseIfMatch(c echo.Context, required bool) (*uint64, error) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if ifMatch == "" {
+		if required {
+			return nil, serviceerror.IfMatchRequired
+		}
+		return nil, nil
+	}
+	if ifMatch == "*" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) || len(ifMatch) < 3 {
+		return nil, serviceerror.InvalidIfMatch
+	}
+	version, err := strconv.ParseUint(ifMatch[1:len(ifMatch)-1], 10, 64)
+	if err != nil {
+		return nil, serviceerror.InvalidIfMatch
+	}
+	return &version, nil
+}
+
+// notModified reports whether the client's cached representation is still fresh.
+// If-None-Match takes precedence over If-Modified-Since, zero lastModified disables the latter.
+func notModified(c echo.Context, etag string, lastModified time.Time) bool {
+	header := c.Request().Header
+	if ifNoneMatch := header.Get(headerIfNoneMatch); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	ifModifiedSince, err := http.ParseTime(header.Get(headerIfModifiedSince))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have second precision
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// etagMatches compares the tag with the If-None-Match list using weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
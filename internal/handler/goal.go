@@ -0,0 +1,63 @@
+package handler
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type GoalHandler struct {
+	goalService service.GoalService
+	logger      logger.Logger
+}
+
+func NewGoalHandler(services *service.Manager, logger logger.Logger) *GoalHandler {
+	return &GoalHandler{
+		goalService: services.Goal,
+		logger:      logger,
+	}
+}
+
+// GetGoalProgress retrieves progress of the goal category.
+//
+// @Tags Goal
+// @Summary Get goal progress
+// @Description Gets the amount saved towards the target of user's goal category.
+// @Description Contributions are spending totals of the category in the target currency, pushed to POST /users/{userId}/categories/spending
+// @ID get-goal-progress
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Goal category ID"
+// @Success 200 {object} model.Response{data=model.GoalProgress} "Goal progress retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/progress [get]
+func (w GoalHandler) GetGoalProgress(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+
+	progress, err := w.goalService.GetGoalProgress(userId, categoryId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetProgress, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetGoalProgress",
+		Message:     "Goal progress retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Goal progress retrieved",
+		Data:        progress,
+		RequestUuid: requestUuid,
+	})
+}
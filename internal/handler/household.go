@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type HouseholdHandler struct {
+	householdService service.HouseholdService
+	logger           logger.Logger
+	validate         *validator.Validate
+}
+
+func NewHouseholdHandler(services *service.Manager, logger logger.Logger) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdService: services.Household,
+		logger:           logger,
+		validate:         model.GetCategoryValidator(),
+	}
+}
+
+// GetHouseholds retrieves households of the user.
+//
+// @Tags Household
+// @Summary Get households
+// @Description Gets households the user is a member of
+// @ID get-households
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Success 200 {object} model.Response{data=[]entity.Household} "Households retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/households [get]
+func (w HouseholdHandler) GetHouseholds(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	households, err := w.householdService.GetHouseholds(userId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetHouseholds, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetHouseholds",
+		Message:     "Households retrieved",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Households retrieved",
+		Data:        households,
+		RequestUuid: requestUuid,
+	})
+}
+
+// CreateHousehold creates a household owned by the user.
+//
+// @Tags Household
+// @Summary Create household
+// @Description Creates a household with the user as its owner. Categories created with the household id are shared with its members
+// @ID create-household
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param household body model.HouseholdCreateDTO true "Household object"
+// @Success 201 {object} model.Response{data=entity.Household} "Household created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/households [post]
+func (w HouseholdHandler) CreateHousehold(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	householdCreateDTO := &model.HouseholdCreateDTO{}
+
+	err := bindDtoValidate[model.HouseholdCreateDTO](c, w.validate, householdCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	householdCreateDTO.UserId = userId
+
+	household, err := w.householdService.CreateHousehold(*householdCreateDTO)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotCreateHousehold, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateHousehold",
+		Message:     "Household created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"householdId": household.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "Household created",
+		Data:        household,
+		RequestUuid: requestUuid,
+	})
+}
+
+// GetMembers retrieves members of the household.
+//
+// @Tags Household
+// @Summary Get household members
+// @Description Gets members of the household with their roles, available to the members only
+// @ID get-household-members
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param householdId path uint64 true "Household ID"
+// @Success 200 {object} model.Response{data=[]entity.HouseholdMember} "Members retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/households/{householdId}/members [get]
+func (w HouseholdHandler) GetMembers(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	householdId, _ := strconv.ParseUint(c.Param("householdId"), 10, 64)
+
+	members, err := w.householdService.GetMembers(userId, householdId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetMembers, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetMembers",
+		Message:     "Members retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"householdId": householdId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Members retrieved",
+		Data:        members,
+		RequestUuid: requestUuid,
+	})
+}
+
+// RemoveMember removes a member from the household.
+//
+// @Tags Household
+// @Summary Remove household member
+// @Description The owner removes other members, members leave the household by removing themselves. The owner can't leave
+// @ID remove-household-member
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param householdId path uint64 true "Household ID"
+// @Param memberId path uint64 true "User ID of the member"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/households/{householdId}/members/{memberId} [delete]
+func (w HouseholdHandler) RemoveMember(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	householdId, _ := strconv.ParseUint(c.Param("householdId"), 10, 64)
+	memberId, _ := strconv.ParseUint(c.Param("memberId"), 10, 64)
+
+	err := w.householdService.RemoveMember(model.HouseholdMemberDeleteDTO{
+		UserId:      userId,
+		HouseholdId: householdId,
+		MemberId:    memberId,
+	})
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotRemoveMember, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "RemoveMember",
+		Message:     "Member removed",
+		UserId:      &userId,
+		Data:        map[string]uint64{"householdId": householdId, "memberId": memberId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateInvitation invites a user to the household.
+//
+// @Tags Household
+// @Summary Invite to household
+// @Description The owner invites a viewer or an editor. The returned token is accepted by the invitee, anyone with
+// @Description the token can accept it unless inviteeId is set. The invitation expires after the configured time
+// @ID create-household-invitation
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param householdId path uint64 true "Household ID"
+// @Param invitation body model.HouseholdInvitationCreateDTO true "Invitation object"
+// @Success 201 {object} model.Response{data=entity.HouseholdInvitation} "Invitation created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/households/{householdId}/invitations [post]
+func (w HouseholdHandler) CreateInvitation(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	householdId, _ := strconv.ParseUint(c.Param("householdId"), 10, 64)
+	invitationCreateDTO := &model.HouseholdInvitationCreateDTO{}
+
+	err := bindDtoValidate[model.HouseholdInvitationCreateDTO](c, w.validate, invitationCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	invitationCreateDTO.UserId = userId
+	invitationCreateDTO.HouseholdId = householdId
+
+	invitation, err := w.householdService.CreateInvitation(*invitationCreateDTO)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotInvite, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateInvitation",
+		Message:     "Invitation created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"householdId": householdId, "invitationId": invitation.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "Invitation created",
+		Data:        invitation,
+		RequestUuid: requestUuid,
+	})
+}
+
+// AcceptInvitation joins the household by the invitation token.
+//
+// @Tags Household
+// @Summary Accept household invitation
+// @Description Adds the user to the household with the role of the invitation
+// @ID accept-household-invitation
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param invitation body model.HouseholdInvitationAcceptDTO true "Invitation token"
+// @Success 200 {object} model.Response{data=entity.HouseholdMember} "Invitation accepted"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/households/invitations/accept [post]
+func (w HouseholdHandler) AcceptInvitation(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	invitationAcceptDTO := &model.HouseholdInvitationAcceptDTO{}
+
+	err := bindDtoValidate[model.HouseholdInvitationAcceptDTO](c, w.validate, invitationAcceptDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	invitationAcceptDTO.UserId = userId
+
+	member, err := w.householdService.AcceptInvitation(*invitationAcceptDTO)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotAcceptInvite, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "AcceptInvitation",
+		Message:     "Invitation accepted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"householdId": member.HouseholdId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Invitation accepted",
+		Data:        member,
+		RequestUuid: requestUuid,
+	})
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const (
+	headerIdempotencyKey     = "Idempotency-Key"
+	headerIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// replayedHeaders are response headers stored together with the response body.
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, headerETag, echo.HeaderLastModified}
+
+type IdempotencyMiddleware struct {
+	idempotencyService service.IdempotencyService
+	cfg                config.IdempotencyConfig
+	logger             logger.Logger
+}
+
+func NewIdempotencyMiddleware(services *service.Manager, cfg config.IdempotencyConfig, logger logger.Logger) *IdempotencyMiddleware {
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = config.DefaultIdempotencyConfig.MaxBodySize
+	}
+	return &IdempotencyMiddleware{
+		idempotencyService: services.Idempotency,
+		cfg:                cfg,
+		logger:             logger,
+	}
+}
+
+// HandleIdempotencyKey replays the stored response when an unsafe request is retried with the same
+// Idempotency-Key header. Failed requests are not stored, so they can be retried.
+func (m *IdempotencyMiddleware) HandleIdempotencyKey(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(headerIdempotencyKey)
+		if key == "" || !IsUnsafeMethod(c.Request().Method) {
+			return next(c)
+		}
+
+		requestUuid, _ := c.Get(common.RequestUuidKey).(string)
+		userId := c.Get("userId").(uint64)
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, m.cfg.MaxBodySize))
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, serviceerror.RequestBodyTooLarge.Error())
+		}
+		if err != nil {
+			return common.NewValidationError(serviceerror.InvalidInputData, err)
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, err := m.idempotencyService.Begin(userId, key, requestFingerprint(c.Request(), body))
+		switch {
+		case errors.Is(err, serviceerror.InvalidIdempotencyKey):
+			return common.NewValidationError(serviceerror.InvalidInputData, err)
+		case errors.Is(err, serviceerror.IdempotencyKeyReused):
+			return common.NewUnprocessableEntityError(serviceerror.CannotUseIdempotency, err)
+		case errors.Is(err, serviceerror.IdempotencyKeyInProgress):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case err != nil:
+			return common.NewUnprocessableEntityError(serviceerror.CannotUseIdempotency, err)
+		}
+
+		if stored != nil {
+			for name, value := range stored.Headers {
+				c.Response().Header().Set(name, value)
+			}
+			c.Response().Header().Set(headerIdempotentReplayed, "true")
+			c.Response().WriteHeader(stored.StatusCode)
+			_, err = c.Response().Write(stored.Body)
+			return err
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+		err = next(c)
+		c.Response().Writer = recorder.ResponseWriter
+
+		res := c.Response()
+		if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError {
+			if releaseErr := m.idempotencyService.Release(userId, key); releaseErr != nil {
+				m.logError(userId, requestUuid, releaseErr)
+			}
+			return err
+		}
+
+		headers := make(map[string]string, len(replayedHeaders))
+		for _, name := range replayedHeaders {
+			if value := res.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		if err = m.idempotencyService.Complete(userId, key, res.Status, headers, recorder.body.Bytes()); err != nil {
+			m.logError(userId, requestUuid, err)
+		}
+		return nil
+	}
+}
+
+func (m *IdempotencyMiddleware) logError(userId uint64, requestUuid string, err error) {
+	m.logger.Error(logger.LogMessage{
+		Action:      "HandleIdempotencyKey",
+		Message:     serviceerror.CannotUseIdempotency,
+		UserId:      &userId,
+		Data:        err.Error(),
+		RequestUuid: requestUuid,
+	})
+}
+
+// IsUnsafeMethod tells whether the request method can change data.
+func IsUnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestFingerprint identifies the request the key was issued for: the method, the path, the query
+// with sorted parameters, the media type without parameters, which change with multipart boundaries, and the body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(echo.HeaderContentType))
+	if err != nil {
+		mediaType = r.Header.Get(echo.HeaderContentType)
+	}
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, r.Method+" "+r.URL.Path+"?"+r.URL.Query().Encode()+"\n"+mediaType+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder copies the response body while writing it to the client.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
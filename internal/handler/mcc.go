@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type MccHandler struct {
+	mccService service.MccService
+	logger     logger.Logger
+	validate   *validator.Validate
+}
+
+func NewMccHandler(services *service.Manager, logger logger.Logger) *MccHandler {
+	return &MccHandler{
+		mccService: services.Mcc,
+		logger:     logger,
+		validate:   model.GetCategoryValidator(),
+	}
+}
+
+// GetMccMappings retrieves user's MCC mappings.
+//
+// @Tags MCC
+// @Summary Get user's MCC mappings
+// @Description Gets user's mappings of merchant category codes to categories
+// @ID get-mcc-mappings
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Success 200 {object} model.Response "MCC mappings retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/mcc-mappings [get]
+func (w MccHandler) GetMccMappings(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	mappings, err := w.mccService.GetMccMappings(userId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetMccMappings, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetMccMappings",
+		Message:     "MCC mappings retrieved",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "MCC mappings retrieved",
+		Data:        mappings,
+		RequestUuid: requestUuid,
+	})
+}
+
+// CreateMccMapping maps merchant category codes to user's category.
+//
+// @Tags MCC
+// @Summary Create a new MCC mapping
+// @Description Maps a merchant category code ("5411") or an inclusive range of codes ("3000-3299") to user's category
+// @ID create-mcc-mapping
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param mapping body model.MccMappingCreateDTO true "MCC mapping to be created"
+// @Success 201 {object} model.Response "MCC mapping created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/mcc-mappings [post]
+func (w MccHandler) CreateMccMapping(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	mccMappingCreateDTO := &model.MccMappingCreateDTO{}
+
+	err := bindDtoValidate[model.MccMappingCreateDTO](c, w.validate, mccMappingCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	mccMappingCreateDTO.UserId = userId
+
+	mapping, err := w.mccService.CreateMccMapping(*mccMappingCreateDTO)
+	if err != nil {
+		return mccError(serviceerror.CannotCreateMapping, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateMccMapping",
+		Message:     "MCC mapping created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": mapping.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "MCC mapping created",
+		Data:        mapping,
+		RequestUuid: requestUuid,
+	})
+}
+
+// UpdateMccMapping updates the MCC mapping.
+//
+// @Tags MCC
+// @Summary Update MCC mapping
+// @Description Changes the codes or the category of the MCC mapping
+// @ID update-mcc-mapping
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param mappingId path uint64 true "MCC mapping ID"
+// @Param mapping body model.MccMappingUpdateDTO true "MCC mapping update attributes"
+// @Success 200 {object} model.Response "MCC mapping updated"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/mcc-mappings/{mappingId} [patch]
+func (w MccHandler) UpdateMccMapping(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	mappingId, _ := strconv.ParseUint(c.Param("mappingId"), 10, 64)
+	mccMappingUpdateDTO := &model.MccMappingUpdateDTO{}
+
+	err := bindDtoValidate[model.MccMappingUpdateDTO](c, w.validate, mccMappingUpdateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	mccMappingUpdateDTO.Id = mappingId
+	mccMappingUpdateDTO.UserId = userId
+
+	mapping, err := w.mccService.UpdateMccMapping(*mccMappingUpdateDTO)
+	if err != nil {
+		return mccError(serviceerror.CannotUpdateMapping, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "UpdateMccMapping",
+		Message:     "MCC mapping updated",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": mapping.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "MCC mapping updated",
+		Data:        mapping,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteMccMapping deletes the MCC mapping by ID.
+//
+// @Tags MCC
+// @Summary Delete MCC mapping
+// @Description Deletes MCC mapping by the provided mapping ID
+// @ID delete-mcc-mapping
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param mappingId path uint64 true "MCC mapping ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/mcc-mappings/{mappingId} [delete]
+func (w MccHandler) DeleteMccMapping(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	mappingId, _ := strconv.ParseUint(c.Param("mappingId"), 10, 64)
+
+	mccMappingDeleteDTO := model.MccMappingDeleteDTO{
+		Id:     mappingId,
+		UserId: userId,
+	}
+
+	if err := w.mccService.DeleteMccMapping(mccMappingDeleteDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteMapping, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteMccMapping",
+		Message:     "MCC mapping deleted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": mappingId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ResolveMcc finds user's category for the merchant category code.
+//
+// @Tags Category
+// @Summary Resolve MCC to category
+// @Description Returns user's category for the merchant category code of a bank transaction.
+// @Description The narrowest matching user's mapping wins. Without a mapping the category named after the code's default template is returned.
+// @Description Category is null when nothing matches, template then suggests the category to create.
+// @ID resolve-mcc
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param mcc body model.MccResolveDTO true "Merchant category code"
+// @Success 200 {object} model.Response "MCC resolved"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/resolve-mcc [post]
+func (w MccHandler) ResolveMcc(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	mccResolveDTO := &model.MccResolveDTO{}
+
+	err := bindDtoValidate[model.MccResolveDTO](c, w.validate, mccResolveDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	mccResolveDTO.UserId = userId
+
+	resolution, err := w.mccService.ResolveMcc(*mccResolveDTO)
+	if err != nil {
+		return mccError(serviceerror.CannotResolveMcc, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "ResolveMcc",
+		Message:     "MCC resolved",
+		UserId:      &userId,
+		Data:        map[string]string{"mcc": resolution.Mcc, "source": string(resolution.Source)},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "MCC resolved",
+		Data:        resolution,
+		RequestUuid: requestUuid,
+	})
+}
+
+func mccError(message string, err error) error {
+	if errors.Is(err, serviceerror.InvalidMcc) {
+		return common.NewValidationError(message, err)
+	}
+	return common.NewUnprocessableEntityError(message, err)
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type RuleHandler struct {
+	rulesService service.RulesService
+	logger       logger.Logger
+	validate     *validator.Validate
+}
+
+func NewRuleHandler(services *service.Manager, logger logger.Logger) *RuleHandler {
+	return &RuleHandler{
+		rulesService: services.Rules,
+		logger:       logger,
+		validate:     model.GetCategoryValidator(),
+	}
+}
+
+// GetRules retrieves user's categorization rules.
+//
+// @Tags Rule
+// @Summary Get user's rules
+// @Description Gets user's categorization rules in evaluation order
+// @ID get-rules
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Success 200 {object} model.Response "Rules retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/rules [get]
+func (w RuleHandler) GetRules(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	rules, err := w.rulesService.GetRules(userId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetRules, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetRules",
+		Message:     "Rules retrieved",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Rules retrieved",
+		Data:        rules,
+		RequestUuid: requestUuid,
+	})
+}
+
+// CreateRule creates a new categorization rule for user.
+//
+// @Tags Rule
+// @Summary Create a new rule
+// @Description Creates a rule assigning the category to transactions matching the condition.
+// @Description Condition operators are and, or (with conditions), contains, equals, prefix, regex (with value, case-insensitive unless caseSensitive) and amount (absolute amount from min inclusive to max exclusive, decimal strings or numbers).
+// @Description Enabled rules are evaluated in ascending priority and the first match wins.
+// @ID create-rule
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param rule body model.RuleCreateDTO true "Rule object to be created"
+// @Success 201 {object} model.Response "Rule created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/rules [post]
+func (w RuleHandler) CreateRule(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	ruleCreateDTO := &model.RuleCreateDTO{}
+
+	err := bindDtoValidate[model.RuleCreateDTO](c, w.validate, ruleCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	ruleCreateDTO.UserId = userId
+
+	rule, err := w.rulesService.CreateRule(*ruleCreateDTO)
+	if err != nil {
+		return ruleError(serviceerror.CannotCreateRule, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateRule",
+		Message:     "Rule created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": rule.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "Rule created",
+		Data:        rule,
+		RequestUuid: requestUuid,
+	})
+}
+
+// UpdateRule updates the categorization rule.
+//
+// @Tags Rule
+// @Summary Update rule
+// @Description Updates rule's properties, the condition is replaced as a whole
+// @ID update-rule
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param ruleId path uint64 true "Rule ID"
+// @Param rule body model.RuleUpdateDTO true "Rule update attributes"
+// @Success 200 {object} model.Response "Rule updated"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/rules/{ruleId} [patch]
+func (w RuleHandler) UpdateRule(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	ruleId, _ := strconv.ParseUint(c.Param("ruleId"), 10, 64)
+	ruleUpdateDTO := &model.RuleUpdateDTO{}
+
+	err := bindDtoValidate[model.RuleUpdateDTO](c, w.validate, ruleUpdateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	ruleUpdateDTO.Id = ruleId
+	ruleUpdateDTO.UserId = userId
+
+	rule, err := w.rulesService.UpdateRule(*ruleUpdateDTO)
+	if err != nil {
+		return ruleError(serviceerror.CannotUpdateRule, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "UpdateRule",
+		Message:     "Rule updated",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": rule.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Rule updated",
+		Data:        rule,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteRule deletes the categorization rule by ID.
+//
+// @Tags Rule
+// @Summary Delete rule
+// @Description Deletes rule by the provided rule ID
+// @ID delete-rule
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param ruleId path uint64 true "Rule ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/rules/{ruleId} [delete]
+func (w RuleHandler) DeleteRule(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	ruleId, _ := strconv.ParseUint(c.Param("ruleId"), 10, 64)
+
+	ruleDeleteDTO := model.RuleDeleteDTO{
+		Id:     ruleId,
+		UserId: userId,
+	}
+
+	if err := w.rulesService.DeleteRule(ruleDeleteDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteRule, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteRule",
+		Message:     "Rule deleted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": ruleId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ClassifyTransactions assigns categories to a batch of transactions by user's rules.
+//
+// @Tags Category
+// @Summary Classify transactions
+// @Description Evaluates user's rules against every transaction and returns the category and the matched rule id in the order of the batch.
+// @Description Both are null when no rule matched. Up to 1000 transactions per request.
+// @ID classify-transactions
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param transactions body model.ClassifyDTO true "Transactions to classify"
+// @Success 200 {object} model.Response "Transactions classified"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/classify [post]
+func (w RuleHandler) ClassifyTransactions(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	classifyDTO := &model.ClassifyDTO{}
+
+	err := bindDtoValidate[model.ClassifyDTO](c, w.validate, classifyDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	classifyDTO.UserId = userId
+
+	classifications, err := w.rulesService.Classify(*classifyDTO)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotClassify, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "ClassifyTransactions",
+		Message:     "Transactions classified",
+		UserId:      &userId,
+		Data:        map[string]int{"count": len(classifications)},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Transactions classified",
+		Data:        classifications,
+		RequestUuid: requestUuid,
+	})
+}
+
+func ruleError(message string, err error) error {
+	if errors.Is(err, serviceerror.InvalidRuleCondition) {
+		return common.NewValidationError(message, err)
+	}
+	return common.NewUnprocessableEntityError(message, err)
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SmartGroupHandler struct {
+	smartGroupService service.SmartGroupService
+	logger            logger.Logger
+	validate          *validator.Validate
+}
+
+func NewSmartGroupHandler(services *service.Manager, logger logger.Logger) *SmartGroupHandler {
+	return &SmartGroupHandler{
+		smartGroupService: services.SmartGroup,
+		logger:            logger,
+		validate:          model.GetCategoryValidator(),
+	}
+}
+
+// GetSmartGroups retrieves user's smart groups.
+//
+// @Tags SmartGroup
+// @Summary Get user's smart groups
+// @Description Gets user's saved category filters ordered by name
+// @ID get-smart-groups
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Success 200 {object} model.Response{data=[]entity.SmartGroup} "Smart groups retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/smart-groups [get]
+func (w SmartGroupHandler) GetSmartGroups(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	groups, err := w.smartGroupService.GetSmartGroups(userId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetGroups, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetSmartGroups",
+		Message:     "Smart groups retrieved",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Smart groups retrieved",
+		Data:        groups,
+		RequestUuid: requestUuid,
+	})
+}
+
+// CreateSmartGroup creates a new smart group for user.
+//
+// @Tags SmartGroup
+// @Summary Create a new smart group
+// @Description Saves a filter of user's categories resolved on every read. Filter operators are and, or, not (with filters),
+// @Description tag (with tagId of user's tag) and type (with category type), e.g. all essential expense categories:
+// @Description {"op":"and","filters":[{"op":"tag","tagId":1},{"op":"type","type":"EXPENSE"}]}
+// @ID create-smart-group
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param group body model.SmartGroupCreateDTO true "Smart group object"
+// @Success 201 {object} model.Response{data=entity.SmartGroup} "Smart group created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/smart-groups [post]
+func (w SmartGroupHandler) CreateSmartGroup(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	groupCreateDTO := &model.SmartGroupCreateDTO{}
+
+	err := bindDtoValidate[model.SmartGroupCreateDTO](c, w.validate, groupCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	groupCreateDTO.UserId = userId
+
+	group, err := w.smartGroupService.CreateSmartGroup(*groupCreateDTO)
+	if err != nil {
+		return smartGroupError(serviceerror.CannotCreateGroup, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateSmartGroup",
+		Message:     "Smart group created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": group.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "Smart group created",
+		Data:        group,
+		RequestUuid: requestUuid,
+	})
+}
+
+// UpdateSmartGroup updates the smart group.
+//
+// @Tags SmartGroup
+// @Summary Update smart group
+// @Description Updates smart group's name or filter, the filter is replaced as a whole
+// @ID update-smart-group
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param groupId path uint64 true "Smart group ID"
+// @Param group body model.SmartGroupUpdateDTO true "Smart group update attributes"
+// @Success 200 {object} model.Response{data=entity.SmartGroup} "Smart group updated"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/smart-groups/{groupId} [patch]
+func (w SmartGroupHandler) UpdateSmartGroup(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	groupId, _ := strconv.ParseUint(c.Param("groupId"), 10, 64)
+	groupUpdateDTO := &model.SmartGroupUpdateDTO{}
+
+	err := bindDtoValidate[model.SmartGroupUpdateDTO](c, w.validate, groupUpdateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	groupUpdateDTO.Id = groupId
+	groupUpdateDTO.UserId = userId
+
+	group, err := w.smartGroupService.UpdateSmartGroup(*groupUpdateDTO)
+	if err != nil {
+		return smartGroupError(serviceerror.CannotUpdateGroup, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "UpdateSmartGroup",
+		Message:     "Smart group updated",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": group.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Smart group updated",
+		Data:        group,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteSmartGroup deletes the smart group by ID.
+//
+// @Tags SmartGroup
+// @Summary Delete smart group
+// @Description Deletes smart group by the provided ID, categories and tags are kept
+// @ID delete-smart-group
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param groupId path uint64 true "Smart group ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/smart-groups/{groupId} [delete]
+func (w SmartGroupHandler) DeleteSmartGroup(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	groupId, _ := strconv.ParseUint(c.Param("groupId"), 10, 64)
+
+	groupDeleteDTO := model.SmartGroupDeleteDTO{
+		Id:     groupId,
+		UserId: userId,
+	}
+
+	if err := w.smartGroupService.DeleteSmartGroup(groupDeleteDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteGroup, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteSmartGroup",
+		Message:     "Smart group deleted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": groupId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetGroupCategories resolves the smart group.
+//
+// @Tags SmartGroup
+// @Summary Get smart group categories
+// @Description Gets categories the user can access matching the smart group filter at the moment of the request
+// @ID get-smart-group-categories
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param groupId path uint64 true "Smart group ID"
+// @Success 200 {object} model.Response{data=[]entity.Category} "Smart group resolved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/smart-groups/{groupId}/categories [get]
+func (w SmartGroupHandler) GetGroupCategories(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	groupId, _ := strconv.ParseUint(c.Param("groupId"), 10, 64)
+
+	categories, err := w.smartGroupService.GetGroupCategories(userId, groupId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotResolveGroup, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetGroupCategories",
+		Message:     "Smart group resolved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": groupId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Smart group resolved",
+		Data:        categories,
+		RequestUuid: requestUuid,
+	})
+}
+
+func smartGroupError(message string, err error) error {
+	if errors.Is(err, serviceerror.InvalidGroupFilter) {
+		return common.NewValidationError(message, err)
+	}
+	return common.NewUnprocessableEntityError(message, err)
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	lastEventIdHeader = "Last-Event-ID"
+	streamRetry       = 3 * time.Second
+)
+
+type StreamHandler struct {
+	streamService service.StreamService
+	cfg           config.StreamConfig
+	logger        logger.Logger
+}
+
+func NewStreamHandler(services *service.Manager, cfg config.StreamConfig, logger logger.Logger) *StreamHandler {
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = config.DefaultStreamConfig.Heartbeat
+	}
+	return &StreamHandler{
+		streamService: services.Stream,
+		cfg:           cfg,
+		logger:        logger,
+	}
+}
+
+// StreamCategoryEvents streams user's category changes as server-sent events.
+//
+// @Tags Category
+// @Summary Stream category changes
+// @Description Streams category.created, category.updated and category.deleted events with periodic heartbeat comments.
+// @Description Event id is a sync token: reconnect with Last-Event-ID header (or lastEventId query param) to receive missed changes as category.changes events.
+// @Description The resync event means the client must reload all categories.
+// @ID stream-category-events
+// @Produce text/event-stream
+// @Param userId path uint64 true "Authorized user ID"
+// @Param Last-Event-ID header string false "Id of the last received event"
+// @Param lastEventId query string false "Id of the last received event"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/stream [get]
+func (w StreamHandler) StreamCategoryEvents(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	events, unsubscribe := w.streamService.Subscribe(userId)
+	defer unsubscribe()
+
+	var missed []model.StreamMessage
+	if lastEventId := getLastEventId(c); lastEventId != "" {
+		var err error
+		missed, err = w.streamService.Replay(userId, lastEventId)
+		if errors.Is(err, serviceerror.InvalidSyncToken) {
+			return common.NewValidationError(serviceerror.InvalidInputData, err)
+		}
+		if err != nil {
+			return common.NewUnprocessableEntityError(serviceerror.CannotStreamEvents, err)
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+		return nil
+	}
+	for _, message := range missed {
+		if err := writeStreamMessage(res, message); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "StreamCategoryEvents",
+		Message:     "Event stream opened",
+		UserId:      &userId,
+		Data:        map[string]int{"replayed": len(missed)},
+		RequestUuid: requestUuid,
+	})
+
+	heartbeat := time.NewTicker(w.cfg.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				// disconnected by broadcaster, client resumes with Last-Event-ID
+				return nil
+			}
+			err = writeStreamMessage(res, w.streamService.Message(e))
+		case <-heartbeat.C:
+			_, err = io.WriteString(res, ": heartbeat\n\n")
+		}
+		if err != nil {
+			return nil
+		}
+		res.Flush()
+	}
+}
+
+func getLastEventId(c echo.Context) string {
+	if id := c.Request().Header.Get(lastEventIdHeader); id != "" {
+		return id
+	}
+	return c.QueryParam("lastEventId")
+}
+
+func writeStreamMessage(w io.Writer, message model.StreamMessage) error {
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return err
+	}
+	if message.Id != "" {
+		if _, err = fmt.Fprintf(w, "id: %s\n", message.Id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Event, data)
+	return err
+}
@@ -0,0 +1,319 @@
+package handler
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type TagHandler struct {
+	tagService service.TagService
+	logger     logger.Logger
+	validate   *validator.Validate
+}
+
+func NewTagHandler(services *service.Manager, logger logger.Logger) *TagHandler {
+	return &TagHandler{
+		tagService: services.Tag,
+		logger:     logger,
+		validate:   model.GetCategoryValidator(),
+	}
+}
+
+// GetTags retrieves user's tags.
+//
+// @Tags Tag
+// @Summary Get user's tags
+// @Description Gets user's tags ordered by name
+// @ID get-tags
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Success 200 {object} model.Response{data=[]entity.Tag} "Tags retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/tags [get]
+func (w TagHandler) GetTags(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	tags, err := w.tagService.GetTags(userId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetTags, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetTags",
+		Message:     "Tags retrieved",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Tags retrieved",
+		Data:        tags,
+		RequestUuid: requestUuid,
+	})
+}
+
+// CreateTag creates a new tag for user.
+//
+// @Tags Tag
+// @Summary Create a new tag
+// @Description Creates a label like "essential" or "subscription" to assign to any of user's categories. Names are unique per user
+// @ID create-tag
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param tag body model.TagCreateDTO true "Tag object"
+// @Success 201 {object} model.Response{data=entity.Tag} "Tag created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/tags [post]
+func (w TagHandler) CreateTag(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	tagCreateDTO := &model.TagCreateDTO{}
+
+	err := bindDtoValidate[model.TagCreateDTO](c, w.validate, tagCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	tagCreateDTO.UserId = userId
+
+	tag, err := w.tagService.CreateTag(*tagCreateDTO)
+	if err != nil {
+		return tagError(serviceerror.CannotCreateTag, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateTag",
+		Message:     "Tag created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": tag.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "Tag created",
+		Data:        tag,
+		RequestUuid: requestUuid,
+	})
+}
+
+// UpdateTag renames the tag.
+//
+// @Tags Tag
+// @Summary Update tag
+// @Description Renames the tag, its assignments and smart groups filtering by it are kept
+// @ID update-tag
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param tagId path uint64 true "Tag ID"
+// @Param tag body model.TagUpdateDTO true "Tag update attributes"
+// @Success 200 {object} model.Response{data=entity.Tag} "Tag updated"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/tags/{tagId} [patch]
+func (w TagHandler) UpdateTag(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	tagId, _ := strconv.ParseUint(c.Param("tagId"), 10, 64)
+	tagUpdateDTO := &model.TagUpdateDTO{}
+
+	err := bindDtoValidate[model.TagUpdateDTO](c, w.validate, tagUpdateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	tagUpdateDTO.Id = tagId
+	tagUpdateDTO.UserId = userId
+
+	tag, err := w.tagService.UpdateTag(*tagUpdateDTO)
+	if err != nil {
+		return tagError(serviceerror.CannotUpdateTag, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "UpdateTag",
+		Message:     "Tag updated",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": tag.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Tag updated",
+		Data:        tag,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteTag deletes the tag by ID.
+//
+// @Tags Tag
+// @Summary Delete tag
+// @Description Deletes the tag and its assignments to categories, smart groups filtering by it no longer match it
+// @ID delete-tag
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param tagId path uint64 true "Tag ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/tags/{tagId} [delete]
+func (w TagHandler) DeleteTag(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	tagId, _ := strconv.ParseUint(c.Param("tagId"), 10, 64)
+
+	tagDeleteDTO := model.TagDeleteDTO{
+		Id:     tagId,
+		UserId: userId,
+	}
+
+	if err := w.tagService.DeleteTag(tagDeleteDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteTag, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteTag",
+		Message:     "Tag deleted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": tagId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetCategoryTags retrieves user's tags assigned to the category.
+//
+// @Tags Tag
+// @Summary Get category tags
+// @Description Gets user's tags assigned to the category. Members of a household see their own tags of its categories
+// @ID get-category-tags
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Success 200 {object} model.Response{data=[]entity.Tag} "Category tags retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/tags [get]
+func (w TagHandler) GetCategoryTags(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+
+	tags, err := w.tagService.GetCategoryTags(userId, categoryId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetTags, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetCategoryTags",
+		Message:     "Category tags retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Category tags retrieved",
+		Data:        tags,
+		RequestUuid: requestUuid,
+	})
+}
+
+// AssignTag assigns the tag to the category.
+//
+// @Tags Tag
+// @Summary Assign tag
+// @Description Assigns user's tag to the category, assigning it again does nothing
+// @ID assign-tag
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param tagId path uint64 true "Tag ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/tags/{tagId} [put]
+func (w TagHandler) AssignTag(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryTagDTO := categoryTag(c, userId)
+
+	if err := w.tagService.AssignTag(categoryTagDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotAssignTag, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "AssignTag",
+		Message:     "Tag assigned",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryTagDTO.CategoryId, "tagId": categoryTagDTO.TagId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UnassignTag removes the tag from the category.
+//
+// @Tags Tag
+// @Summary Unassign tag
+// @Description Removes user's tag from the category
+// @ID unassign-tag
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param tagId path uint64 true "Tag ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/tags/{tagId} [delete]
+func (w TagHandler) UnassignTag(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryTagDTO := categoryTag(c, userId)
+
+	if err := w.tagService.UnassignTag(categoryTagDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotUnassignTag, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "UnassignTag",
+		Message:     "Tag unassigned",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryTagDTO.CategoryId, "tagId": categoryTagDTO.TagId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func categoryTag(c echo.Context, userId uint64) model.CategoryTagDTO {
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	tagId, _ := strconv.ParseUint(c.Param("tagId"), 10, 64)
+	return model.CategoryTagDTO{
+		UserId:     userId,
+		CategoryId: categoryId,
+		TagId:      tagId,
+	}
+}
+
+func tagError(message string, err error) error {
+	if errors.Is(err, serviceerror.TagNameRequired) {
+		return common.NewValidationError(message, err)
+	}
+	return common.NewUnprocessableEntityError(message, err)
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type TaxHandler struct {
+	taxService service.TaxService
+	logger     logger.Logger
+	validate   *validator.Validate
+}
+
+func NewTaxHandler(services *service.Manager, logger logger.Logger) *TaxHandler {
+	return &TaxHandler{
+		taxService: services.Tax,
+		logger:     logger,
+		validate:   model.GetCategoryValidator(),
+	}
+}
+
+// GetTaxCodes retrieves the tax code reference list.
+//
+// @Tags Tax
+// @Summary Get tax codes
+// @Description Gets tax form lines categories can be mapped to. Jurisdictions: US (Form 1040 Schedule C), GB (SA103F)
+// @ID get-tax-codes
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param jurisdiction query string false "Jurisdiction, all when empty"
+// @Success 200 {object} model.Response{data=[]reference.TaxCode} "Tax codes retrieved"
+// @Failure 400 {object} model.Response "Bad request"
+// @Router /users/{userId}/categories/tax-codes [get]
+func (w TaxHandler) GetTaxCodes(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+
+	taxCodes, err := w.taxService.GetTaxCodes(c.QueryParam("jurisdiction"))
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Tax codes retrieved",
+		Data:        taxCodes,
+		RequestUuid: requestUuid,
+	})
+}
+
+// GetCategoryTax retrieves tax attributes of the category.
+//
+// @Tags Tax
+// @Summary Get category tax attributes
+// @Description Gets deductible flag, deductible percentage and tax code of user's category
+// @ID get-category-tax
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Success 200 {object} model.Response{data=entity.CategoryTax} "Tax attributes retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/tax [get]
+func (w TaxHandler) GetCategoryTax(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+
+	categoryTax, err := w.taxService.GetCategoryTax(userId, categoryId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetTax, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetCategoryTax",
+		Message:     "Tax attributes retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Tax attributes retrieved",
+		Data:        categoryTax,
+		RequestUuid: requestUuid,
+	})
+}
+
+// SetCategoryTax replaces tax attributes of the category.
+//
+// @Tags Tax
+// @Summary Set category tax attributes
+// @Description Replaces tax attributes of user's income or expense category. Only expense categories can be deductible,
+// @Description deductible percentage defaults to 100. The tax code must be a code of the jurisdiction for the category type
+// @ID set-category-tax
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Param tax body model.CategoryTaxDTO true "Tax attributes"
+// @Success 200 {object} model.Response{data=entity.CategoryTax} "Tax attributes set"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/tax [put]
+func (w TaxHandler) SetCategoryTax(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+	categoryTaxDTO := &model.CategoryTaxDTO{}
+
+	err := bindDtoValidate[model.CategoryTaxDTO](c, w.validate, categoryTaxDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	categoryTaxDTO.UserId = userId
+	categoryTaxDTO.CategoryId = categoryId
+
+	categoryTax, err := w.taxService.SetCategoryTax(*categoryTaxDTO)
+	if err != nil {
+		return taxError(serviceerror.CannotSetTax, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "SetCategoryTax",
+		Message:     "Tax attributes set",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Tax attributes set",
+		Data:        categoryTax,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteCategoryTax clears tax attributes of the category.
+//
+// @Tags Tax
+// @Summary Delete category tax attributes
+// @Description Makes user's category not deductible and not mapped to a tax code
+// @ID delete-category-tax
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param categoryId path uint64 true "Category ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/{categoryId}/tax [delete]
+func (w TaxHandler) DeleteCategoryTax(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	categoryId, _ := strconv.ParseUint(c.Param("categoryId"), 10, 64)
+
+	if err := w.taxService.DeleteCategoryTax(userId, categoryId); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteTax, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteCategoryTax",
+		Message:     "Tax attributes deleted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"categoryId": categoryId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetTaxSummary retrieves user's categories grouped by tax code.
+//
+// @Tags Tax
+// @Summary Get tax summary
+// @Description Groups user's categories by the tax form lines they are mapped to, in form line order.
+// @Description Deductible categories without a tax code are grouped last under an empty code
+// @ID get-tax-summary
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param jurisdiction query string false "Jurisdiction, all when empty"
+// @Success 200 {object} model.Response{data=[]model.TaxSummaryLine} "Tax summary retrieved"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/categories/tax-summary [get]
+func (w TaxHandler) GetTaxSummary(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	summary, err := w.taxService.GetTaxSummary(userId, c.QueryParam("jurisdiction"))
+	if err != nil {
+		return taxError(serviceerror.CannotGetTaxSummary, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetTaxSummary",
+		Message:     "Tax summary retrieved",
+		UserId:      &userId,
+		Data:        map[string]int{"lines": len(summary)},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Tax summary retrieved",
+		Data:        summary,
+		RequestUuid: requestUuid,
+	})
+}
+
+func taxError(message string, err error) error {
+	if errors.Is(err, serviceerror.InvalidDeductible) ||
+		errors.Is(err, serviceerror.InvalidJurisdiction) ||
+		errors.Is(err, serviceerror.InvalidTaxCode) {
+		return common.NewValidationError(message, err)
+	}
+	return common.NewUnprocessableEntityError(message, err)
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+	logger         logger.Logger
+	validate       *validator.Validate
+}
+
+func NewWebhookHandler(services *service.Manager, logger logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: services.Webhook,
+		logger:         logger,
+		validate:       model.GetCategoryValidator(),
+	}
+}
+
+// GetWebhooks retrieves user's webhooks.
+//
+// @Tags Webhook
+// @Summary Get user's webhooks
+// @Description Gets user's webhook subscriptions
+// @ID get-webhooks
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Success 200 {object} model.Response "Webhooks retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/webhooks [get]
+func (w WebhookHandler) GetWebhooks(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+
+	webhooks, err := w.webhookService.GetWebhooksByUserId(userId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetWebhooks, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetWebhooks",
+		Message:     "Webhooks retrieved",
+		UserId:      &userId,
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Webhooks retrieved",
+		Data:        webhooks,
+		RequestUuid: requestUuid,
+	})
+}
+
+// CreateWebhook creates a new webhook subscription for user.
+//
+// @Tags Webhook
+// @Summary Create a new webhook
+// @Description Subscribes the URL to category events. Payloads are signed with HMAC-SHA256 of the secret in X-Portmonetka-Signature header. The URL must be https and must not target loopback, private or link-local addresses
+// @ID create-webhook
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param webhook body model.WebhookCreateDTO true "Webhook object to be created"
+// @Success 201 {object} model.Response "Webhook created"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/webhooks [post]
+func (w WebhookHandler) CreateWebhook(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	webhookCreateDTO := &model.WebhookCreateDTO{}
+
+	err := bindDtoValidate[model.WebhookCreateDTO](c, w.validate, webhookCreateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	webhookCreateDTO.UserId = userId
+
+	webhook, err := w.webhookService.CreateWebhook(*webhookCreateDTO)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotCreateWebhook, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "CreateWebhook",
+		Message:     "Webhook created",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": webhook.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusCreated, model.Response{
+		Message:     "Webhook created",
+		Data:        webhook,
+		RequestUuid: requestUuid,
+	})
+}
+
+// UpdateWebhook updates the webhook.
+//
+// @Tags Webhook
+// @Summary Update webhook
+// @Description Updates webhook's properties. Enabling the webhook resets its failures counter. A new URL must be https and must not target loopback, private or link-local addresses
+// @ID update-webhook
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param webhookId path uint64 true "Webhook ID"
+// @Param webhook body model.WebhookUpdateDTO true "Webhook update attributes"
+// @Success 200 {object} model.Response "Webhook updated"
+// @Failure 400 {object} model.Response "Bad request"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/webhooks/{webhookId} [patch]
+func (w WebhookHandler) UpdateWebhook(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	webhookId, _ := strconv.ParseUint(c.Param("webhookId"), 10, 64)
+	webhookUpdateDTO := &model.WebhookUpdateDTO{}
+
+	err := bindDtoValidate[model.WebhookUpdateDTO](c, w.validate, webhookUpdateDTO)
+	if err != nil {
+		return common.NewValidationError(serviceerror.InvalidInputData, err)
+	}
+
+	webhookUpdateDTO.Id = webhookId
+	webhookUpdateDTO.UserId = userId
+
+	webhook, err := w.webhookService.UpdateWebhook(*webhookUpdateDTO)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotUpdateWebhook, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "UpdateWebhook",
+		Message:     "Webhook updated",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": webhook.Id},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Webhook updated",
+		Data:        webhook,
+		RequestUuid: requestUuid,
+	})
+}
+
+// DeleteWebhook deletes the webhook by ID.
+//
+// @Tags Webhook
+// @Summary Delete webhook
+// @Description Deletes webhook by the provided webhook ID
+// @ID delete-webhook
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param webhookId path uint64 true "Webhook ID"
+// @Success 204 {string} string "No content"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/webhooks/{webhookId} [delete]
+func (w WebhookHandler) DeleteWebhook(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	webhookId, _ := strconv.ParseUint(c.Param("webhookId"), 10, 64)
+
+	webhookDeleteDTO := model.WebhookDeleteDTO{
+		Id:     webhookId,
+		UserId: userId,
+	}
+
+	if err := w.webhookService.DeleteWebhook(webhookDeleteDTO); err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotDeleteWebhook, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "DeleteWebhook",
+		Message:     "Webhook deleted",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": webhookId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries retrieves the latest delivery attempts of the webhook.
+//
+// @Tags Webhook
+// @Summary Get webhook deliveries
+// @Description Gets the latest delivery attempts of the webhook
+// @ID get-webhook-deliveries
+// @Accept json
+// @Produce json
+// @Param userId path uint64 true "Authorized user ID"
+// @Param webhookId path uint64 true "Webhook ID"
+// @Success 200 {object} model.Response "Webhook deliveries retrieved"
+// @Failure 422 {object} model.Response "Unprocessable entity"
+// @Router /users/{userId}/webhooks/{webhookId}/deliveries [get]
+func (w WebhookHandler) GetWebhookDeliveries(c echo.Context) error {
+	requestUuid := c.Get(common.RequestUuidKey).(string)
+	userId := c.Get("userId").(uint64)
+	webhookId, _ := strconv.ParseUint(c.Param("webhookId"), 10, 64)
+
+	deliveries, err := w.webhookService.GetDeliveries(webhookId, userId)
+	if err != nil {
+		return common.NewUnprocessableEntityError(serviceerror.CannotGetDeliveries, err)
+	}
+
+	w.logger.Info(logger.LogMessage{
+		Action:      "GetWebhookDeliveries",
+		Message:     "Webhook deliveries retrieved",
+		UserId:      &userId,
+		Data:        map[string]uint64{"id": webhookId},
+		RequestUuid: requestUuid,
+	})
+
+	return c.JSON(http.StatusOK, model.Response{
+		Message:     "Webhook deliveries retrieved",
+		Data:        deliveries,
+		RequestUuid: requestUuid,
+	})
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/handler"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// auditAlert prefixes log records of requests missing from the audit log, so alerts can match them.
+const auditAlert = "ALERT: " + serviceerror.CannotRecordAudit
+
+type AuditMiddleware struct {
+	auditService service.AuditService
+	logger       logger.Logger
+}
+
+func NewAuditMiddleware(services *service.Manager, logger logger.Logger) *AuditMiddleware {
+	return &AuditMiddleware{
+		auditService: services.Audit,
+		logger:       logger,
+	}
+}
+
+// RecordAudit appends every unsafe request to the audit log after it is handled, including rejected ones.
+// The change is already stored by then, so an entry that can't be appended doesn't fail the request,
+// the response keeps its status and the missing entry is logged as an alert.
+func (m *AuditMiddleware) RecordAudit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !handler.IsUnsafeMethod(c.Request().Method) {
+			return next(c)
+		}
+
+		err := next(c)
+
+		requestUuid, _ := c.Get(common.RequestUuidKey).(string)
+		entry := &entity.AuditEntry{
+			Ip:          c.RealIP(),
+			RequestUuid: requestUuid,
+			Method:      c.Request().Method,
+			Route:       c.Path(),
+			Path:        c.Request().URL.Path,
+			Status:      responseStatus(c, err),
+		}
+		if userId, ok := c.Get("userId").(uint64); ok {
+			entry.UserId = &userId
+		} else if subject, ok := handler.TokenSubject(c); ok {
+			// the token is valid but the caller was refused, e.g. the path is of another user
+			entry.UserId = &subject
+		}
+
+		if recordErr := m.auditService.Record(entry); recordErr != nil {
+			m.logger.Error(logger.LogMessage{
+				Action:      "RecordAudit",
+				Message:     auditAlert,
+				UserId:      entry.UserId,
+				Data:        map[string]any{"entry": entry, "error": recordErr.Error()},
+				RequestUuid: requestUuid,
+			})
+		}
+		return err
+	}
+}
+
+// responseStatus is the status of the response written or the one the error handler will write for err.
+func responseStatus(c echo.Context, err error) int {
+	var (
+		authorizationError       common.AuthorizationError
+		validationError          common.ValidationError
+		unprocessableEntityError common.UnprocessableEntityError
+		httpError                *echo.HTTPError
+	)
+	switch {
+	case err == nil || c.Response().Committed:
+		return c.Response().Status
+	case errors.As(err, &authorizationError):
+		return http.StatusUnauthorized
+	case errors.As(err, &validationError):
+		return http.StatusBadRequest
+	case errors.As(err, &unprocessableEntityError):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &httpError):
+		return httpError.Code
+	default:
+		return http.StatusInternalServerError
+	}
+}
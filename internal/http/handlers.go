@@ -1,6 +1,7 @@
 package http
 
 import (
+	"github.com/khivuksergey/portmonetka.category/config"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
 	"github.com/khivuksergey/portmonetka.category/internal/handler"
 	"github.com/khivuksergey/portmonetka.common/middleware/authentication"
@@ -13,12 +14,40 @@ type Handlers struct {
 	error          *error.ErrorHandlingMiddleware
 	authentication *authentication.AuthenticationMiddleware
 	category       *handler.CategoryHandler
+	webhook        *handler.WebhookHandler
+	stream         *handler.StreamHandler
+	idempotency    *handler.IdempotencyMiddleware
+	mcc            *handler.MccHandler
+	rule           *handler.RuleHandler
+	budget         *handler.BudgetHandler
+	goal           *handler.GoalHandler
+	tax            *handler.TaxHandler
+	household      *handler.HouseholdHandler
+	account        *handler.AccountHandler
+	tag            *handler.TagHandler
+	smartGroup     *handler.SmartGroupHandler
+	audit          *AuditMiddleware
+	auditLog       *handler.AuditHandler
 }
 
-func newHandlers(services *service.Manager, logger logger.Logger) Handlers {
+func newHandlers(cfg *config.Configuration, services *service.Manager, logger logger.Logger) Handlers {
 	return Handlers{
 		error:          error.NewErrorHandlingMiddleware(),
 		authentication: authentication.NewAuthenticationMiddleware(viper.GetString("JWT_SECRET"), logger),
-		category:       handler.NewCategoryHandler(services, logger),
+		category:       handler.NewCategoryHandler(services, cfg.Category, cfg.Import, logger),
+		webhook:        handler.NewWebhookHandler(services, logger),
+		stream:         handler.NewStreamHandler(services, cfg.Stream, logger),
+		idempotency:    handler.NewIdempotencyMiddleware(services, cfg.Idempotency, logger),
+		mcc:            handler.NewMccHandler(services, logger),
+		rule:           handler.NewRuleHandler(services, logger),
+		budget:         handler.NewBudgetHandler(services, logger),
+		goal:           handler.NewGoalHandler(services, logger),
+		tax:            handler.NewTaxHandler(services, logger),
+		household:      handler.NewHouseholdHandler(services, logger),
+		account:        handler.NewAccountHandler(services, logger),
+		tag:            handler.NewTagHandler(services, logger),
+		smartGroup:     handler.NewSmartGroupHandler(services, logger),
+		audit:          NewAuditMiddleware(services, logger),
+		auditLog:       handler.NewAuditHandler(services, cfg.Audit, logger),
 	}
 }
@@ -15,19 +15,95 @@ type Router struct {
 }
 
 func NewRouter(cfg *config.Configuration, services *service.Manager, logger logger.Logger) http.Handler {
-	handlers := newHandlers(services, logger)
+	handlers := newHandlers(cfg, services, logger)
 
 	e := router.NewEchoRouter().
 		WithConfig(cfg.Router).
-		UseMiddleware(handlers.error.HandleError).
+		UseMiddleware(handlers.error.HandleError, handlers.audit.RecordAudit).
 		UseHealthCheck().
 		UseSwagger(docs.SwaggerInfo, cfg.Swagger)
 
-	categories := e.Group("users/:userId/categories", handlers.authentication.AuthenticateJWT)
+	categories := e.Group("users/:userId/categories",
+		handlers.authentication.AuthenticateJWT,
+		handlers.idempotency.HandleIdempotencyKey,
+	)
 	categories.GET("", handlers.category.GetCategories)
+	categories.GET("/changes", handlers.category.GetCategoryChanges)
+	categories.GET("/stream", handlers.stream.StreamCategoryEvents)
+	categories.GET("/export", handlers.category.ExportCategories)
+	categories.GET("/suggest", handlers.category.SuggestCategories)
+	categories.GET("/budget-status", handlers.budget.GetBudgetStatus)
+	categories.GET("/tax-codes", handlers.tax.GetTaxCodes)
+	categories.GET("/tax-summary", handlers.tax.GetTaxSummary)
+	categories.GET("/:categoryId", handlers.category.GetCategory)
 	categories.POST("", handlers.category.CreateCategory)
+	categories.POST("/import", handlers.category.ImportCategories)
+	categories.POST("/resolve-mcc", handlers.mcc.ResolveMcc)
+	categories.POST("/classify", handlers.rule.ClassifyTransactions)
+	categories.POST("/spending", handlers.budget.RecordSpending)
 	categories.DELETE("/:categoryId", handlers.category.DeleteCategory)
 	categories.PATCH("/:categoryId", handlers.category.UpdateCategory)
+	categories.PUT("/by-external-id/:externalId", handlers.category.PutCategory)
+	categories.GET("/:categoryId/history", handlers.category.GetCategoryHistory)
+	categories.POST("/:categoryId/revert", handlers.category.RevertCategory)
+	categories.GET("/:categoryId/budgets", handlers.budget.GetBudgets)
+	categories.POST("/:categoryId/budgets", handlers.budget.CreateBudget)
+	categories.PATCH("/:categoryId/budgets/:budgetId", handlers.budget.UpdateBudget)
+	categories.DELETE("/:categoryId/budgets/:budgetId", handlers.budget.DeleteBudget)
+	categories.GET("/:categoryId/progress", handlers.goal.GetGoalProgress)
+	categories.GET("/:categoryId/tax", handlers.tax.GetCategoryTax)
+	categories.PUT("/:categoryId/tax", handlers.tax.SetCategoryTax)
+	categories.DELETE("/:categoryId/tax", handlers.tax.DeleteCategoryTax)
+	categories.GET("/:categoryId/accounts", handlers.account.GetCategoryAccounts)
+	categories.PUT("/:categoryId/accounts", handlers.account.SetCategoryAccounts)
+	categories.DELETE("/:categoryId/accounts/:accountId", handlers.account.DeleteCategoryAccount)
+	categories.GET("/:categoryId/tags", handlers.tag.GetCategoryTags)
+	categories.PUT("/:categoryId/tags/:tagId", handlers.tag.AssignTag)
+	categories.DELETE("/:categoryId/tags/:tagId", handlers.tag.UnassignTag)
+
+	webhooks := e.Group("users/:userId/webhooks", handlers.authentication.AuthenticateJWT)
+	webhooks.GET("", handlers.webhook.GetWebhooks)
+	webhooks.POST("", handlers.webhook.CreateWebhook)
+	webhooks.PATCH("/:webhookId", handlers.webhook.UpdateWebhook)
+	webhooks.DELETE("/:webhookId", handlers.webhook.DeleteWebhook)
+	webhooks.GET("/:webhookId/deliveries", handlers.webhook.GetWebhookDeliveries)
+
+	mccMappings := e.Group("users/:userId/mcc-mappings", handlers.authentication.AuthenticateJWT)
+	mccMappings.GET("", handlers.mcc.GetMccMappings)
+	mccMappings.POST("", handlers.mcc.CreateMccMapping)
+	mccMappings.PATCH("/:mappingId", handlers.mcc.UpdateMccMapping)
+	mccMappings.DELETE("/:mappingId", handlers.mcc.DeleteMccMapping)
+
+	rules := e.Group("users/:userId/rules", handlers.authentication.AuthenticateJWT)
+	rules.GET("", handlers.rule.GetRules)
+	rules.POST("", handlers.rule.CreateRule)
+	rules.PATCH("/:ruleId", handlers.rule.UpdateRule)
+	rules.DELETE("/:ruleId", handlers.rule.DeleteRule)
+
+	tags := e.Group("users/:userId/tags", handlers.authentication.AuthenticateJWT)
+	tags.GET("", handlers.tag.GetTags)
+	tags.POST("", handlers.tag.CreateTag)
+	tags.PATCH("/:tagId", handlers.tag.UpdateTag)
+	tags.DELETE("/:tagId", handlers.tag.DeleteTag)
+
+	smartGroups := e.Group("users/:userId/smart-groups", handlers.authentication.AuthenticateJWT)
+	smartGroups.GET("", handlers.smartGroup.GetSmartGroups)
+	smartGroups.POST("", handlers.smartGroup.CreateSmartGroup)
+	smartGroups.PATCH("/:groupId", handlers.smartGroup.UpdateSmartGroup)
+	smartGroups.DELETE("/:groupId", handlers.smartGroup.DeleteSmartGroup)
+	smartGroups.GET("/:groupId/categories", handlers.smartGroup.GetGroupCategories)
+
+	households := e.Group("users/:userId/households", handlers.authentication.AuthenticateJWT)
+	households.GET("", handlers.household.GetHouseholds)
+	households.POST("", handlers.household.CreateHousehold)
+	households.POST("/invitations/accept", handlers.household.AcceptInvitation)
+	households.GET("/:householdId/members", handlers.household.GetMembers)
+	households.DELETE("/:householdId/members/:memberId", handlers.household.RemoveMember)
+	households.POST("/:householdId/invitations", handlers.household.CreateInvitation)
+
+	auditLog := e.Group("admin/audit", handlers.authentication.JWT, handlers.auditLog.RequireAdmin)
+	auditLog.GET("/verify", handlers.auditLog.VerifyAuditLog)
+	auditLog.GET("/export", handlers.auditLog.ExportAuditLog)
 
 	return e
 }
@@ -2,7 +2,9 @@ package http
 
 import (
 	"github.com/khivuksergey/portmonetka.category/config"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/event"
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/memory"
 	"github.com/khivuksergey/portmonetka.category/internal/core/service"
 	"github.com/khivuksergey/webserver"
 	"github.com/khivuksergey/webserver/logger"
@@ -17,7 +19,24 @@ func NewServer() webserver.Server {
 
 	db := gorm.NewDbManager(cfg.DB)
 
-	services := service.NewServiceManager(db.InitRepositoryManager())
+	broadcaster := event.NewInProcessBroadcaster(cfg.Stream.BufferSize)
+
+	repositories := db.InitRepositoryManager()
+	if cfg.Idempotency.Storage == config.IdempotencyStorageMemory {
+		repositories.Idempotency = memory.NewIdempotencyRepository()
+	}
+	switch cfg.Spending.Storage {
+	case config.SpendingStorageMemory:
+		repositories.Spending = memory.NewSpendingRepository()
+	case config.SpendingStorageFile:
+		spending, err := memory.NewFileSpendingRepository(cfg.Spending.File)
+		if err != nil {
+			panic(err)
+		}
+		repositories.Spending = spending
+	}
+
+	services := service.NewServiceManager(repositories, broadcaster, cfg)
 
 	log := logger.Default.SetLevel(logger.GetLogLevelFromString(cfg.Logger.LogLevel))
 
@@ -27,7 +46,11 @@ func NewServer() webserver.Server {
 		NewServer(router).
 		WithConfig(&cfg.Server).
 		AddLogger(log).
-		AddStopHandlers(webserver.NewStopHandler("Database", db.Close))
+		AddStopHandlers(
+			webserver.NewStopHandler("Event streams", broadcaster.Close),
+			webserver.NewStopHandler("Webhooks", services.Webhook.Shutdown),
+			webserver.NewStopHandler("Database", db.Close),
+		)
 
 	return server
 }
@@ -0,0 +1,8 @@
+package model
+
+// CategoryAccountsDTO replaces the accounts the category is shown for, no accounts makes it shown for all.
+type CategoryAccountsDTO struct {
+	UserId     uint64   `json:"userId"`
+	CategoryId uint64   `json:"categoryId"`
+	AccountIds []string `json:"accountIds" validate:"max=100,dive,min=1,max=64"`
+}
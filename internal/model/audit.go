@@ -0,0 +1,21 @@
+package model
+
+// AuditVerification is the result of checking the audit chains over a time range.
+type AuditVerification struct {
+	Valid   bool `json:"valid"`
+	Entries int  `json:"entries"`
+	// Chains are the verified chains of the range by chain id, the user id or 0 for calls without one
+	Chains map[uint64]*AuditChainHead `json:"chains,omitempty"`
+	// BrokenChain and BrokenSeq are the first entry failing the check, Reason tells why
+	BrokenChain *uint64 `json:"brokenChain,omitempty"`
+	BrokenSeq   *uint64 `json:"brokenSeq,omitempty"`
+	Reason      string  `json:"reason,omitempty"`
+}
+
+// AuditChainHead is the verified part of a chain. LastHash of a valid chain can be kept elsewhere
+// to detect later removal of the entries at the end.
+type AuditChainHead struct {
+	FirstSeq uint64 `json:"firstSeq"`
+	LastSeq  uint64 `json:"lastSeq"`
+	LastHash string `json:"lastHash"`
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type BudgetCreateDTO struct {
+	UserId     uint64              `json:"userId"`
+	CategoryId uint64              `json:"categoryId"`
+	Amount     decimal.Decimal     `json:"amount" swaggertype:"string"`
+	Currency   string              `json:"currency" validate:"required,iso4217"`
+	Period     entity.BudgetPeriod `json:"period" validate:"required,oneof=weekly monthly yearly custom"`
+	// StartDate defaults to the start of the current week, month or year, custom period requires it
+	StartDate     *time.Time             `json:"startDate"`
+	EndDate       *time.Time             `json:"endDate"`
+	Rollover      *entity.BudgetRollover `json:"rollover" validate:"omitnil,oneof=none surplus full"`
+	RolloverLimit *decimal.Decimal       `json:"rolloverLimit" swaggertype:"string"`
+}
+
+type BudgetUpdateDTO struct {
+	Id            uint64                 `json:"id"`
+	UserId        uint64                 `json:"userId"`
+	CategoryId    uint64                 `json:"categoryId"`
+	Amount        *decimal.Decimal       `json:"amount" swaggertype:"string"`
+	Currency      *string                `json:"currency" validate:"omitnil,iso4217"`
+	Period        *entity.BudgetPeriod   `json:"period" validate:"omitnil,oneof=weekly monthly yearly custom"`
+	StartDate     *time.Time             `json:"startDate"`
+	EndDate       *time.Time             `json:"endDate"`
+	Rollover      *entity.BudgetRollover `json:"rollover" validate:"omitnil,oneof=none surplus full"`
+	RolloverLimit *decimal.Decimal       `json:"rolloverLimit" swaggertype:"string"`
+}
+
+type BudgetDeleteDTO struct {
+	Id         uint64 `json:"id"`
+	UserId     uint64 `json:"userId"`
+	CategoryId uint64 `json:"categoryId"`
+}
+
+// SpendingTotalDTO is the amount spent in the category over the period from periodStart inclusive
+// to periodEnd exclusive. Pushing a total for the same category, currency and period replaces it.
+type SpendingTotalDTO struct {
+	CategoryId  uint64          `json:"categoryId" validate:"required"`
+	Currency    string          `json:"currency" validate:"required,iso4217"`
+	PeriodStart time.Time       `json:"periodStart" validate:"required"`
+	PeriodEnd   time.Time       `json:"periodEnd" validate:"required"`
+	Amount      decimal.Decimal `json:"amount" swaggertype:"string"`
+}
+
+type SpendingRecordDTO struct {
+	UserId uint64             `json:"userId"`
+	Totals []SpendingTotalDTO `json:"totals" validate:"required,min=1,max=1000,dive"`
+}
+
+type BudgetStatusDTO struct {
+	UserId uint64
+	// Period filters budgets by period, all budgets when empty
+	Period entity.BudgetPeriod
+	// Date selects the budget periods containing it
+	Date time.Time
+}
+
+// BudgetStatus is the progress of the budget in its period containing the requested date.
+// Limit is the budget amount with the rollover from the previous periods.
+type BudgetStatus struct {
+	BudgetId     uint64              `json:"budgetId"`
+	CategoryId   uint64              `json:"categoryId"`
+	CategoryName string              `json:"categoryName"`
+	Period       entity.BudgetPeriod `json:"period"`
+	Currency     string              `json:"currency"`
+	PeriodStart  time.Time           `json:"periodStart"`
+	PeriodEnd    time.Time           `json:"periodEnd"`
+	Limit        decimal.Decimal     `json:"limit" swaggertype:"string"`
+	Spent        decimal.Decimal     `json:"spent" swaggertype:"string"`
+	Remaining    decimal.Decimal     `json:"remaining" swaggertype:"string"`
+	Percentage   decimal.Decimal     `json:"percentage" swaggertype:"string"`
+}
+
+// BudgetThresholdEvent is the data of budget.threshold_crossed event.
+type BudgetThresholdEvent struct {
+	Threshold int          `json:"threshold"`
+	Status    BudgetStatus `json:"status"`
+}
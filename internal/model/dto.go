@@ -2,13 +2,36 @@ package model
 
 import (
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/shopspring/decimal"
+	"time"
 )
 
 type CategoryCreateDTO struct {
 	UserId      uint64              `json:"userId"`
 	Name        string              `json:"name" validate:"required"`
 	Description string              `json:"description"`
-	Type        entity.CategoryType `json:"type" validate:"required,oneof=INCOME EXPENSE"`
+	Type        entity.CategoryType `json:"type" validate:"required,oneof=INCOME EXPENSE GOAL"`
+	ExternalId  *string             `json:"externalId" validate:"omitnil,min=1,max=255"`
+	// TargetAmount, TargetDate and TargetCurrency are required for GOAL type and not allowed for others
+	TargetAmount   *decimal.Decimal `json:"targetAmount" swaggertype:"string"`
+	TargetDate     *time.Time       `json:"targetDate"`
+	TargetCurrency *string          `json:"targetCurrency" validate:"omitnil,iso4217"`
+	// HouseholdId shares the category with the household, the user must be its editor
+	HouseholdId *uint64 `json:"householdId"`
+}
+
+// CategoryPutDTO is the full category representation identified by the client supplied external id.
+type CategoryPutDTO struct {
+	UserId      uint64              `json:"-"`
+	ExternalId  string              `json:"-" validate:"min=1,max=255"`
+	Name        string              `json:"name" validate:"required,min=3,max=128"`
+	Description string              `json:"description" validate:"max=256"`
+	Type        entity.CategoryType `json:"type" validate:"required,oneof=INCOME EXPENSE GOAL"`
+	// TargetAmount, TargetDate and TargetCurrency are required for GOAL type and not allowed for others
+	TargetAmount   *decimal.Decimal `json:"targetAmount" swaggertype:"string"`
+	TargetDate     *time.Time       `json:"targetDate"`
+	TargetCurrency *string          `json:"targetCurrency" validate:"omitnil,iso4217"`
+	Version        *uint64          `json:"-" swaggerignore:"true"`
 }
 
 type CategoryUpdateDTO struct {
@@ -16,9 +39,78 @@ type CategoryUpdateDTO struct {
 	UserId      uint64  `json:"userId"`
 	Name        *string `json:"name"`
 	Description *string `json:"description"`
+	// TargetAmount, TargetDate and TargetCurrency can be changed for goal categories
+	TargetAmount   *decimal.Decimal `json:"targetAmount" swaggertype:"string"`
+	TargetDate     *time.Time       `json:"targetDate"`
+	TargetCurrency *string          `json:"targetCurrency" validate:"omitnil,iso4217"`
+	// Version is the expected category version taken from If-Match header
+	Version *uint64 `json:"-" swaggerignore:"true"`
+}
+
+// PatchType is the media type of the category patch document.
+type PatchType string
+
+const (
+	MergePatch PatchType = "application/merge-patch+json"
+	JSONPatch  PatchType = "application/json-patch+json"
+)
+
+// CategoryPatchDTO is RFC 7396 merge patch or RFC 6902 JSON patch applied to the category JSON.
+type CategoryPatchDTO struct {
+	Id      uint64
+	UserId  uint64
+	Type    PatchType
+	Patch   []byte
+	Version *uint64
 }
 
 type CategoryDeleteDTO struct {
+	Id      uint64  `json:"id"`
+	UserId  uint64  `json:"userId"`
+	Version *uint64 `json:"-" swaggerignore:"true"`
+}
+
+// CategoryRevertDTO rolls the category back to the version from its history.
+type CategoryRevertDTO struct {
+	Id     uint64 `json:"-"`
+	UserId uint64 `json:"-"`
+	// Version is the version to roll back to
+	Version uint64 `json:"version" validate:"required"`
+	// ExpectedVersion is the current category version taken from If-Match header
+	ExpectedVersion *uint64 `json:"-" swaggerignore:"true"`
+}
+
+type WebhookCreateDTO struct {
+	UserId uint64   `json:"userId"`
+	Url    string   `json:"url" validate:"required,http_url,max=2048"`
+	Secret string   `json:"secret" validate:"required,min=16,max=256"`
+	Events []string `json:"events" validate:"dive,oneof=category.created category.updated category.deleted budget.threshold_crossed"`
+}
+
+type WebhookUpdateDTO struct {
+	Id      uint64    `json:"id"`
+	UserId  uint64    `json:"userId"`
+	Url     *string   `json:"url" validate:"omitnil,http_url,max=2048"`
+	Secret  *string   `json:"secret" validate:"omitnil,min=16,max=256"`
+	Events  *[]string `json:"events" validate:"omitnil,dive,oneof=category.created category.updated category.deleted budget.threshold_crossed"`
+	Enabled *bool     `json:"enabled"`
+}
+
+type WebhookDeleteDTO struct {
 	Id     uint64 `json:"id"`
 	UserId uint64 `json:"userId"`
 }
+
+type CategoryChangesDTO struct {
+	Created            []entity.Category   `json:"created"`
+	Updated            []entity.Category   `json:"updated"`
+	Deleted            []CategoryTombstone `json:"deleted"`
+	Token              string              `json:"token"`
+	HasMore            bool                `json:"hasMore"`
+	FullResyncRequired bool                `json:"fullResyncRequired"`
+}
+
+type CategoryTombstone struct {
+	Id        uint64    `json:"id"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+type EventType string
+
+const (
+	CategoryCreated EventType = "category.created"
+	CategoryUpdated EventType = "category.updated"
+	CategoryDeleted EventType = "category.deleted"
+	CategoryChanges EventType = "category.changes"
+	Resync          EventType = "resync"
+	// BudgetThresholdCrossed is emitted when spending reaches a configured percentage of the budget
+	BudgetThresholdCrossed EventType = "budget.threshold_crossed"
+)
+
+// Event describes a change of user's data that is delivered to subscribers.
+type Event struct {
+	Type       EventType `json:"type"`
+	UserId     uint64    `json:"userId"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+	// Sequence is the position of the change in user's change feed, zero when unknown
+	Sequence uint64 `json:"-"`
+}
+
+// StreamMessage is a server-sent event.
+type StreamMessage struct {
+	Id    string
+	Event EventType
+	Data  any
+}
+
+func NewEvent(eventType EventType, userId uint64, data any) Event {
+	return Event{
+		Type:       eventType,
+		UserId:     userId,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/shopspring/decimal"
+	"strconv"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportJSON ExportFormat = "json"
+)
+
+// CategoryExportVersion is the version of the export schema, it changes when columns are changed or removed.
+const CategoryExportVersion = "2"
+
+// CategoryRecordColumns is the CSV header of exported categories.
+var CategoryRecordColumns = []string{
+	"id", "externalId", "name", "type", "description", "targetAmount", "targetDate", "targetCurrency", "createdAt", "updatedAt",
+}
+
+// CategoryRecord is the exported category.
+type CategoryRecord struct {
+	Id          uint64              `json:"id"`
+	ExternalId  string              `json:"externalId"`
+	Name        string              `json:"name"`
+	Type        entity.CategoryType `json:"type"`
+	Description string              `json:"description"`
+	// TargetAmount, TargetDate and TargetCurrency are set for goal categories only
+	TargetAmount   *decimal.Decimal `json:"targetAmount,omitempty" swaggertype:"string"`
+	TargetDate     *time.Time       `json:"targetDate,omitempty"`
+	TargetCurrency *string          `json:"targetCurrency,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
+}
+
+func NewCategoryRecord(category entity.Category) CategoryRecord {
+	record := CategoryRecord{
+		Id:             category.Id,
+		Name:           category.Name,
+		Type:           category.Type,
+		Description:    category.Description,
+		TargetAmount:   category.TargetAmount,
+		TargetDate:     category.TargetDate,
+		TargetCurrency: category.TargetCurrency,
+		CreatedAt:      category.CreatedAt.UTC(),
+		UpdatedAt:      category.UpdatedAt.UTC(),
+	}
+	if category.ExternalId != nil {
+		record.ExternalId = *category.ExternalId
+	}
+	return record
+}
+
+// CSV returns the record fields in CategoryRecordColumns order, the target date as 2006-01-02.
+func (r CategoryRecord) CSV() []string {
+	var targetAmount, targetDate, targetCurrency string
+	if r.TargetAmount != nil {
+		targetAmount = r.TargetAmount.String()
+	}
+	if r.TargetDate != nil {
+		targetDate = r.TargetDate.Format(time.DateOnly)
+	}
+	if r.TargetCurrency != nil {
+		targetCurrency = *r.TargetCurrency
+	}
+	return []string{
+		strconv.FormatUint(r.Id, 10),
+		r.ExternalId,
+		r.Name,
+		string(r.Type),
+		r.Description,
+		targetAmount,
+		targetDate,
+		targetCurrency,
+		r.CreatedAt.Format(time.RFC3339),
+		r.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// GoalProgress is the amount saved towards the goal category target.
+// Only contributions in the target currency are counted.
+type GoalProgress struct {
+	CategoryId     uint64          `json:"categoryId"`
+	Name           string          `json:"name"`
+	TargetAmount   decimal.Decimal `json:"targetAmount" swaggertype:"string"`
+	TargetCurrency string          `json:"targetCurrency"`
+	TargetDate     time.Time       `json:"targetDate"`
+	Saved          decimal.Decimal `json:"saved" swaggertype:"string"`
+	Remaining      decimal.Decimal `json:"remaining" swaggertype:"string"`
+	Percentage     decimal.Decimal `json:"percentage" swaggertype:"string"`
+	// DaysLeft is the number of days until the target date, zero when it has passed
+	DaysLeft int  `json:"daysLeft"`
+	Achieved bool `json:"achieved"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+
+type HouseholdCreateDTO struct {
+	UserId uint64 `json:"userId"`
+	Name   string `json:"name" validate:"required,max=255"`
+}
+
+// HouseholdInvitationCreateDTO invites to the household with the role, anyone with the token
+// can accept the invitation unless InviteeId is set.
+type HouseholdInvitationCreateDTO struct {
+	UserId      uint64               `json:"userId"`
+	HouseholdId uint64               `json:"householdId"`
+	InviteeId   *uint64              `json:"inviteeId"`
+	Role        entity.HouseholdRole `json:"role" validate:"required,oneof=viewer editor"`
+}
+
+type HouseholdInvitationAcceptDTO struct {
+	UserId uint64 `json:"userId"`
+	Token  string `json:"token" validate:"required,len=64,hexadecimal"`
+}
+
+type HouseholdMemberDeleteDTO struct {
+	UserId      uint64 `json:"userId"`
+	HouseholdId uint64 `json:"householdId"`
+	MemberId    uint64 `json:"memberId"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import "io"
+
+// ImportFormat is the format of the imported file.
+type ImportFormat string
+
+const (
+	ImportCSV        ImportFormat = "csv"
+	ImportJSON       ImportFormat = "json"
+	ImportQIF        ImportFormat = "qif"
+	ImportYNAB       ImportFormat = "ynab"
+	ImportCoinKeeper ImportFormat = "coinkeeper"
+)
+
+// ConflictPolicy tells what to do with imported category whose name is already taken.
+type ConflictPolicy string
+
+const (
+	ConflictSkip      ConflictPolicy = "skip"
+	ConflictRename    ConflictPolicy = "rename"
+	ConflictOverwrite ConflictPolicy = "overwrite"
+)
+
+type ImportStatus string
+
+const (
+	ImportCreated ImportStatus = "created"
+	ImportRenamed ImportStatus = "renamed"
+	ImportUpdated ImportStatus = "updated"
+	ImportSkipped ImportStatus = "skipped"
+	ImportInvalid ImportStatus = "invalid"
+)
+
+type CategoryImportDTO struct {
+	UserId uint64
+	// Format is detected from the file content when empty
+	Format   ImportFormat
+	File     io.Reader
+	DryRun   bool
+	Conflict ConflictPolicy
+}
+
+type CategoryImportReport struct {
+	DryRun  bool                `json:"dryRun"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Skipped int                 `json:"skipped"`
+	Invalid int                 `json:"invalid"`
+	Rows    []CategoryImportRow `json:"rows"`
+}
+
+type CategoryImportRow struct {
+	// Row is the number of the category in the file starting from 1
+	Row        int          `json:"row"`
+	Name       string       `json:"name"`
+	Status     ImportStatus `json:"status"`
+	CategoryId uint64       `json:"categoryId,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import "github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+
+type MccMappingCreateDTO struct {
+	UserId uint64 `json:"userId"`
+	// Mcc is a single code "5411" or an inclusive range "3000-3299"
+	Mcc        string `json:"mcc" validate:"required"`
+	CategoryId uint64 `json:"categoryId" validate:"required"`
+}
+
+type MccMappingUpdateDTO struct {
+	Id         uint64  `json:"id"`
+	UserId     uint64  `json:"userId"`
+	Mcc        *string `json:"mcc"`
+	CategoryId *uint64 `json:"categoryId" validate:"omitnil,min=1"`
+}
+
+type MccMappingDeleteDTO struct {
+	Id     uint64 `json:"id"`
+	UserId uint64 `json:"userId"`
+}
+
+type MccResolveDTO struct {
+	UserId uint64 `json:"userId"`
+	Mcc    string `json:"mcc" validate:"required,len=4,numeric"`
+}
+
+// MccResolutionSource tells where the resolved category came from.
+type MccResolutionSource string
+
+const (
+	// MccSourceMapping is user's own mapping of the code
+	MccSourceMapping MccResolutionSource = "mapping"
+	// MccSourceTemplate is user's category named after the default template of the code
+	MccSourceTemplate MccResolutionSource = "template"
+	// MccSourceNone means no user's category matches, Template may still suggest one to create
+	MccSourceNone MccResolutionSource = "none"
+)
+
+type MccResolution struct {
+	Mcc         string              `json:"mcc"`
+	Description string              `json:"description,omitempty"`
+	Template    string              `json:"template,omitempty"`
+	Source      MccResolutionSource `json:"source"`
+	MappingId   *uint64             `json:"mappingId,omitempty"`
+	Category    *entity.Category    `json:"category"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/shopspring/decimal"
+)
+
+type RuleCreateDTO struct {
+	UserId     uint64               `json:"userId"`
+	Name       string               `json:"name" validate:"required,max=128"`
+	Priority   int                  `json:"priority"`
+	CategoryId uint64               `json:"categoryId" validate:"required"`
+	Condition  entity.RuleCondition `json:"condition"`
+	Enabled    *bool                `json:"enabled"`
+}
+
+type RuleUpdateDTO struct {
+	Id         uint64                `json:"id"`
+	UserId     uint64                `json:"userId"`
+	Name       *string               `json:"name" validate:"omitnil,min=1,max=128"`
+	Priority   *int                  `json:"priority"`
+	CategoryId *uint64               `json:"categoryId" validate:"omitnil,min=1"`
+	Condition  *entity.RuleCondition `json:"condition"`
+	Enabled    *bool                 `json:"enabled"`
+}
+
+type RuleDeleteDTO struct {
+	Id     uint64 `json:"id"`
+	UserId uint64 `json:"userId"`
+}
+
+// TransactionDescriptor is what the rules see of a bank transaction.
+type TransactionDescriptor struct {
+	// Id is an optional client reference echoed back in the classification
+	Id          string          `json:"id,omitempty" validate:"max=255"`
+	Description string          `json:"description" validate:"max=1024"`
+	Amount      decimal.Decimal `json:"amount" swaggertype:"string"`
+}
+
+type ClassifyDTO struct {
+	UserId       uint64                  `json:"userId"`
+	Transactions []TransactionDescriptor `json:"transactions" validate:"required,min=1,max=1000,dive"`
+}
+
+// Classification is the result for the transaction at the same position of the batch.
+// CategoryId and RuleId are null when no rule matched.
+type Classification struct {
+	Id         string  `json:"id,omitempty"`
+	CategoryId *uint64 `json:"categoryId"`
+	RuleId     *uint64 `json:"ruleId"`
+}
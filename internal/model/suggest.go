@@ -0,0 +1,37 @@
+package model
+
+import "github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+
+// SuggestionSource tells whether the suggestion is user's category or a template to create.
+type SuggestionSource string
+
+const (
+	SuggestionCategory SuggestionSource = "category"
+	SuggestionTemplate SuggestionSource = "template"
+)
+
+type CategorySuggestion struct {
+	Name       string              `json:"name"`
+	Type       entity.CategoryType `json:"type"`
+	Source     SuggestionSource    `json:"source"`
+	CategoryId *uint64             `json:"categoryId,omitempty"`
+	// Score is the similarity from 0 to 1
+	Score float64 `json:"score"`
+}
+
+// SimilarMode tells how create treats near-duplicate names of user's categories.
+type SimilarMode string
+
+const (
+	SimilarOff SimilarMode = ""
+	// SimilarWarn creates the category and returns near-duplicates alongside
+	SimilarWarn SimilarMode = "warn"
+	// SimilarStrict rejects the category when it has near-duplicates
+	SimilarStrict SimilarMode = "strict"
+)
+
+// CategoryCreatedDTO is the created category with near-duplicates of its name.
+type CategoryCreatedDTO struct {
+	Category *entity.Category     `json:"category"`
+	Similar  []CategorySuggestion `json:"similar"`
+}
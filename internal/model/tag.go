@@ -0,0 +1,44 @@
+package model
+
+import "github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+
+type TagCreateDTO struct {
+	UserId uint64 `json:"userId"`
+	Name   string `json:"name" validate:"required,max=64"`
+}
+
+type TagUpdateDTO struct {
+	Id     uint64 `json:"id"`
+	UserId uint64 `json:"userId"`
+	Name   string `json:"name" validate:"required,max=64"`
+}
+
+type TagDeleteDTO struct {
+	Id     uint64 `json:"id"`
+	UserId uint64 `json:"userId"`
+}
+
+// CategoryTagDTO assigns user's tag to the category or removes the assignment.
+type CategoryTagDTO struct {
+	UserId     uint64 `json:"userId"`
+	CategoryId uint64 `json:"categoryId"`
+	TagId      uint64 `json:"tagId"`
+}
+
+type SmartGroupCreateDTO struct {
+	UserId uint64             `json:"userId"`
+	Name   string             `json:"name" validate:"required,max=128"`
+	Filter entity.GroupFilter `json:"filter"`
+}
+
+type SmartGroupUpdateDTO struct {
+	Id     uint64              `json:"id"`
+	UserId uint64              `json:"userId"`
+	Name   *string             `json:"name" validate:"omitnil,min=1,max=128"`
+	Filter *entity.GroupFilter `json:"filter"`
+}
+
+type SmartGroupDeleteDTO struct {
+	Id     uint64 `json:"id"`
+	UserId uint64 `json:"userId"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/shopspring/decimal"
+)
+
+// CategoryTaxDTO replaces tax attributes of the category.
+type CategoryTaxDTO struct {
+	UserId     uint64 `json:"userId"`
+	CategoryId uint64 `json:"categoryId"`
+	// Deductible is allowed for expense categories only
+	Deductible bool `json:"deductible"`
+	// DeductiblePercentage defaults to 100 for deductible categories
+	DeductiblePercentage *decimal.Decimal `json:"deductiblePercentage" swaggertype:"string"`
+	// Jurisdiction and TaxCode are set together, the code must match the category type
+	Jurisdiction *string `json:"jurisdiction" validate:"omitnil,len=2"`
+	TaxCode      *string `json:"taxCode" validate:"omitnil,min=1,max=32"`
+}
+
+// TaxSummaryLine is a tax form line with user's categories mapped to it.
+// Deductible categories without a tax code are grouped under an empty code.
+type TaxSummaryLine struct {
+	Jurisdiction string               `json:"jurisdiction"`
+	TaxCode      string               `json:"taxCode"`
+	Form         string               `json:"form"`
+	Line         string               `json:"line"`
+	Description  string               `json:"description"`
+	Categories   []TaxSummaryCategory `json:"categories"`
+}
+
+type TaxSummaryCategory struct {
+	CategoryId           uint64              `json:"categoryId"`
+	Name                 string              `json:"name"`
+	Type                 entity.CategoryType `json:"type"`
+	Deductible           bool                `json:"deductible"`
+	DeductiblePercentage decimal.Decimal     `json:"deductiblePercentage" swaggertype:"string"`
+}
@@ -0,0 +1,108 @@
+package reference
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//go:embed mcc.csv
+var mccCSV []byte
+
+// MccRange is an inclusive range of merchant category codes. Codes are four digit strings,
+// so they compare in numeric order.
+type MccRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MerchantCategory is an ISO 18245 code or range with the template it falls into by default.
+type MerchantCategory struct {
+	Range       MccRange `json:"range"`
+	Description string   `json:"description"`
+	Template    string   `json:"template"`
+}
+
+var merchantCategories = mustLoadMerchantCategories()
+
+// ValidMcc reports whether mcc is a four digit code.
+func ValidMcc(mcc string) bool {
+	if len(mcc) != 4 {
+		return false
+	}
+	_, err := strconv.ParseUint(mcc, 10, 16)
+	return err == nil
+}
+
+// ParseMccRange parses a single code "5411" or a range "3000-3299".
+func ParseMccRange(s string) (MccRange, bool) {
+	from, to, found := strings.Cut(strings.TrimSpace(s), "-")
+	if !found {
+		to = from
+	}
+	r := MccRange{From: strings.TrimSpace(from), To: strings.TrimSpace(to)}
+	if !ValidMcc(r.From) || !ValidMcc(r.To) || r.From > r.To {
+		return MccRange{}, false
+	}
+	return r, true
+}
+
+func (r MccRange) Contains(mcc string) bool {
+	return r.From <= mcc && mcc <= r.To
+}
+
+// Width is the number of codes in the range.
+func (r MccRange) Width() int {
+	from, _ := strconv.Atoi(r.From)
+	to, _ := strconv.Atoi(r.To)
+	return to - from + 1
+}
+
+func (r MccRange) String() string {
+	if r.From == r.To {
+		return r.From
+	}
+	return r.From + "-" + r.To
+}
+
+// MerchantCategories returns the whole MCC reference dataset.
+func MerchantCategories() []MerchantCategory {
+	return append([]MerchantCategory(nil), merchantCategories...)
+}
+
+// LookupMcc finds the reference entry of the code, an exact code wins over a range containing it.
+func LookupMcc(mcc string) (MerchantCategory, bool) {
+	var found *MerchantCategory
+	for i, merchantCategory := range merchantCategories {
+		if !merchantCategory.Range.Contains(mcc) {
+			continue
+		}
+		if found == nil || merchantCategory.Range.Width() < found.Range.Width() {
+			found = &merchantCategories[i]
+		}
+	}
+	if found == nil {
+		return MerchantCategory{}, false
+	}
+	return *found, true
+}
+
+func mustLoadMerchantCategories() []MerchantCategory {
+	rows, err := readCSV(mccCSV)
+	if err != nil {
+		panic(fmt.Errorf("reference mcc: %w", err))
+	}
+	result := make([]MerchantCategory, 0, len(rows))
+	for _, row := range rows {
+		r, ok := ParseMccRange(row[0])
+		if !ok {
+			panic(fmt.Errorf("reference mcc: invalid code %q", row[0]))
+		}
+		if _, ok = GetTemplate(row[2]); !ok {
+			panic(fmt.Errorf("reference mcc: unknown template %q of %s", row[2], row[0]))
+		}
+		result = append(result, MerchantCategory{Range: r, Description: row[1], Template: row[2]})
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package reference
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+)
+
+//go:embed taxcodes.csv
+var taxCodesCSV []byte
+
+// TaxCode is a line of a jurisdiction's tax form that income or expense categories map to.
+type TaxCode struct {
+	Jurisdiction string              `json:"jurisdiction"`
+	Code         string              `json:"code"`
+	Type         entity.CategoryType `json:"type"`
+	Form         string              `json:"form"`
+	Line         string              `json:"line"`
+	Description  string              `json:"description"`
+}
+
+var taxCodes = mustLoadTaxCodes()
+
+// TaxCodes returns the codes of the jurisdiction in form line order, all codes when jurisdiction is empty.
+func TaxCodes(jurisdiction string) []TaxCode {
+	result := make([]TaxCode, 0)
+	for _, taxCode := range taxCodes {
+		if jurisdiction == "" || taxCode.Jurisdiction == jurisdiction {
+			result = append(result, taxCode)
+		}
+	}
+	return result
+}
+
+// GetTaxCode finds the code of the jurisdiction.
+func GetTaxCode(jurisdiction, code string) (TaxCode, bool) {
+	for _, taxCode := range taxCodes {
+		if taxCode.Jurisdiction == jurisdiction && taxCode.Code == code {
+			return taxCode, true
+		}
+	}
+	return TaxCode{}, false
+}
+
+// ValidJurisdiction reports whether the reference list has codes of the jurisdiction.
+func ValidJurisdiction(jurisdiction string) bool {
+	for _, taxCode := range taxCodes {
+		if taxCode.Jurisdiction == jurisdiction {
+			return true
+		}
+	}
+	return false
+}
+
+func mustLoadTaxCodes() []TaxCode {
+	rows, err := readCSV(taxCodesCSV)
+	if err != nil {
+		panic(fmt.Errorf("reference tax codes: %w", err))
+	}
+	result := make([]TaxCode, 0, len(rows))
+	for _, row := range rows {
+		categoryType := entity.CategoryType(row[2])
+		if categoryType != entity.Income && categoryType != entity.Expense {
+			panic(fmt.Errorf("reference tax codes: invalid type %q of %s", row[2], row[1]))
+		}
+		result = append(result, TaxCode{
+			Jurisdiction: row[0],
+			Code:         row[1],
+			Type:         categoryType,
+			Form:         row[3],
+			Line:         row[4],
+			Description:  row[5],
+		})
+	}
+	return result
+}
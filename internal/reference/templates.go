@@ -0,0 +1,67 @@
+// Package reference holds embedded reference data: default category templates and
+// ISO 18245 merchant category codes mapped to them.
+package reference
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"strings"
+)
+
+//go:embed templates.csv
+var templatesCSV []byte
+
+// Template is a default category offered to users who don't have a matching one yet.
+type Template struct {
+	Name        string              `json:"name"`
+	Type        entity.CategoryType `json:"type"`
+	Description string              `json:"description"`
+}
+
+var templates = mustLoadTemplates()
+
+// Templates returns all category templates.
+func Templates() []Template {
+	return append([]Template(nil), templates...)
+}
+
+// GetTemplate finds the template by case-insensitive name.
+func GetTemplate(name string) (Template, bool) {
+	for _, template := range templates {
+		if strings.EqualFold(template.Name, name) {
+			return template, true
+		}
+	}
+	return Template{}, false
+}
+
+func mustLoadTemplates() []Template {
+	rows, err := readCSV(templatesCSV)
+	if err != nil {
+		panic(fmt.Errorf("reference templates: %w", err))
+	}
+	result := make([]Template, 0, len(rows))
+	for _, row := range rows {
+		categoryType := entity.CategoryType(row[1])
+		if categoryType != entity.Income && categoryType != entity.Expense {
+			panic(fmt.Errorf("reference templates: invalid type of %q", row[0]))
+		}
+		result = append(result, Template{Name: row[0], Type: categoryType, Description: row[2]})
+	}
+	return result
+}
+
+// readCSV reads the embedded file skipping its header.
+func readCSV(data []byte) ([][]string, error) {
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("missing header")
+	}
+	return rows[1:], nil
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+	"github.com/khivuksergey/portmonetka.category/internal/cli"
 	"github.com/khivuksergey/portmonetka.category/internal/http"
 	"github.com/khivuksergey/webserver"
 	"os"
@@ -14,6 +16,14 @@ import (
 // @BasePath /
 // @schemes http https
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "audit" {
+		if err := cli.RunAudit(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	server := http.NewServer()
 	quit := make(chan os.Signal, 1)
 	if err := webserver.RunServer(server, quit); err != nil {
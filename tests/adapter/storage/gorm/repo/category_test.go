@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTranslateCategoryError(t *testing.T) {
+	uniqueViolation := func(constraint string) *pgconn.PgError {
+		return &pgconn.PgError{Code: "23505", ConstraintName: constraint}
+	}
+	notNullViolation := &pgconn.PgError{Code: "23502", ColumnName: "name"}
+	unknownIndex := uniqueViolation("idx_categories_unknown")
+	otherError := errors.New("connection reset")
+
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "user name", err: uniqueViolation("idx_categories_user_name"), expected: serviceerror.CategoryAlreadyExists},
+		{name: "household name", err: uniqueViolation("idx_categories_household_name"), expected: serviceerror.CategoryAlreadyExists},
+		{name: "external id", err: uniqueViolation("idx_categories_user_external_id"), expected: serviceerror.CategoryExternalIdExists},
+		{name: "transfer", err: uniqueViolation("idx_categories_user_transfer"), expected: serviceerror.TransferCategoryExists},
+		{name: "wrapped", err: fmt.Errorf("create: %w", uniqueViolation("idx_categories_user_name")), expected: serviceerror.CategoryAlreadyExists},
+		{name: "unknown index", err: unknownIndex, expected: unknownIndex},
+		{name: "not unique violation", err: notNullViolation, expected: notNullViolation},
+		{name: "not postgres", err: otherError, expected: otherError},
+		{name: "nil", err: nil, expected: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, repo.TranslateCategoryError(tc.err))
+		})
+	}
+}
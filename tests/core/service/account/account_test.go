@@ -0,0 +1,148 @@
+package account
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/account"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"strings"
+	"testing"
+)
+
+const (
+	userId     = uint64(1)
+	categoryId = uint64(10)
+)
+
+func newAccountService(ctl *gomock.Controller) (service.AccountService, *mock.MockCategoryAccountRepository, *mock.MockCategoryRepository) {
+	mockAccountRepository := mock.NewMockCategoryAccountRepository(ctl)
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+		Account:  mockAccountRepository,
+	}
+	return account.NewAccountService(mockManager), mockAccountRepository, mockCategoryRepository
+}
+
+func expectCategory(repo *mock.MockCategoryRepository, ownerId uint64) {
+	repo.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: ownerId, Name: "Office", Type: entity.Expense}, nil)
+}
+
+func TestSetCategoryAccounts_DeduplicatesAndSorts(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	accountService, mockAccountRepository, mockCategoryRepository := newAccountService(ctl)
+	expectCategory(mockCategoryRepository, userId)
+
+	mockAccountRepository.
+		EXPECT().
+		SetAccountIds(categoryId, []string{"business", "personal"}).
+		Times(1).
+		Return(nil)
+
+	result, err := accountService.SetCategoryAccounts(model.CategoryAccountsDTO{
+		UserId:     userId,
+		CategoryId: categoryId,
+		AccountIds: []string{"personal", "business", "personal"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"business", "personal"}, result)
+}
+
+func TestSetCategoryAccounts_Empty_Unscopes(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	accountService, mockAccountRepository, mockCategoryRepository := newAccountService(ctl)
+	expectCategory(mockCategoryRepository, userId)
+
+	mockAccountRepository.
+		EXPECT().
+		SetAccountIds(categoryId, []string{}).
+		Times(1).
+		Return(nil)
+
+	result, err := accountService.SetCategoryAccounts(model.CategoryAccountsDTO{UserId: userId, CategoryId: categoryId})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestSetCategoryAccounts_InvalidAccountId_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	accountService, _, mockCategoryRepository := newAccountService(ctl)
+	expectCategory(mockCategoryRepository, userId)
+
+	_, err := accountService.SetCategoryAccounts(model.CategoryAccountsDTO{
+		UserId:     userId,
+		CategoryId: categoryId,
+		AccountIds: []string{strings.Repeat("a", entity.MaxAccountIdLength+1)},
+	})
+
+	assert.ErrorIs(t, err, serviceerror.InvalidAccountId)
+}
+
+func TestSetCategoryAccounts_DoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	accountService, _, mockCategoryRepository := newAccountService(ctl)
+	expectCategory(mockCategoryRepository, 2)
+
+	_, err := accountService.SetCategoryAccounts(model.CategoryAccountsDTO{
+		UserId:     userId,
+		CategoryId: categoryId,
+		AccountIds: []string{"business"},
+	})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntBelongToUser)
+}
+
+func TestGetCategoryAccounts_CategoryDoesntExist_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	accountService, _, mockCategoryRepository := newAccountService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := accountService.GetCategoryAccounts(userId, categoryId)
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntExist)
+}
+
+func TestDeleteCategoryAccount_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	accountService, mockAccountRepository, mockCategoryRepository := newAccountService(ctl)
+	expectCategory(mockCategoryRepository, userId)
+
+	mockAccountRepository.
+		EXPECT().
+		DeleteAccountId(categoryId, "business").
+		Times(1).
+		Return(nil)
+
+	err := accountService.DeleteCategoryAccount(userId, categoryId, "business")
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,205 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/audit"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+var base = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newAuditService(ctl *gomock.Controller) (service.AuditService, *mock.MockAuditRepository) {
+	mockAuditRepository := mock.NewMockAuditRepository(ctl)
+	mockManager := &repository.Manager{
+		Audit: mockAuditRepository,
+	}
+	return audit.NewAuditService(mockManager), mockAuditRepository
+}
+
+// chain builds n correctly linked entries of the user.
+func chain(userId uint64, n int) []entity.AuditEntry {
+	entries := make([]entity.AuditEntry, n)
+	prevHash := ""
+	for i := range entries {
+		entries[i] = entity.AuditEntry{
+			Id:          userId*100 + uint64(i+1),
+			Chain:       userId,
+			Seq:         uint64(i + 1),
+			CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+			UserId:      &userId,
+			Ip:          "10.0.0.1",
+			RequestUuid: "8c1f0a52-54a4-4d8c-9d0e-6f3b0a1d2c3e",
+			Method:      "POST",
+			Route:       "/users/:userId/categories",
+			Path:        fmt.Sprintf("/users/%d/categories", userId),
+			Status:      201,
+			PrevHash:    prevHash,
+		}
+		entries[i].Hash = entries[i].ChainHash()
+		prevHash = entries[i].Hash
+	}
+	return entries
+}
+
+func expectEntries(repo *mock.MockAuditRepository, start, end time.Time, entries []entity.AuditEntry) {
+	repo.
+		EXPECT().
+		ForEachEntry(start, end, gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_, _ time.Time, _ int, fn func(entry entity.AuditEntry) error) error {
+			for _, entry := range entries {
+				if err := fn(entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func TestVerify_ValidChain(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, mockAuditRepository := newAuditService(ctl)
+	entries := chain(1, 3)
+	expectEntries(mockAuditRepository, time.Time{}, time.Time{}, entries)
+
+	verification, err := auditService.Verify(time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.True(t, verification.Valid)
+	assert.Equal(t, 3, verification.Entries)
+	assert.Equal(t, &model.AuditChainHead{FirstSeq: 1, LastSeq: 3, LastHash: entries[2].Hash}, verification.Chains[1])
+}
+
+func TestVerify_InterleavedChains(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, mockAuditRepository := newAuditService(ctl)
+	first, second := chain(1, 2), chain(2, 2)
+	expectEntries(mockAuditRepository, time.Time{}, time.Time{}, []entity.AuditEntry{first[0], second[0], second[1], first[1]})
+
+	verification, err := auditService.Verify(time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.True(t, verification.Valid)
+	assert.Equal(t, 4, verification.Entries)
+	assert.Equal(t, first[1].Hash, verification.Chains[1].LastHash)
+	assert.Equal(t, second[1].Hash, verification.Chains[2].LastHash)
+}
+
+func TestVerify_EntryOfOtherChain_Broken(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, mockAuditRepository := newAuditService(ctl)
+	first, second := chain(1, 2), chain(2, 2)
+	moved := second[1]
+	moved.Chain = 1
+	expectEntries(mockAuditRepository, time.Time{}, time.Time{}, []entity.AuditEntry{first[0], first[1], second[0], moved})
+
+	verification, err := auditService.Verify(time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.False(t, verification.Valid)
+	assert.Equal(t, uint64(1), *verification.BrokenChain)
+	assert.Equal(t, uint64(2), *verification.BrokenSeq)
+}
+
+func TestVerify_ModifiedEntry_Broken(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, mockAuditRepository := newAuditService(ctl)
+	entries := chain(1, 3)
+	entries[1].Status = 200
+	expectEntries(mockAuditRepository, time.Time{}, time.Time{}, entries)
+
+	verification, err := auditService.Verify(time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.False(t, verification.Valid)
+	assert.Equal(t, uint64(2), *verification.BrokenSeq)
+	assert.Equal(t, 1, verification.Entries)
+}
+
+func TestVerify_RemovedEntry_Broken(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, mockAuditRepository := newAuditService(ctl)
+	entries := chain(1, 3)
+	expectEntries(mockAuditRepository, time.Time{}, time.Time{}, []entity.AuditEntry{entries[0], entries[2]})
+
+	verification, err := auditService.Verify(time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.False(t, verification.Valid)
+	assert.Equal(t, uint64(3), *verification.BrokenSeq)
+}
+
+func TestVerify_RangeLinkedToPreviousEntry(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, mockAuditRepository := newAuditService(ctl)
+	entries := chain(1, 4)
+	start := entries[2].CreatedAt
+	expectEntries(mockAuditRepository, start, time.Time{}, entries[2:])
+	mockAuditRepository.
+		EXPECT().
+		GetEntry(uint64(1), uint64(2)).
+		Times(1).
+		Return(&entries[1], nil)
+
+	verification, err := auditService.Verify(start, time.Time{})
+
+	assert.NoError(t, err)
+	assert.True(t, verification.Valid)
+	assert.Equal(t, uint64(3), verification.Chains[1].FirstSeq)
+	assert.Equal(t, 2, verification.Entries)
+}
+
+func TestVerify_InvalidRange_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, _ := newAuditService(ctl)
+
+	_, err := auditService.Verify(base, base.Add(-time.Hour))
+
+	assert.ErrorIs(t, err, serviceerror.InvalidAuditRange)
+}
+
+func TestExport_JSONLines(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	auditService, mockAuditRepository := newAuditService(ctl)
+	entries := chain(1, 2)
+	end := base.Add(time.Hour)
+	expectEntries(mockAuditRepository, base, end, entries)
+
+	var out bytes.Buffer
+	err := auditService.Export(base, end, &out)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	var exported entity.AuditEntry
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &exported))
+	assert.Equal(t, entries[1].Hash, exported.ChainHash())
+}
@@ -0,0 +1,5 @@
+package budget
+
+func ptr[T any](t T) *T {
+	return &t
+}
@@ -0,0 +1,353 @@
+package budget
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func monthlyBudget(rollover entity.BudgetRollover) entity.Budget {
+	return entity.Budget{
+		Id:         1,
+		UserId:     userId,
+		CategoryId: categoryId,
+		Amount:     decimal.RequireFromString("100"),
+		Currency:   "EUR",
+		Period:     entity.Monthly,
+		StartDate:  day(2024, 1, 1),
+		Rollover:   rollover,
+	}
+}
+
+func spendingTotal(start, end time.Time, amount string) entity.SpendingTotal {
+	return entity.SpendingTotal{
+		UserId:      userId,
+		CategoryId:  categoryId,
+		Currency:    "EUR",
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Amount:      decimal.RequireFromString(amount),
+	}
+}
+
+func expectCategories(mocks budgetMocks) {
+	mocks.category.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return([]entity.Category{{Id: categoryId, UserId: userId, Name: "Groceries", Type: entity.Expense}}, nil)
+}
+
+func TestGetBudgetStatus_SurplusRollover(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+	mocks.budget.EXPECT().GetBudgetsByUserId(userId).Times(1).
+		Return([]entity.Budget{monthlyBudget(entity.RolloverSurplus)}, nil)
+	mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 3, 1)).Times(1).
+		Return([]entity.SpendingTotal{
+			spendingTotal(day(2024, 1, 1), day(2024, 1, 16), "40"),
+			spendingTotal(day(2024, 1, 16), day(2024, 2, 1), "30"),
+			spendingTotal(day(2024, 2, 1), day(2024, 2, 2), "50"),
+		}, nil)
+
+	statuses, err := budgetService.GetBudgetStatus(model.BudgetStatusDTO{UserId: userId, Date: day(2024, 2, 10)})
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "Groceries", statuses[0].CategoryName)
+	assert.Equal(t, day(2024, 2, 1), statuses[0].PeriodStart)
+	assert.Equal(t, day(2024, 3, 1), statuses[0].PeriodEnd)
+	assert.True(t, decimal.RequireFromString("130").Equal(statuses[0].Limit))
+	assert.True(t, decimal.RequireFromString("50").Equal(statuses[0].Spent))
+	assert.True(t, decimal.RequireFromString("80").Equal(statuses[0].Remaining))
+	assert.True(t, decimal.RequireFromString("38.46").Equal(statuses[0].Percentage))
+}
+
+func TestGetBudgetStatus_FullRolloverCarriesOverspending(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+	fullBudget := monthlyBudget(entity.RolloverFull)
+	rolloverLimit := decimal.RequireFromString("20")
+	fullBudget.RolloverLimit = &rolloverLimit
+	mocks.budget.EXPECT().GetBudgetsByUserId(userId).Times(1).Return([]entity.Budget{fullBudget}, nil)
+	mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 3, 1)).Times(1).
+		Return([]entity.SpendingTotal{
+			spendingTotal(day(2024, 1, 1), day(2024, 2, 1), "150"),
+			spendingTotal(day(2024, 2, 1), day(2024, 3, 1), "40"),
+		}, nil)
+
+	statuses, err := budgetService.GetBudgetStatus(model.BudgetStatusDTO{UserId: userId, Date: day(2024, 2, 29)})
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.True(t, decimal.RequireFromString("80").Equal(statuses[0].Limit))
+	assert.True(t, decimal.RequireFromString("40").Equal(statuses[0].Remaining))
+	assert.True(t, decimal.RequireFromString("50").Equal(statuses[0].Percentage))
+}
+
+func TestGetBudgetStatus_MonthEndClamped(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+	monthEndBudget := monthlyBudget(entity.RolloverNone)
+	monthEndBudget.StartDate = day(2024, 1, 31)
+	mocks.budget.EXPECT().GetBudgetsByUserId(userId).Times(1).Return([]entity.Budget{monthEndBudget}, nil)
+	mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 31), day(2024, 3, 31)).Times(1).Return(nil, nil)
+
+	statuses, err := budgetService.GetBudgetStatus(model.BudgetStatusDTO{UserId: userId, Date: day(2024, 3, 1)})
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, day(2024, 2, 29), statuses[0].PeriodStart)
+	assert.Equal(t, day(2024, 3, 31), statuses[0].PeriodEnd)
+	assert.True(t, decimal.Zero.Equal(statuses[0].Percentage))
+}
+
+func TestGetBudgetStatus_NoLimitLeft(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		spent      string
+		percentage string
+	}{
+		{name: "nothing spent", spent: "0", percentage: "0"},
+		{name: "spent", spent: "10", percentage: "100"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			budgetService, mocks := newBudgetServiceWithMocks(ctl)
+			expectCategories(mocks)
+			mocks.budget.EXPECT().GetBudgetsByUserId(userId).Times(1).
+				Return([]entity.Budget{monthlyBudget(entity.RolloverFull)}, nil)
+			mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 3, 1)).Times(1).
+				Return([]entity.SpendingTotal{
+					spendingTotal(day(2024, 1, 1), day(2024, 2, 1), "200"),
+					spendingTotal(day(2024, 2, 1), day(2024, 3, 1), tc.spent),
+				}, nil)
+
+			statuses, err := budgetService.GetBudgetStatus(model.BudgetStatusDTO{UserId: userId, Date: day(2024, 2, 1)})
+
+			assert.NoError(t, err)
+			assert.Len(t, statuses, 1)
+			assert.True(t, decimal.Zero.Equal(statuses[0].Limit))
+			assert.True(t, decimal.RequireFromString(tc.percentage).Equal(statuses[0].Percentage))
+		})
+	}
+}
+
+func TestGetBudgetStatus_BeforeStart_Empty(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+	mocks.budget.EXPECT().GetBudgetsByUserId(userId).Times(1).
+		Return([]entity.Budget{monthlyBudget(entity.RolloverNone)}, nil)
+
+	statuses, err := budgetService.GetBudgetStatus(model.BudgetStatusDTO{UserId: userId, Date: day(2023, 12, 31)})
+
+	assert.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestRecordSpending_ThresholdsCrossed(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+	mocks.budget.EXPECT().GetBudgetsByUserId(userId).Times(1).
+		Return([]entity.Budget{monthlyBudget(entity.RolloverNone)}, nil)
+
+	before := spendingTotal(day(2024, 1, 1), day(2024, 1, 2), "70")
+	after := spendingTotal(day(2024, 1, 2), day(2024, 1, 3), "35")
+	committed := false
+	gomock.InOrder(
+		mocks.spending.EXPECT().Transaction([]uint64{categoryId}, gomock.Any()).Times(1).
+			DoAndReturn(func(_ []uint64, fn func(repository.SpendingRepository) error) error {
+				err := fn(mocks.spending)
+				committed = true
+				return err
+			}),
+		mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 2, 1)).
+			Return([]entity.SpendingTotal{before}, nil),
+		mocks.spending.EXPECT().SaveTotals(gomock.Len(1)).Return(nil),
+		mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 2, 1)).
+			Return([]entity.SpendingTotal{before, after}, nil),
+	)
+
+	var thresholds []int
+	mocks.publisher.EXPECT().Publish(gomock.Any()).Times(2).Do(func(event model.Event) {
+		assert.True(t, committed, "published before the totals were committed")
+		assert.Equal(t, model.BudgetThresholdCrossed, event.Type)
+		thresholdEvent := event.Data.(model.BudgetThresholdEvent)
+		assert.True(t, decimal.RequireFromString("105").Equal(thresholdEvent.Status.Percentage))
+		thresholds = append(thresholds, thresholdEvent.Threshold)
+	})
+
+	err := budgetService.RecordSpending(model.SpendingRecordDTO{
+		UserId: userId,
+		Totals: []model.SpendingTotalDTO{{
+			CategoryId:  categoryId,
+			Currency:    "EUR",
+			PeriodStart: day(2024, 1, 2),
+			PeriodEnd:   day(2024, 1, 3),
+			Amount:      decimal.RequireFromString("35"),
+		}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{80, 100}, thresholds)
+}
+
+func TestRecordSpending_HouseholdCategory_MembersSpendingSummedAndNotified(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	const (
+		householdId = uint64(7)
+		memberId    = uint64(2)
+	)
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	mocks.category.EXPECT().GetCategoriesByUserId(memberId).Times(1).
+		Return([]entity.Category{{Id: categoryId, UserId: userId, HouseholdId: ptr(householdId), Name: "Groceries", Type: entity.Expense}}, nil)
+	mocks.budget.EXPECT().GetBudgetsByUserId(memberId).Times(1).
+		Return([]entity.Budget{monthlyBudget(entity.RolloverNone)}, nil)
+
+	ownerTotal := spendingTotal(day(2024, 1, 1), day(2024, 1, 2), "70")
+	memberTotal := spendingTotal(day(2024, 1, 2), day(2024, 1, 3), "15")
+	memberTotal.UserId = memberId
+	mocks.spending.EXPECT().Transaction([]uint64{categoryId}, gomock.Any()).Times(1).
+		DoAndReturn(func(_ []uint64, fn func(repository.SpendingRepository) error) error {
+			return fn(mocks.spending)
+		})
+	gomock.InOrder(
+		mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 2, 1)).
+			Return([]entity.SpendingTotal{ownerTotal}, nil),
+		mocks.spending.EXPECT().SaveTotals(gomock.Len(1)).
+			Do(func(totals []entity.SpendingTotal) {
+				assert.Equal(t, memberId, totals[0].UserId)
+			}).
+			Return(nil),
+		mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 2, 1)).
+			Return([]entity.SpendingTotal{ownerTotal, memberTotal}, nil),
+	)
+	mocks.household.EXPECT().GetMembers(householdId).Times(1).
+		Return([]entity.HouseholdMember{
+			{HouseholdId: householdId, UserId: userId, Role: entity.RoleOwner},
+			{HouseholdId: householdId, UserId: memberId, Role: entity.RoleEditor},
+		}, nil)
+
+	var recipients []uint64
+	mocks.publisher.EXPECT().Publish(gomock.Any()).Times(2).Do(func(event model.Event) {
+		thresholdEvent := event.Data.(model.BudgetThresholdEvent)
+		assert.Equal(t, 80, thresholdEvent.Threshold)
+		assert.True(t, decimal.RequireFromString("85").Equal(thresholdEvent.Status.Spent))
+		recipients = append(recipients, event.UserId)
+	})
+
+	err := budgetService.RecordSpending(model.SpendingRecordDTO{
+		UserId: memberId,
+		Totals: []model.SpendingTotalDTO{{
+			CategoryId:  categoryId,
+			Currency:    "EUR",
+			PeriodStart: day(2024, 1, 2),
+			PeriodEnd:   day(2024, 1, 3),
+			Amount:      decimal.RequireFromString("15"),
+		}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{userId, memberId}, recipients)
+}
+
+func TestRecordSpending_TransactionFailed_NothingPublished(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+	mocks.budget.EXPECT().GetBudgetsByUserId(userId).Times(1).
+		Return([]entity.Budget{monthlyBudget(entity.RolloverNone)}, nil)
+	mocks.spending.EXPECT().Transaction([]uint64{categoryId}, gomock.Any()).Times(1).
+		DoAndReturn(func(_ []uint64, fn func(repository.SpendingRepository) error) error {
+			return fn(mocks.spending)
+		})
+	mocks.spending.EXPECT().GetTotals([]uint64{categoryId}, day(2024, 1, 1), day(2024, 2, 1)).Times(1).Return(nil, nil)
+	mocks.spending.EXPECT().SaveTotals(gomock.Len(1)).Times(1).Return(assert.AnError)
+	mocks.publisher.EXPECT().Publish(gomock.Any()).Times(0)
+
+	err := budgetService.RecordSpending(model.SpendingRecordDTO{
+		UserId: userId,
+		Totals: []model.SpendingTotalDTO{{
+			CategoryId:  categoryId,
+			Currency:    "EUR",
+			PeriodStart: day(2024, 1, 2),
+			PeriodEnd:   day(2024, 1, 3),
+			Amount:      decimal.RequireFromString("135"),
+		}},
+	})
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestRecordSpending_InvalidPeriod(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+
+	err := budgetService.RecordSpending(model.SpendingRecordDTO{
+		UserId: userId,
+		Totals: []model.SpendingTotalDTO{{
+			CategoryId:  categoryId,
+			Currency:    "EUR",
+			PeriodStart: day(2024, 1, 2),
+			PeriodEnd:   day(2024, 1, 2),
+			Amount:      decimal.RequireFromString("35"),
+		}},
+	})
+
+	assert.ErrorIs(t, err, serviceerror.InvalidSpendingTotal)
+}
+
+func TestRecordSpending_ForeignCategory(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	expectCategories(mocks)
+
+	err := budgetService.RecordSpending(model.SpendingRecordDTO{
+		UserId: userId,
+		Totals: []model.SpendingTotalDTO{{
+			CategoryId:  categoryId + 1,
+			Currency:    "EUR",
+			PeriodStart: day(2024, 1, 2),
+			PeriodEnd:   day(2024, 1, 3),
+			Amount:      decimal.RequireFromString("35"),
+		}},
+	})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntExist)
+}
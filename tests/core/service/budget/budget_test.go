@@ -0,0 +1,279 @@
+package budget
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/budget"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+const (
+	userId     = uint64(1)
+	categoryId = uint64(10)
+)
+
+type budgetMocks struct {
+	budget    *mock.MockBudgetRepository
+	category  *mock.MockCategoryRepository
+	spending  *mock.MockSpendingRepository
+	household *mock.MockHouseholdRepository
+	publisher *eventmock.MockPublisher
+}
+
+func newBudgetServiceWithMocks(ctl *gomock.Controller) (service.BudgetService, budgetMocks) {
+	mocks := budgetMocks{
+		budget:    mock.NewMockBudgetRepository(ctl),
+		category:  mock.NewMockCategoryRepository(ctl),
+		spending:  mock.NewMockSpendingRepository(ctl),
+		household: mock.NewMockHouseholdRepository(ctl),
+		publisher: eventmock.NewMockPublisher(ctl),
+	}
+	mockManager := &repository.Manager{
+		Category:  mocks.category,
+		Budget:    mocks.budget,
+		Spending:  mocks.spending,
+		Household: mocks.household,
+	}
+	return budget.NewBudgetService(mockManager, mocks.publisher, config.DefaultBudgetConfig), mocks
+}
+
+func newBudgetService(ctl *gomock.Controller) (service.BudgetService, *mock.MockBudgetRepository, *mock.MockCategoryRepository) {
+	budgetService, mocks := newBudgetServiceWithMocks(ctl)
+	return budgetService, mocks.budget, mocks.category
+}
+
+func expectCategory(repo *mock.MockCategoryRepository, categoryType entity.CategoryType) {
+	repo.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: userId, Name: "Groceries", Type: categoryType}, nil)
+}
+
+func TestCreateBudget_Monthly_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mockBudgetRepository, mockCategoryRepository := newBudgetService(ctl)
+	expectCategory(mockCategoryRepository, entity.Expense)
+
+	startDate := time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC)
+	surplus := entity.RolloverSurplus
+	limit := decimal.RequireFromString("100")
+	expected := &entity.Budget{
+		UserId:        userId,
+		CategoryId:    categoryId,
+		Amount:        decimal.RequireFromString("400.50"),
+		Currency:      "EUR",
+		Period:        entity.Monthly,
+		StartDate:     time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		Rollover:      entity.RolloverSurplus,
+		RolloverLimit: &limit,
+	}
+	mockBudgetRepository.
+		EXPECT().
+		CreateBudget(expected).
+		Times(1).
+		Return(expected, nil)
+
+	created, err := budgetService.CreateBudget(model.BudgetCreateDTO{
+		UserId:        userId,
+		CategoryId:    categoryId,
+		Amount:        decimal.RequireFromString("400.50"),
+		Currency:      "EUR",
+		Period:        entity.Monthly,
+		StartDate:     &startDate,
+		Rollover:      &surplus,
+		RolloverLimit: &limit,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, created)
+}
+
+func TestCreateBudget_DefaultStartAndRollover(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mockBudgetRepository, mockCategoryRepository := newBudgetService(ctl)
+	expectCategory(mockCategoryRepository, entity.Expense)
+
+	mockBudgetRepository.
+		EXPECT().
+		CreateBudget(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(b *entity.Budget) (*entity.Budget, error) { return b, nil })
+
+	created, err := budgetService.CreateBudget(model.BudgetCreateDTO{
+		UserId:     userId,
+		CategoryId: categoryId,
+		Amount:     decimal.NewFromInt(50),
+		Currency:   "USD",
+		Period:     entity.Weekly,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RolloverNone, created.Rollover)
+	assert.Equal(t, time.Monday, created.StartDate.Weekday())
+	assert.Equal(t, budget.PeriodStart(entity.Weekly, time.Now()), created.StartDate)
+}
+
+func TestCreateBudget_IncomeCategory_Rejected(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, _, mockCategoryRepository := newBudgetService(ctl)
+	expectCategory(mockCategoryRepository, entity.Income)
+
+	_, err := budgetService.CreateBudget(model.BudgetCreateDTO{
+		UserId:     userId,
+		CategoryId: categoryId,
+		Amount:     decimal.NewFromInt(50),
+		Currency:   "USD",
+		Period:     entity.Monthly,
+	})
+
+	assert.ErrorIs(t, err, serviceerror.BudgetRequiresExpenseCategory)
+}
+
+func TestCreateBudget_Invalid(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, 0, -1)
+	negative := decimal.NewFromInt(-1)
+
+	tests := map[string]struct {
+		dto      model.BudgetCreateDTO
+		expected error
+	}{
+		"zero amount": {
+			dto:      model.BudgetCreateDTO{Amount: decimal.Zero, Period: entity.Monthly},
+			expected: serviceerror.InvalidBudgetAmount,
+		},
+		"negative rollover limit": {
+			dto:      model.BudgetCreateDTO{Amount: decimal.NewFromInt(1), Period: entity.Monthly, RolloverLimit: &negative},
+			expected: serviceerror.InvalidBudgetAmount,
+		},
+		"custom without start": {
+			dto:      model.BudgetCreateDTO{Amount: decimal.NewFromInt(1), Period: entity.Custom, EndDate: &start},
+			expected: serviceerror.InvalidBudgetPeriod,
+		},
+		"custom without end": {
+			dto:      model.BudgetCreateDTO{Amount: decimal.NewFromInt(1), Period: entity.Custom, StartDate: &start},
+			expected: serviceerror.InvalidBudgetPeriod,
+		},
+		"end before start": {
+			dto:      model.BudgetCreateDTO{Amount: decimal.NewFromInt(1), Period: entity.Custom, StartDate: &start, EndDate: &before},
+			expected: serviceerror.InvalidBudgetPeriod,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			budgetService, _, mockCategoryRepository := newBudgetService(ctl)
+			expectCategory(mockCategoryRepository, entity.Expense)
+
+			test.dto.UserId = userId
+			test.dto.CategoryId = categoryId
+			test.dto.Currency = "EUR"
+			_, err := budgetService.CreateBudget(test.dto)
+
+			assert.ErrorIs(t, err, test.expected)
+		})
+	}
+}
+
+func TestUpdateBudget_OfAnotherCategory_DoesntExist(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mockBudgetRepository, _ := newBudgetService(ctl)
+
+	mockBudgetRepository.
+		EXPECT().
+		GetBudgetById(uint64(5)).
+		Times(1).
+		Return(&entity.Budget{Id: 5, UserId: userId, CategoryId: 11}, nil)
+
+	amount := decimal.NewFromInt(10)
+	_, err := budgetService.UpdateBudget(model.BudgetUpdateDTO{Id: 5, UserId: userId, CategoryId: categoryId, Amount: &amount})
+
+	assert.ErrorIs(t, err, serviceerror.BudgetDoesntExist)
+}
+
+func TestDeleteBudget_DoesntBelongToUser(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mockBudgetRepository, mockCategoryRepository := newBudgetService(ctl)
+
+	mockBudgetRepository.
+		EXPECT().
+		GetBudgetById(uint64(5)).
+		Times(1).
+		Return(&entity.Budget{Id: 5, UserId: 2, CategoryId: categoryId}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: 2, Type: entity.Expense}, nil)
+
+	err := budgetService.DeleteBudget(model.BudgetDeleteDTO{Id: 5, UserId: userId, CategoryId: categoryId})
+
+	assert.ErrorIs(t, err, serviceerror.BudgetDoesntBelongToUser)
+}
+
+func TestDeleteBudget_HouseholdEditor_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	budgetService, mockBudgetRepository, mockCategoryRepository := newBudgetService(ctl)
+	householdId := uint64(3)
+
+	mockBudgetRepository.
+		EXPECT().
+		GetBudgetById(uint64(5)).
+		Times(1).
+		Return(&entity.Budget{Id: 5, UserId: 2, CategoryId: categoryId}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: 2, HouseholdId: &householdId, Type: entity.Expense}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(categoryId, userId, entity.RoleEditor).
+		Times(1).
+		Return(true)
+	mockBudgetRepository.
+		EXPECT().
+		DeleteBudget(uint64(5)).
+		Times(1).
+		Return(nil)
+
+	err := budgetService.DeleteBudget(model.BudgetDeleteDTO{Id: 5, UserId: userId, CategoryId: categoryId})
+
+	assert.NoError(t, err)
+}
+
+func TestPeriodStart(t *testing.T) {
+	// Sunday evening in UTC+3 is still Sunday in UTC
+	moment := time.Date(2024, 3, 17, 23, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	assert.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), budget.PeriodStart(entity.Weekly, moment))
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), budget.PeriodStart(entity.Monthly, moment))
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), budget.PeriodStart(entity.Yearly, moment))
+	assert.Equal(t, time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC), budget.PeriodStart(entity.Custom, moment))
+}
@@ -0,0 +1,201 @@
+package category
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestCreateCategory_Goal_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryCreateDTO := model.CategoryCreateDTO{
+		UserId:         1,
+		Name:           "Vacation fund",
+		Type:           entity.Goal,
+		TargetAmount:   ptr(decimal.RequireFromString("2000")),
+		TargetDate:     ptr(time.Date(2025, 6, 1, 15, 30, 0, 0, time.UTC)),
+		TargetCurrency: ptr("EUR"),
+	}
+
+	expectTransferCategoryExists(mockCategoryRepository)
+
+	mockCategoryRepository.
+		EXPECT().
+		CreateCategory(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+			category.Id = 1
+			return category, nil
+		})
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	createdCategory, err := categoryService.CreateCategory(categoryCreateDTO)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Goal, createdCategory.Type)
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), *createdCategory.TargetDate)
+	assert.Equal(t, "EUR", *createdCategory.TargetCurrency)
+}
+
+func TestCreateCategory_Goal_InvalidTarget(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	for name, categoryCreateDTO := range map[string]model.CategoryCreateDTO{
+		"missing date": {
+			UserId:         1,
+			Name:           "Vacation fund",
+			Type:           entity.Goal,
+			TargetAmount:   ptr(decimal.RequireFromString("2000")),
+			TargetCurrency: ptr("EUR"),
+		},
+		"zero amount": {
+			UserId:         1,
+			Name:           "Vacation fund",
+			Type:           entity.Goal,
+			TargetAmount:   ptr(decimal.Zero),
+			TargetDate:     ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+			TargetCurrency: ptr("EUR"),
+		},
+	} {
+		_, err := categoryService.CreateCategory(categoryCreateDTO)
+		assert.ErrorIs(t, err, serviceerror.InvalidGoalTarget, name)
+	}
+}
+
+func TestCreateCategory_Expense_TargetNotAllowed(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	_, err := categoryService.CreateCategory(model.CategoryCreateDTO{
+		UserId:       1,
+		Name:         "Groceries",
+		Type:         entity.Expense,
+		TargetAmount: ptr(decimal.RequireFromString("2000")),
+	})
+
+	assert.ErrorIs(t, err, serviceerror.GoalTargetNotAllowed)
+}
+
+func TestUpdateCategory_Goal_ChangesTarget(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	existingCategory := &entity.Category{
+		Id:             1,
+		UserId:         1,
+		Name:           "Vacation fund",
+		Type:           entity.Goal,
+		TargetAmount:   ptr(decimal.RequireFromString("2000")),
+		TargetDate:     ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+		TargetCurrency: ptr("EUR"),
+		Version:        1,
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(existingCategory.Id).
+		Times(1).
+		Return(existingCategory, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(gomock.Any(), existingCategory.Version, uint64(1)).
+		Times(1).
+		DoAndReturn(func(category *entity.Category, expectedVersion, _ uint64) (*entity.Category, error) {
+			return category, nil
+		})
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	updatedCategory, err := categoryService.UpdateCategory(model.CategoryUpdateDTO{
+		Id:           1,
+		UserId:       1,
+		TargetAmount: ptr(decimal.RequireFromString("2500")),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("2500").Equal(*updatedCategory.TargetAmount))
+}
+
+func TestUpdateCategory_Expense_TargetNotAllowed(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 1, Name: "Groceries", Type: entity.Expense, Version: 1}, nil)
+
+	_, err := categoryService.UpdateCategory(model.CategoryUpdateDTO{
+		Id:         1,
+		UserId:     1,
+		TargetDate: ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+	})
+
+	assert.ErrorIs(t, err, serviceerror.GoalTargetNotAllowed)
+}
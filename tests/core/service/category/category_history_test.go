@@ -0,0 +1,205 @@
+package category
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestRevertCategory_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, mockPublisher := newHouseholdCategoryService(ctl)
+
+	existingCategory := &entity.Category{Id: 1, UserId: 1, Name: "Food", Description: "Renamed", Type: entity.Expense, Version: 3}
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(existingCategory, nil)
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryVersion(uint64(1), uint64(1)).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 1, Name: "Groceries", Description: "Weekly", Type: entity.Expense, Version: 1}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		RevertCategory(gomock.Any(), uint64(3), uint64(1)).
+		Times(1).
+		DoAndReturn(func(category *entity.Category, expectedVersion, _ uint64) (*entity.Category, error) {
+			category.Version = expectedVersion + 1
+			return category, nil
+		})
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	revertedCategory, err := categoryService.RevertCategory(model.CategoryRevertDTO{Id: 1, UserId: 1, Version: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Groceries", revertedCategory.Name)
+	assert.Equal(t, "Weekly", revertedCategory.Description)
+	assert.Equal(t, uint64(4), revertedCategory.Version)
+}
+
+func TestRevertCategory_CurrentVersion_NothingWritten(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	existingCategory := &entity.Category{Id: 1, UserId: 1, Name: "Food", Type: entity.Expense, Version: 3}
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(existingCategory, nil)
+
+	revertedCategory, err := categoryService.RevertCategory(model.CategoryRevertDTO{Id: 1, UserId: 1, Version: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, existingCategory, revertedCategory)
+}
+
+func TestRevertCategory_VersionDoesntExist_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 1, Name: "Food", Type: entity.Expense, Version: 3}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryVersion(uint64(1), uint64(9)).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := categoryService.RevertCategory(model.CategoryRevertDTO{Id: 1, UserId: 1, Version: 9})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryVersionDoesntExist)
+}
+
+func TestRevertCategory_StaleVersion_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 1, Name: "Food", Type: entity.Expense, Version: 3}, nil)
+
+	_, err := categoryService.RevertCategory(model.CategoryRevertDTO{Id: 1, UserId: 1, Version: 1, ExpectedVersion: ptr(uint64(2))})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryVersionMismatch)
+}
+
+func TestRevertCategory_HouseholdViewer_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(5)).
+		Times(1).
+		Return(householdCategory(), nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleEditor).
+		Times(1).
+		Return(false)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleViewer).
+		Times(1).
+		Return(true)
+
+	_, err := categoryService.RevertCategory(model.CategoryRevertDTO{Id: 5, UserId: memberId, Version: 1})
+
+	assert.ErrorIs(t, err, serviceerror.HouseholdEditorRequired)
+}
+
+func TestGetCategoryHistory_DoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByIdUnscoped(uint64(1)).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 2, Name: "Food", Type: entity.Expense}, nil)
+
+	_, err := categoryService.GetCategoryHistory(1, 1)
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntBelongToUser)
+}
+
+func TestGetCategoryHistory_DeletedHouseholdCategory_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	deletedCategory := householdCategory()
+	deletedCategory.Version = 2
+	deletedCategory.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	history := []entity.CategoryHistory{
+		{CategoryId: 5, Version: 2, Action: entity.HistoryDelete, ActorId: 1},
+		{CategoryId: 5, Version: 1, Action: entity.HistoryCreate, ActorId: 1},
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByIdUnscoped(uint64(5)).
+		Times(1).
+		Return(deletedCategory, nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleViewer).
+		Times(1).
+		Return(true)
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryHistory(uint64(5)).
+		Times(1).
+		Return(history, nil)
+
+	actualHistory, err := categoryService.GetCategoryHistory(memberId, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, history, actualHistory)
+}
+
+func TestGetCategoryHistory_CategoryDoesntExist_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByIdUnscoped(uint64(1)).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := categoryService.GetCategoryHistory(1, 1)
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntExist)
+}
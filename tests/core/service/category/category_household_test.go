@@ -0,0 +1,297 @@
+package category
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+)
+
+const (
+	householdId = uint64(7)
+	memberId    = uint64(2)
+)
+
+func householdCategory() *entity.Category {
+	return &entity.Category{
+		Id:          5,
+		UserId:      1,
+		HouseholdId: ptr(householdId),
+		Name:        "Groceries",
+		Type:        entity.Expense,
+		Version:     1,
+	}
+}
+
+func newHouseholdCategoryService(ctl *gomock.Controller) (service.CategoryService, *mock.MockCategoryRepository, *mock.MockHouseholdRepository, *eventmock.MockPublisher) {
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockHouseholdRepository := mock.NewMockHouseholdRepository(ctl)
+	mockManager := &repository.Manager{
+		Category:  mockCategoryRepository,
+		Household: mockHouseholdRepository,
+	}
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	return category.NewCategoryService(mockManager, mockPublisher), mockCategoryRepository, mockHouseholdRepository, mockPublisher
+}
+
+func TestGetCategory_HouseholdViewer_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(5)).
+		Times(1).
+		Return(householdCategory(), nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleViewer).
+		Times(1).
+		Return(true)
+
+	result, err := categoryService.GetCategory(memberId, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, householdId, *result.HouseholdId)
+}
+
+func TestGetCategory_HouseholdNonMember_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(5)).
+		Times(1).
+		Return(householdCategory(), nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleViewer).
+		Times(1).
+		Return(false)
+
+	_, err := categoryService.GetCategory(memberId, 5)
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntBelongToUser)
+}
+
+func TestUpdateCategory_HouseholdViewer_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(5)).
+		Times(1).
+		Return(householdCategory(), nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleEditor).
+		Times(1).
+		Return(false)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleViewer).
+		Times(1).
+		Return(true)
+
+	_, err := categoryService.UpdateCategory(model.CategoryUpdateDTO{Id: 5, UserId: memberId, Name: ptr("Food")})
+
+	assert.ErrorIs(t, err, serviceerror.HouseholdEditorRequired)
+}
+
+func TestDeleteCategory_HouseholdEditor_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, mockHouseholdRepository, mockPublisher := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(5)).
+		Times(1).
+		Return(householdCategory(), nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleEditor).
+		Times(1).
+		Return(true)
+	mockCategoryRepository.
+		EXPECT().
+		DeleteCategory(uint64(5), uint64(0), memberId).
+		Times(1).
+		Return(nil)
+	mockHouseholdRepository.
+		EXPECT().
+		GetMembers(householdId).
+		Times(1).
+		Return(householdMembers(), nil)
+
+	var recipients []uint64
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(2).
+		Do(func(e model.Event) {
+			assert.Equal(t, model.CategoryDeleted, e.Type)
+			recipients = append(recipients, e.UserId)
+		})
+
+	err := categoryService.DeleteCategory(model.CategoryDeleteDTO{Id: 5, UserId: memberId})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{1, memberId}, recipients)
+}
+
+func TestUpdateCategory_Household_PublishesToEveryMember(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, mockHouseholdRepository, mockPublisher := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(5)).
+		Times(1).
+		Return(householdCategory(), nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleEditor).
+		Times(1).
+		Return(true)
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(gomock.Any(), uint64(1), memberId).
+		Times(1).
+		DoAndReturn(func(category *entity.Category, expectedVersion, _ uint64) (*entity.Category, error) {
+			category.Version = expectedVersion + 1
+			category.ChangeSeq = 12
+			return category, nil
+		})
+	mockHouseholdRepository.
+		EXPECT().
+		GetMembers(householdId).
+		Times(1).
+		Return(householdMembers(), nil)
+
+	var recipients []uint64
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(2).
+		Do(func(e model.Event) {
+			assert.Equal(t, model.CategoryUpdated, e.Type)
+			assert.Equal(t, uint64(12), e.Sequence)
+			recipients = append(recipients, e.UserId)
+		})
+
+	_, err := categoryService.UpdateCategory(model.CategoryUpdateDTO{Id: 5, UserId: memberId, Name: ptr("Food")})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{1, memberId}, recipients)
+}
+
+func householdMembers() []entity.HouseholdMember {
+	return []entity.HouseholdMember{
+		{HouseholdId: householdId, UserId: 1, Role: entity.RoleOwner},
+		{HouseholdId: householdId, UserId: memberId, Role: entity.RoleEditor},
+	}
+}
+
+func TestCreateCategory_Household_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, mockHouseholdRepository, mockPublisher := newHouseholdCategoryService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		GetMember(householdId, memberId).
+		Times(1).
+		Return(&entity.HouseholdMember{HouseholdId: householdId, UserId: memberId, Role: entity.RoleEditor}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		CreateCategory(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+			assert.Equal(t, householdId, *category.HouseholdId)
+			assert.Equal(t, memberId, category.UserId)
+			return category, nil
+		})
+	mockHouseholdRepository.
+		EXPECT().
+		GetMembers(householdId).
+		Times(1).
+		Return(householdMembers(), nil)
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(2)
+
+	_, err := categoryService.CreateCategory(model.CategoryCreateDTO{
+		UserId:      memberId,
+		HouseholdId: ptr(householdId),
+		Name:        "Groceries",
+		Type:        entity.Expense,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestCreateCategory_HouseholdViewer_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, _, mockHouseholdRepository, _ := newHouseholdCategoryService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		GetMember(householdId, memberId).
+		Times(1).
+		Return(&entity.HouseholdMember{HouseholdId: householdId, UserId: memberId, Role: entity.RoleViewer}, nil)
+
+	_, err := categoryService.CreateCategory(model.CategoryCreateDTO{
+		UserId:      memberId,
+		HouseholdId: ptr(householdId),
+		Name:        "Groceries",
+		Type:        entity.Expense,
+	})
+
+	assert.ErrorIs(t, err, serviceerror.HouseholdEditorRequired)
+}
+
+func TestCreateCategory_HouseholdNonMember_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, _, mockHouseholdRepository, _ := newHouseholdCategoryService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		GetMember(householdId, memberId).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := categoryService.CreateCategory(model.CategoryCreateDTO{
+		UserId:      memberId,
+		HouseholdId: ptr(householdId),
+		Name:        "Groceries",
+		Type:        entity.Expense,
+	})
+
+	assert.ErrorIs(t, err, serviceerror.NotHouseholdMember)
+}
@@ -0,0 +1,227 @@
+package category
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func existingPatchCategory() *entity.Category {
+	return &entity.Category{
+		Id:          1,
+		UserId:      1,
+		Name:        "Groceries",
+		Description: "Food and household",
+		Type:        "EXPENSE",
+		Version:     2,
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		CreatedSeq:  10,
+		ChangeSeq:   12,
+	}
+}
+
+func TestPatchCategory_MergePatch_ClearsDescription(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	existingCategory := existingPatchCategory()
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(existingCategory.Id).
+		Times(1).
+		Return(existingCategory, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(gomock.Any(), existingCategory.Version, uint64(1)).
+		Times(1).
+		DoAndReturn(func(category *entity.Category, expectedVersion, _ uint64) (*entity.Category, error) {
+			category.Version = expectedVersion + 1
+			return category, nil
+		})
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	patchedCategory, err := categoryService.PatchCategory(model.CategoryPatchDTO{
+		Id:     1,
+		UserId: 1,
+		Type:   model.MergePatch,
+		Patch:  []byte(`{"name":"Food","description":null}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Food", patchedCategory.Name)
+	assert.Equal(t, "", patchedCategory.Description)
+	assert.Equal(t, uint64(3), patchedCategory.Version)
+	assert.Equal(t, existingCategory.CreatedSeq, patchedCategory.CreatedSeq)
+}
+
+func TestPatchCategory_JSONPatch_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	existingCategory := existingPatchCategory()
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(existingCategory.Id).
+		Times(1).
+		Return(existingCategory, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(gomock.Any(), existingCategory.Version, uint64(1)).
+		Times(1).
+		DoAndReturn(func(category *entity.Category, expectedVersion, _ uint64) (*entity.Category, error) {
+			return category, nil
+		})
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	patchedCategory, err := categoryService.PatchCategory(model.CategoryPatchDTO{
+		Id:     1,
+		UserId: 1,
+		Type:   model.JSONPatch,
+		Patch: []byte(`[
+			{"op":"test","path":"/name","value":"Groceries"},
+			{"op":"replace","path":"/description","value":"Supermarkets"}
+		]`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Groceries", patchedCategory.Name)
+	assert.Equal(t, "Supermarkets", patchedCategory.Description)
+}
+
+func TestPatchCategory_ReadOnlyField_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	for _, patch := range []string{`{"userId":2}`, `{"version":null}`, `{"type":"INCOME"}`} {
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryById(uint64(1)).
+			Times(1).
+			Return(existingPatchCategory(), nil)
+
+		patchedCategory, err := categoryService.PatchCategory(model.CategoryPatchDTO{
+			Id:     1,
+			UserId: 1,
+			Type:   model.MergePatch,
+			Patch:  []byte(patch),
+		})
+
+		assert.Nil(t, patchedCategory)
+		assert.ErrorIs(t, err, serviceerror.ReadOnlyFieldChanged)
+	}
+}
+
+func TestPatchCategory_InvalidResult_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(existingPatchCategory(), nil)
+
+	patchedCategory, err := categoryService.PatchCategory(model.CategoryPatchDTO{
+		Id:     1,
+		UserId: 1,
+		Type:   model.MergePatch,
+		Patch:  []byte(`{"name":null}`),
+	})
+
+	var validationErrors validator.ValidationErrors
+	assert.Nil(t, patchedCategory)
+	assert.True(t, errors.As(err, &validationErrors))
+}
+
+func TestPatchCategory_InvalidPatch_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	patches := []model.CategoryPatchDTO{
+		{Id: 1, UserId: 1, Type: model.JSONPatch, Patch: []byte(`[{"op":"remove","path":"/missing"}]`)},
+		{Id: 1, UserId: 1, Type: model.JSONPatch, Patch: []byte(`[{"op":"test","path":"/name","value":"Rent"}]`)},
+		{Id: 1, UserId: 1, Type: model.MergePatch, Patch: []byte(`{"unknown":"field"}`)},
+		{Id: 1, UserId: 1, Type: model.MergePatch, Patch: []byte(`not json`)},
+	}
+
+	for _, patch := range patches {
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryById(uint64(1)).
+			Times(1).
+			Return(existingPatchCategory(), nil)
+
+		patchedCategory, err := categoryService.PatchCategory(patch)
+
+		assert.Nil(t, patchedCategory)
+		assert.ErrorIs(t, err, serviceerror.InvalidPatch, string(patch.Patch))
+	}
+}
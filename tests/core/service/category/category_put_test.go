@@ -0,0 +1,311 @@
+package category
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestPutCategory_NewExternalId_Created(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryPutDTO := model.CategoryPutDTO{
+		UserId:     1,
+		ExternalId: "bank-42",
+		Name:       "Groceries",
+		Type:       "EXPENSE",
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByExternalId(categoryPutDTO.UserId, categoryPutDTO.ExternalId).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	expectTransferCategoryExists(mockCategoryRepository)
+
+	mockCategoryRepository.
+		EXPECT().
+		CreateCategory(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+			assert.Equal(t, categoryPutDTO.ExternalId, *category.ExternalId)
+			category.Id = 1
+			return category, nil
+		})
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	putCategory, created, err := categoryService.PutCategory(categoryPutDTO)
+
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, categoryPutDTO.Name, putCategory.Name)
+}
+
+func TestPutCategory_ExistingExternalId_Replaced(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryPutDTO := model.CategoryPutDTO{
+		UserId:     1,
+		ExternalId: "bank-42",
+		Name:       "Food",
+		Type:       "EXPENSE",
+	}
+
+	existingCategory := &entity.Category{
+		Id:          1,
+		UserId:      1,
+		Name:        "Groceries",
+		Description: "Supermarkets",
+		Type:        "EXPENSE",
+		ExternalId:  ptr[string]("bank-42"),
+		Version:     4,
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByExternalId(categoryPutDTO.UserId, categoryPutDTO.ExternalId).
+		Times(1).
+		Return(existingCategory, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(existingCategory, uint64(4), uint64(1)).
+		Times(1).
+		Return(existingCategory, nil)
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	putCategory, created, err := categoryService.PutCategory(categoryPutDTO)
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "Food", putCategory.Name)
+	assert.Equal(t, "", putCategory.Description)
+}
+
+func TestPutCategory_CreatedConcurrently_Replaced(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryPutDTO := model.CategoryPutDTO{
+		UserId:     1,
+		ExternalId: "bank-42",
+		Name:       "Food",
+		Type:       "EXPENSE",
+	}
+
+	concurrentCategory := &entity.Category{Id: 1, UserId: 1, Name: "Groceries", Type: "EXPENSE", Version: 1}
+
+	expectTransaction(mockCategoryRepository)
+
+	gomock.InOrder(
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryByExternalId(categoryPutDTO.UserId, categoryPutDTO.ExternalId).
+			Return(nil, gorm.ErrRecordNotFound),
+		mockCategoryRepository.
+			EXPECT().
+			CreateCategory(gomock.Any()).
+			Return(nil, serviceerror.CategoryExternalIdExists),
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryByExternalId(categoryPutDTO.UserId, categoryPutDTO.ExternalId).
+			Return(concurrentCategory, nil),
+		mockCategoryRepository.
+			EXPECT().
+			UpdateCategory(concurrentCategory, uint64(1), uint64(1)).
+			Return(concurrentCategory, nil),
+	)
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	putCategory, created, err := categoryService.PutCategory(categoryPutDTO)
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "Food", putCategory.Name)
+}
+
+func TestPutCategory_TypeChanged_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryPutDTO := model.CategoryPutDTO{
+		UserId:     1,
+		ExternalId: "bank-42",
+		Name:       "Salary",
+		Type:       "INCOME",
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByExternalId(categoryPutDTO.UserId, categoryPutDTO.ExternalId).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 1, Name: "Groceries", Type: "EXPENSE", Version: 1}, nil)
+
+	putCategory, created, err := categoryService.PutCategory(categoryPutDTO)
+
+	assert.Nil(t, putCategory)
+	assert.False(t, created)
+	assert.ErrorIs(t, err, serviceerror.ReadOnlyFieldChanged)
+}
+
+func householdPutDTO(userId uint64) model.CategoryPutDTO {
+	return model.CategoryPutDTO{
+		UserId:     userId,
+		ExternalId: "bank-42",
+		Name:       "Food",
+		Type:       entity.Expense,
+	}
+}
+
+func TestPutCategory_HouseholdEditor_Replaced(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, mockHouseholdRepository, mockPublisher := newHouseholdCategoryService(ctl)
+
+	existingCategory := householdCategory()
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByExternalId(memberId, "bank-42").
+		Times(1).
+		Return(existingCategory, nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleEditor).
+		Times(1).
+		Return(true)
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(existingCategory, uint64(1), memberId).
+		Times(1).
+		Return(existingCategory, nil)
+	mockHouseholdRepository.
+		EXPECT().
+		GetMembers(householdId).
+		Times(1).
+		Return(householdMembers(), nil)
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(len(householdMembers()))
+
+	putCategory, created, err := categoryService.PutCategory(householdPutDTO(memberId))
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "Food", putCategory.Name)
+}
+
+func TestPutCategory_HouseholdViewer_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryByExternalId(memberId, "bank-42").
+		Times(1).
+		Return(householdCategory(), nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleEditor).
+		Times(1).
+		Return(false)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(uint64(5), memberId, entity.RoleViewer).
+		Times(1).
+		Return(true)
+
+	_, _, err := categoryService.PutCategory(householdPutDTO(memberId))
+
+	assert.ErrorIs(t, err, serviceerror.HouseholdEditorRequired)
+}
+
+func TestPutCategory_AuthorRemovedFromHousehold_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	categoryService, mockCategoryRepository, _, _ := newHouseholdCategoryService(ctl)
+
+	authorId := householdCategory().UserId
+	expectTransaction(mockCategoryRepository)
+	gomock.InOrder(
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryByExternalId(authorId, "bank-42").
+			Return(nil, gorm.ErrRecordNotFound),
+		mockCategoryRepository.
+			EXPECT().
+			CreateCategory(gomock.Any()).
+			Return(nil, serviceerror.CategoryExternalIdExists),
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryByExternalId(authorId, "bank-42").
+			Return(nil, gorm.ErrRecordNotFound),
+	)
+
+	_, _, err := categoryService.PutCategory(householdPutDTO(authorId))
+
+	assert.ErrorIs(t, err, serviceerror.CategoryExternalIdExists)
+}
@@ -0,0 +1,90 @@
+package category
+
+import (
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+var suggestCategories = []entity.Category{
+	{Id: 1, UserId: 1, Name: "Groceries", Type: entity.Expense},
+	{Id: 2, UserId: 1, Name: "Eating out", Type: entity.Expense},
+	{Id: 3, UserId: 1, Name: "Salary", Type: entity.Income},
+}
+
+func TestSuggestCategories_RanksCategoriesAndTemplates(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	categoryService := category.NewCategoryService(&repository.Manager{Category: mockCategoryRepository}, eventmock.NewMockPublisher(ctl))
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(uint64(1)).
+		Times(2).
+		Return(suggestCategories, nil)
+
+	suggestions, err := categoryService.SuggestCategories(1, "grocery", 0)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, suggestions)
+	assert.Equal(t, "Groceries", suggestions[0].Name)
+	assert.Equal(t, model.SuggestionCategory, suggestions[0].Source)
+	assert.Equal(t, uint64(1), *suggestions[0].CategoryId)
+	assert.Equal(t, 1.0, suggestions[0].Score)
+	for _, suggestion := range suggestions {
+		// user already has Groceries, its template isn't suggested again
+		assert.False(t, suggestion.Source == model.SuggestionTemplate && suggestion.Name == "Groceries")
+	}
+
+	suggestions, err = categoryService.SuggestCategories(1, "rest", 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "Restaurants", suggestions[0].Name)
+	assert.Equal(t, model.SuggestionTemplate, suggestions[0].Source)
+	assert.Nil(t, suggestions[0].CategoryId)
+}
+
+func TestSimilarCategories_NearDuplicates(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	categoryService := category.NewCategoryService(&repository.Manager{Category: mockCategoryRepository}, eventmock.NewMockPublisher(ctl))
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(uint64(1)).
+		AnyTimes().
+		Return(suggestCategories, nil)
+
+	tests := map[string]string{
+		"grocery":       "Groceries",
+		"Grocerys":      "Groceries",
+		"  GROCERIES! ": "Groceries",
+		"Out eating":    "Eating out",
+		"Salaries":      "Salary",
+		"Salarry":       "Salary",
+	}
+	for name, expected := range tests {
+		similar, err := categoryService.SimilarCategories(1, name)
+		assert.NoError(t, err)
+		if assert.Len(t, similar, 1, name) {
+			assert.Equal(t, expected, similar[0].Name, name)
+		}
+	}
+
+	for _, name := range []string{"Rent", "Gifts", "Eating", "Transport"} {
+		similar, err := categoryService.SimilarCategories(1, name)
+		assert.NoError(t, err)
+		assert.Empty(t, similar, name)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	serviceerror "github.com/khivuksergey/portmonetka.category/error"
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
 	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
 	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
 	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
 	"github.com/khivuksergey/portmonetka.category/internal/model"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 	"testing"
 )
 
@@ -23,7 +25,9 @@ func TestGetCategoriesByUserId_Success(t *testing.T) {
 		Category: mockCategoryRepository,
 	}
 
-	categoryService := category.NewCategoryService(mockManager)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
 
 	userId := uint64(1)
 	expectedCategories := []entity.Category{
@@ -41,6 +45,13 @@ func TestGetCategoriesByUserId_Success(t *testing.T) {
 			Description: "Description 2",
 			Type:        "EXPENSE",
 		},
+		{
+			Id:     3,
+			UserId: userId,
+			Name:   "Transfers",
+			Type:   "TRANSFER",
+			System: true,
+		},
 	}
 
 	mockCategoryRepository.
@@ -56,6 +67,83 @@ func TestGetCategoriesByUserId_Success(t *testing.T) {
 	assert.Equal(t, expectedCategories, actualCategories)
 }
 
+func TestGetCategory_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	expectedCategory := &entity.Category{Id: 1, UserId: 1, Name: "Test Category", Type: "INCOME", Version: 3}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(expectedCategory.Id).
+		Times(1).
+		Return(expectedCategory, nil)
+
+	actualCategory, err := categoryService.GetCategory(1, expectedCategory.Id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCategory, actualCategory)
+}
+
+func TestGetCategory_CategoryNotFound(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	actualCategory, err := categoryService.GetCategory(1, 1)
+
+	assert.Nil(t, actualCategory)
+	assert.Equal(t, serviceerror.CategoryDoesntExist, err)
+}
+
+func TestGetCategory_CategoryDoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(1)).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 2, Name: "Test Category"}, nil)
+
+	actualCategory, err := categoryService.GetCategory(1, 1)
+
+	assert.Nil(t, actualCategory)
+	assert.Equal(t, serviceerror.CategoryDoesntBelongToUser, err)
+}
+
 func TestCreateCategory_Success(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
@@ -65,7 +153,9 @@ func TestCreateCategory_Success(t *testing.T) {
 		Category: mockCategoryRepository,
 	}
 
-	categoryService := category.NewCategoryService(mockManager)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
 
 	categoryCreateDTO := &model.CategoryCreateDTO{
 		UserId:      1,
@@ -81,11 +171,7 @@ func TestCreateCategory_Success(t *testing.T) {
 		Type:        categoryCreateDTO.Type,
 	}
 
-	mockCategoryRepository.
-		EXPECT().
-		ExistsWithName(categoryCreateDTO.UserId, categoryCreateDTO.Name).
-		Times(1).
-		Return(false)
+	expectTransferCategoryExists(mockCategoryRepository)
 
 	mockCategoryRepository.
 		EXPECT().
@@ -93,6 +179,11 @@ func TestCreateCategory_Success(t *testing.T) {
 		Times(1).
 		Return(expectedCategory, nil)
 
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
 	createdCategory, err := categoryService.CreateCategory(*categoryCreateDTO)
 
 	assert.NoError(t, err)
@@ -109,7 +200,9 @@ func TestCreateCategory_DuplicateName_Error(t *testing.T) {
 		Category: mockCategoryRepository,
 	}
 
-	categoryService := category.NewCategoryService(mockManager)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
 
 	categoryCreateDTO := &model.CategoryCreateDTO{
 		UserId:      1,
@@ -118,11 +211,13 @@ func TestCreateCategory_DuplicateName_Error(t *testing.T) {
 		Type:        "expense",
 	}
 
+	expectTransaction(mockCategoryRepository)
+
 	mockCategoryRepository.
 		EXPECT().
-		ExistsWithName(categoryCreateDTO.UserId, categoryCreateDTO.Name).
+		CreateCategory(gomock.Any()).
 		Times(1).
-		Return(true)
+		Return(nil, serviceerror.CategoryAlreadyExists)
 
 	createdCategory, err := categoryService.CreateCategory(*categoryCreateDTO)
 
@@ -131,6 +226,45 @@ func TestCreateCategory_DuplicateName_Error(t *testing.T) {
 	assert.Equal(t, serviceerror.CategoryAlreadyExists, err)
 }
 
+func TestUpdateCategory_DuplicateName_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryUpdateDTO := &model.CategoryUpdateDTO{
+		Id:     1,
+		UserId: 1,
+		Name:   ptr[string]("Duplicate category"),
+	}
+
+	existingCategory := &entity.Category{Id: 1, UserId: 1, Name: "Category name", Version: 1}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryUpdateDTO.Id).
+		Times(1).
+		Return(existingCategory, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(existingCategory, existingCategory.Version, uint64(1)).
+		Times(1).
+		Return(nil, serviceerror.CategoryAlreadyExists)
+
+	updatedCategory, err := categoryService.UpdateCategory(*categoryUpdateDTO)
+
+	assert.Nil(t, updatedCategory)
+	assert.Equal(t, serviceerror.CategoryAlreadyExists, err)
+}
+
 func TestUpdateCategory_Success(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
@@ -140,7 +274,9 @@ func TestUpdateCategory_Success(t *testing.T) {
 		Category: mockCategoryRepository,
 	}
 
-	categoryService := category.NewCategoryService(mockManager)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
 
 	categoryUpdateDTO := &model.CategoryUpdateDTO{
 		Id:          1,
@@ -155,6 +291,7 @@ func TestUpdateCategory_Success(t *testing.T) {
 		Name:        "Old category name",
 		Description: "Old description",
 		Type:        "INCOME",
+		Version:     1,
 	}
 
 	updatedCategory := &entity.Category{
@@ -163,6 +300,7 @@ func TestUpdateCategory_Success(t *testing.T) {
 		Name:        "Updated category name",
 		Description: "Updated description",
 		Type:        "INCOME",
+		Version:     2,
 	}
 
 	mockCategoryRepository.
@@ -173,20 +311,18 @@ func TestUpdateCategory_Success(t *testing.T) {
 
 	mockCategoryRepository.
 		EXPECT().
-		ExistsWithName(existingCategory.UserId, *categoryUpdateDTO.Name).
+		UpdateCategory(existingCategory, existingCategory.Version, uint64(1)).
 		Times(1).
-		Return(false)
-
-	mockCategoryRepository.
-		EXPECT().
-		UpdateCategory(existingCategory).
-		Times(1).
-		DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
-			category.Name = *categoryUpdateDTO.Name
-			category.Description = *categoryUpdateDTO.Description
+		DoAndReturn(func(category *entity.Category, expectedVersion, _ uint64) (*entity.Category, error) {
+			category.Version = expectedVersion + 1
 			return category, nil
 		})
 
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
 	updatedCategoryFromService, err := categoryService.UpdateCategory(*categoryUpdateDTO)
 
 	assert.NoError(t, err)
@@ -203,7 +339,9 @@ func TestUpdateCategory_CategoryNotFound_Error(t *testing.T) {
 		Category: mockCategoryRepository,
 	}
 
-	categoryService := category.NewCategoryService(mockManager)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
 
 	categoryUpdateDTO := &model.CategoryUpdateDTO{
 		Id:          1,
@@ -224,6 +362,108 @@ func TestUpdateCategory_CategoryNotFound_Error(t *testing.T) {
 	assert.Equal(t, serviceerror.CategoryDoesntExist, err)
 }
 
+func TestUpdateCategory_StaleVersion_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryUpdateDTO := &model.CategoryUpdateDTO{
+		Id:      1,
+		UserId:  1,
+		Name:    ptr[string]("Updated category name"),
+		Version: ptr[uint64](1),
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryUpdateDTO.Id).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 1, Name: "Category name", Version: 2}, nil)
+
+	updatedCategory, err := categoryService.UpdateCategory(*categoryUpdateDTO)
+
+	assert.Nil(t, updatedCategory)
+	assert.Equal(t, serviceerror.CategoryVersionMismatch, err)
+}
+
+func TestUpdateCategory_ConcurrentModification_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryUpdateDTO := &model.CategoryUpdateDTO{
+		Id:          1,
+		UserId:      1,
+		Description: ptr[string]("Updated description"),
+	}
+
+	existingCategory := &entity.Category{Id: 1, UserId: 1, Name: "Category name", Version: 3}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryUpdateDTO.Id).
+		Times(1).
+		Return(existingCategory, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(existingCategory, uint64(3), uint64(1)).
+		Times(1).
+		Return(nil, serviceerror.CategoryVersionMismatch)
+
+	updatedCategory, err := categoryService.UpdateCategory(*categoryUpdateDTO)
+
+	assert.Nil(t, updatedCategory)
+	assert.Equal(t, serviceerror.CategoryVersionMismatch, err)
+}
+
+func TestUpdateCategory_CategoryDoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryUpdateDTO := &model.CategoryUpdateDTO{
+		Id:     1,
+		UserId: 2,
+		Name:   ptr[string]("Updated category name"),
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryUpdateDTO.Id).
+		Times(1).
+		Return(&entity.Category{Id: 1, UserId: 1, Name: "Category name", Version: 1}, nil)
+
+	updatedCategory, err := categoryService.UpdateCategory(*categoryUpdateDTO)
+
+	assert.Nil(t, updatedCategory)
+	assert.Equal(t, serviceerror.CategoryDoesntBelongToUser, err)
+}
+
 func TestDeleteCategory_Success(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
@@ -233,7 +473,9 @@ func TestDeleteCategory_Success(t *testing.T) {
 		Category: mockCategoryRepository,
 	}
 
-	categoryService := category.NewCategoryService(mockManager)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
 
 	categoryDeleteDTO := &model.CategoryDeleteDTO{
 		Id: 1,
@@ -241,16 +483,21 @@ func TestDeleteCategory_Success(t *testing.T) {
 
 	mockCategoryRepository.
 		EXPECT().
-		CategoryBelongsToUser(categoryDeleteDTO.Id, categoryDeleteDTO.UserId).
+		GetCategoryById(categoryDeleteDTO.Id).
 		Times(1).
-		Return(true)
+		Return(&entity.Category{Id: categoryDeleteDTO.Id, UserId: categoryDeleteDTO.UserId, Name: "Groceries", Type: entity.Expense}, nil)
 
 	mockCategoryRepository.
 		EXPECT().
-		DeleteCategory(categoryDeleteDTO.Id).
+		DeleteCategory(categoryDeleteDTO.Id, uint64(0), categoryDeleteDTO.UserId).
 		Times(1).
 		Return(nil)
 
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
 	err := categoryService.DeleteCategory(*categoryDeleteDTO)
 
 	assert.NoError(t, err)
@@ -265,7 +512,9 @@ func TestDeleteCategory_CategoryDoesntBelongToUser_Error(t *testing.T) {
 		Category: mockCategoryRepository,
 	}
 
-	categoryService := category.NewCategoryService(mockManager)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
 
 	categoryDeleteDTO := &model.CategoryDeleteDTO{
 		Id:     1,
@@ -274,12 +523,78 @@ func TestDeleteCategory_CategoryDoesntBelongToUser_Error(t *testing.T) {
 
 	mockCategoryRepository.
 		EXPECT().
-		CategoryBelongsToUser(categoryDeleteDTO.Id, categoryDeleteDTO.UserId).
+		GetCategoryById(categoryDeleteDTO.Id).
 		Times(1).
-		Return(false)
+		Return(&entity.Category{Id: categoryDeleteDTO.Id, UserId: categoryDeleteDTO.UserId + 1, Name: "Groceries", Type: entity.Expense}, nil)
 
 	err := categoryService.DeleteCategory(*categoryDeleteDTO)
 
 	assert.Error(t, err)
 	assert.Equal(t, serviceerror.CategoryDoesntBelongToUser, err)
 }
+
+func TestDeleteCategory_StaleVersion_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	categoryDeleteDTO := &model.CategoryDeleteDTO{
+		Id:      1,
+		UserId:  1,
+		Version: ptr[uint64](4),
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryDeleteDTO.Id).
+		Times(1).
+		Return(&entity.Category{Id: categoryDeleteDTO.Id, UserId: categoryDeleteDTO.UserId, Name: "Groceries", Type: entity.Expense}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		DeleteCategory(categoryDeleteDTO.Id, uint64(4), categoryDeleteDTO.UserId).
+		Times(1).
+		Return(serviceerror.CategoryVersionMismatch)
+
+	err := categoryService.DeleteCategory(*categoryDeleteDTO)
+
+	assert.Equal(t, serviceerror.CategoryVersionMismatch, err)
+}
+
+func TestGetCategoriesByAccountId_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	userId := uint64(1)
+	expectedCategories := []entity.Category{
+		{Id: 1, UserId: userId, Name: "Office", Type: entity.Expense},
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByAccountId(userId, "business").
+		Times(1).
+		Return(expectedCategories, nil)
+
+	result, err := categoryService.GetCategoriesByAccountId(userId, "business")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCategories, result)
+}
@@ -0,0 +1,310 @@
+package category
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/category"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func transferCategory() *entity.Category {
+	return &entity.Category{
+		Id:      3,
+		UserId:  1,
+		Name:    entity.TransferCategoryName,
+		Type:    entity.Transfer,
+		System:  true,
+		Version: 1,
+	}
+}
+
+// expectTransaction expects the transaction creating a personal category.
+func expectTransaction(mockCategoryRepository *mock.MockCategoryRepository) {
+	mockCategoryRepository.
+		EXPECT().
+		Transaction(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(fn func(categoryRepository repository.CategoryRepository) error) error {
+			return fn(mockCategoryRepository)
+		})
+}
+
+// expectTransferCategoryExists expects the transaction creating a personal category of the user having the transfer one.
+func expectTransferCategoryExists(mockCategoryRepository *mock.MockCategoryRepository) {
+	expectTransaction(mockCategoryRepository)
+	mockCategoryRepository.
+		EXPECT().
+		HasTransferCategory(uint64(1)).
+		Times(1).
+		Return(true, nil)
+}
+
+func TestCreateCategory_FirstPersonal_CreatesTransferCategory(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	userId := uint64(1)
+	expectTransaction(mockCategoryRepository)
+	gomock.InOrder(
+		mockCategoryRepository.
+			EXPECT().
+			CreateCategory(gomock.Any()).
+			DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+				assert.Equal(t, "Transfers", category.Name)
+				category.Id = 1
+				return category, nil
+			}),
+		mockCategoryRepository.
+			EXPECT().
+			HasTransferCategory(userId).
+			Return(false, nil),
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoriesByUserId(userId).
+			Return([]entity.Category{
+				{Id: 1, UserId: userId, Name: "Transfers", Type: entity.Expense},
+				{Id: 2, UserId: userId, Name: "transfers 2", Type: entity.Expense},
+			}, nil),
+		mockCategoryRepository.
+			EXPECT().
+			CreateCategory(gomock.Any()).
+			DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+				assert.Equal(t, "Transfers 3", category.Name)
+				assert.Equal(t, entity.Transfer, category.Type)
+				assert.True(t, category.System)
+				category.Id = 3
+				return category, nil
+			}),
+	)
+
+	var created []string
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(2).
+		Do(func(event model.Event) {
+			assert.Equal(t, model.CategoryCreated, event.Type)
+			created = append(created, event.Data.(*entity.Category).Name)
+		})
+
+	createdCategory, err := categoryService.CreateCategory(model.CategoryCreateDTO{
+		UserId: userId,
+		Name:   "Transfers",
+		Type:   entity.Expense,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Transfers", createdCategory.Name)
+	assert.Equal(t, []string{"Transfers 3", "Transfers"}, created)
+}
+
+func TestCreateCategory_TransferCreatedConcurrently(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	userId := uint64(1)
+	expectTransaction(mockCategoryRepository)
+	gomock.InOrder(
+		mockCategoryRepository.
+			EXPECT().
+			CreateCategory(gomock.Any()).
+			DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+				category.Id = 4
+				return category, nil
+			}),
+		mockCategoryRepository.
+			EXPECT().
+			HasTransferCategory(userId).
+			Return(false, nil),
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoriesByUserId(userId).
+			Return([]entity.Category{{Id: 4, UserId: userId, Name: "Groceries", Type: entity.Expense}}, nil),
+		mockCategoryRepository.
+			EXPECT().
+			CreateCategory(gomock.Any()).
+			Return(nil, serviceerror.TransferCategoryExists),
+	)
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	createdCategory, err := categoryService.CreateCategory(model.CategoryCreateDTO{
+		UserId: userId,
+		Name:   "Groceries",
+		Type:   entity.Expense,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), createdCategory.Id)
+}
+
+func TestCreateCategory_TransferFailed_NothingPublished(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	expectTransaction(mockCategoryRepository)
+	mockCategoryRepository.
+		EXPECT().
+		CreateCategory(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+			return category, nil
+		})
+	mockCategoryRepository.
+		EXPECT().
+		HasTransferCategory(uint64(1)).
+		Times(1).
+		Return(false, assert.AnError)
+
+	_, err := categoryService.CreateCategory(model.CategoryCreateDTO{
+		UserId: 1,
+		Name:   "Groceries",
+		Type:   entity.Expense,
+	})
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGetCategoriesByUserId_NoTransferCategory_NothingCreated(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(uint64(1)).
+		Times(1).
+		Return([]entity.Category{}, nil)
+
+	categories, err := categoryService.GetCategoriesByUserId(1)
+
+	assert.NoError(t, err)
+	assert.Empty(t, categories)
+}
+
+func TestDeleteCategory_SystemCategory_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(3)).
+		Times(1).
+		Return(transferCategory(), nil)
+
+	err := categoryService.DeleteCategory(model.CategoryDeleteDTO{Id: 3, UserId: 1})
+
+	assert.ErrorIs(t, err, serviceerror.SystemCategoryCantBeDeleted)
+}
+
+func TestUpdateCategory_SystemCategory_RenameIntoDuplicate(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(3)).
+		Times(1).
+		Return(transferCategory(), nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(uint64(1)).
+		Times(1).
+		Return([]entity.Category{*transferCategory(), {Id: 1, UserId: 1, Name: "Moves", Type: entity.Expense}}, nil)
+
+	_, err := categoryService.UpdateCategory(model.CategoryUpdateDTO{Id: 3, UserId: 1, Name: ptr("MOVES")})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryAlreadyExists)
+}
+
+func TestPatchCategory_SystemFlag_ReadOnly(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+	}
+
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+
+	categoryService := category.NewCategoryService(mockManager, mockPublisher)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(3)).
+		Times(1).
+		Return(transferCategory(), nil)
+
+	_, err := categoryService.PatchCategory(model.CategoryPatchDTO{
+		Id:     3,
+		UserId: 1,
+		Type:   model.MergePatch,
+		Patch:  []byte(`{"system": false}`),
+	})
+
+	assert.ErrorIs(t, err, serviceerror.ReadOnlyFieldChanged)
+}
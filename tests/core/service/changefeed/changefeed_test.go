@@ -0,0 +1,322 @@
+package changefeed
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/changefeed"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+var testConfig = config.ChangeFeedConfig{
+	Retention: time.Hour,
+	PageSize:  10,
+}
+
+func TestGetChanges_WithoutToken_ReturnsLiveCategories(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	changeFeedService := changefeed.NewChangeFeedService(&repository.Manager{Category: mockCategoryRepository}, testConfig)
+
+	userId := uint64(1)
+	live := entity.Category{Id: 1, UserId: userId, Name: "Food", CreatedSeq: 1, ChangeSeq: 3}
+	deleted := entity.Category{
+		Id:         2,
+		UserId:     userId,
+		Name:       "Taxi",
+		CreatedSeq: 2,
+		ChangeSeq:  4,
+		DeletedAt:  gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(0), uint64(0), testConfig.PageSize+1).
+		Times(1).
+		Return(feed(live, deleted), nil)
+
+	changes, err := changeFeedService.GetChanges(userId, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Category{live}, changes.Created)
+	assert.Empty(t, changes.Updated)
+	assert.Empty(t, changes.Deleted)
+	assert.False(t, changes.HasMore)
+	assert.False(t, changes.FullResyncRequired)
+	assert.NotEmpty(t, changes.Token)
+}
+
+func TestGetChanges_WithToken_ClassifiesChanges(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	changeFeedService := changefeed.NewChangeFeedService(&repository.Manager{Category: mockCategoryRepository}, testConfig)
+
+	userId := uint64(1)
+	existing := entity.Category{Id: 1, UserId: userId, Name: "Food", CreatedSeq: 1, ChangeSeq: 5}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(0), uint64(0), testConfig.PageSize+1).
+		Times(1).
+		Return(feed(existing), nil)
+
+	first, err := changeFeedService.GetChanges(userId, "")
+	assert.NoError(t, err)
+
+	updated := entity.Category{Id: 1, UserId: userId, Name: "Groceries", CreatedSeq: 1, ChangeSeq: 6}
+	created := entity.Category{Id: 3, UserId: userId, Name: "Rent", CreatedSeq: 7, ChangeSeq: 7}
+	deletedAt := time.Now()
+	deleted := entity.Category{
+		Id:         2,
+		UserId:     userId,
+		CreatedSeq: 2,
+		ChangeSeq:  8,
+		DeletedAt:  gorm.DeletedAt{Time: deletedAt, Valid: true},
+	}
+	createdAndDeleted := entity.Category{
+		Id:         4,
+		UserId:     userId,
+		CreatedSeq: 9,
+		ChangeSeq:  10,
+		DeletedAt:  gorm.DeletedAt{Time: deletedAt, Valid: true},
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, existing.ChangeSeq, existing.Id, testConfig.PageSize+1).
+		Times(1).
+		Return(feed(updated, created, deleted, createdAndDeleted), nil)
+
+	second, err := changeFeedService.GetChanges(userId, first.Token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Category{created}, second.Created)
+	assert.Equal(t, []entity.Category{updated}, second.Updated)
+	assert.Len(t, second.Deleted, 1)
+	assert.Equal(t, deleted.Id, second.Deleted[0].Id)
+	assert.Equal(t, deletedAt, second.Deleted[0].DeletedAt)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, createdAndDeleted.ChangeSeq, createdAndDeleted.Id, testConfig.PageSize+1).
+		Times(1).
+		Return(nil, nil)
+
+	third, err := changeFeedService.GetChanges(userId, second.Token)
+
+	assert.NoError(t, err)
+	assert.Empty(t, third.Created)
+	assert.Empty(t, third.Updated)
+	assert.Empty(t, third.Deleted)
+}
+
+func TestGetChanges_PageOverflow_HasMore(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	changeFeedService := changefeed.NewChangeFeedService(
+		&repository.Manager{Category: mockCategoryRepository},
+		config.ChangeFeedConfig{Retention: time.Hour, PageSize: 1},
+	)
+
+	userId := uint64(1)
+	categories := []entity.Category{
+		{Id: 1, UserId: userId, CreatedSeq: 1, ChangeSeq: 1},
+		{Id: 2, UserId: userId, CreatedSeq: 2, ChangeSeq: 2},
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(0), uint64(0), 2).
+		Times(1).
+		Return(feed(categories...), nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(1), uint64(1), 2).
+		Times(1).
+		Return(feed(categories[1:]...), nil)
+
+	first, err := changeFeedService.GetChanges(userId, "")
+	assert.NoError(t, err)
+	assert.True(t, first.HasMore)
+	assert.Len(t, first.Created, 1)
+
+	second, err := changeFeedService.GetChanges(userId, first.Token)
+	assert.NoError(t, err)
+	assert.False(t, second.HasMore)
+	assert.Equal(t, categories[1:], second.Created)
+}
+
+func TestGetChanges_RetentionExceeded_FullResyncRequired(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	changeFeedService := changefeed.NewChangeFeedService(
+		&repository.Manager{Category: mockCategoryRepository},
+		config.ChangeFeedConfig{Retention: time.Nanosecond, PageSize: 10},
+	)
+
+	userId := uint64(1)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(0), uint64(0), 11).
+		Times(1).
+		Return(nil, nil)
+
+	first, err := changeFeedService.GetChanges(userId, "")
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond)
+
+	second, err := changeFeedService.GetChanges(userId, first.Token)
+
+	assert.NoError(t, err)
+	assert.True(t, second.FullResyncRequired)
+	assert.Empty(t, second.Token)
+}
+
+func TestGetChanges_InvalidToken_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	changeFeedService := changefeed.NewChangeFeedService(&repository.Manager{Category: mockCategoryRepository}, testConfig)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(uint64(1), uint64(0), uint64(0), testConfig.PageSize+1).
+		Times(1).
+		Return(nil, nil)
+
+	othersToken, err := changeFeedService.GetChanges(1, "")
+	assert.NoError(t, err)
+
+	for _, token := range []string{"not a token", "MTox", othersToken.Token} {
+		changes, err := changeFeedService.GetChanges(2, token)
+
+		assert.Nil(t, changes)
+		assert.Equal(t, serviceerror.InvalidSyncToken, err)
+	}
+}
+
+func TestGetChanges_JoinedHousehold_ReturnsExistingCategoriesAsCreated(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	changeFeedService := changefeed.NewChangeFeedService(&repository.Manager{Category: mockCategoryRepository}, testConfig)
+
+	userId, householdId := uint64(2), uint64(7)
+	own := entity.Category{Id: 3, UserId: userId, Name: "Taxi", CreatedSeq: 4, ChangeSeq: 4}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(0), uint64(0), testConfig.PageSize+1).
+		Times(1).
+		Return(feed(own), nil)
+
+	first, err := changeFeedService.GetChanges(userId, "")
+	assert.NoError(t, err)
+
+	// the household categories were changed before the user joined at sequence number 9
+	shared := entity.Category{Id: 1, UserId: 1, HouseholdId: &householdId, Name: "Food", CreatedSeq: 1, ChangeSeq: 2}
+	deletedBefore := entity.Category{
+		Id:          2,
+		UserId:      1,
+		HouseholdId: &householdId,
+		CreatedSeq:  1,
+		ChangeSeq:   3,
+		DeletedAt:   gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, own.ChangeSeq, own.Id, testConfig.PageSize+1).
+		Times(1).
+		Return([]entity.CategoryChange{
+			{Category: shared, FeedSeq: 9, JoinedSeq: 9},
+			{Category: deletedBefore, FeedSeq: 9, JoinedSeq: 9},
+		}, nil)
+
+	second, err := changeFeedService.GetChanges(userId, first.Token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Category{shared}, second.Created)
+	assert.Empty(t, second.Updated)
+	assert.Empty(t, second.Deleted)
+
+	// the next page continues after the last category of sequence number 9
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(9), deletedBefore.Id, testConfig.PageSize+1).
+		Times(1).
+		Return(nil, nil)
+
+	_, err = changeFeedService.GetChanges(userId, second.Token)
+	assert.NoError(t, err)
+}
+
+func TestGetChanges_LeftHousehold_ReturnsTombstones(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	changeFeedService := changefeed.NewChangeFeedService(&repository.Manager{Category: mockCategoryRepository}, testConfig)
+
+	userId, householdId := uint64(2), uint64(7)
+	shared := entity.Category{Id: 1, UserId: 1, HouseholdId: &householdId, Name: "Food", CreatedSeq: 1, ChangeSeq: 2}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(0), uint64(0), testConfig.PageSize+1).
+		Times(1).
+		Return([]entity.CategoryChange{{Category: shared, FeedSeq: 5, JoinedSeq: 5}}, nil)
+
+	first, err := changeFeedService.GetChanges(userId, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Category{shared}, first.Created)
+
+	leftAt := time.Now()
+	// created after the token and gone before the client saw it
+	unseen := entity.Category{Id: 4, UserId: 1, HouseholdId: &householdId, Name: "Rent", CreatedSeq: 8, ChangeSeq: 8}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(5), shared.Id, testConfig.PageSize+1).
+		Times(1).
+		Return([]entity.CategoryChange{
+			{Category: shared, FeedSeq: 10, JoinedSeq: 5, RevokedAt: &leftAt},
+			{Category: unseen, FeedSeq: 10, JoinedSeq: 5, RevokedAt: &leftAt},
+		}, nil)
+
+	second, err := changeFeedService.GetChanges(userId, first.Token)
+
+	assert.NoError(t, err)
+	assert.Empty(t, second.Created)
+	assert.Empty(t, second.Updated)
+	assert.Equal(t, []model.CategoryTombstone{{Id: shared.Id, DeletedAt: leftAt}}, second.Deleted)
+}
+
+// feed places the categories in the change feed at their change sequence numbers.
+func feed(categories ...entity.Category) []entity.CategoryChange {
+	changes := make([]entity.CategoryChange, len(categories))
+	for i, category := range categories {
+		changes[i] = entity.CategoryChange{Category: category, FeedSeq: category.ChangeSeq}
+	}
+	return changes
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/export"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+var externalId = "bank-42"
+
+var categories = []entity.Category{
+	{
+		Id:          1,
+		UserId:      1,
+		Name:        "Groceries",
+		Description: "Food, \"household\"",
+		Type:        "EXPENSE",
+		ExternalId:  &externalId,
+		CreatedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+	},
+	{
+		Id:        2,
+		UserId:    1,
+		Name:      "Salary",
+		Type:      "INCOME",
+		CreatedAt: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC),
+	},
+	{
+		Id:             3,
+		UserId:         1,
+		Name:           "Vacation",
+		Type:           "GOAL",
+		TargetAmount:   ptr(decimal.RequireFromString("1500")),
+		TargetDate:     ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+		TargetCurrency: ptr("EUR"),
+		CreatedAt:      time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:      time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC),
+	},
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func expectCategories(mockCategoryRepository *mock.MockCategoryRepository) {
+	mockCategoryRepository.
+		EXPECT().
+		ForEachCategoryByUserId(uint64(1), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(userId uint64, batchSize int, fn func(category entity.Category) error) error {
+			for _, category := range categories {
+				if err := fn(category); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func TestExportCategories_CSV(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	exportService := export.NewExportService(&repository.Manager{Category: mockCategoryRepository})
+
+	expectCategories(mockCategoryRepository)
+
+	buf := &bytes.Buffer{}
+	err := exportService.ExportCategories(1, model.ExportCSV, buf)
+	assert.NoError(t, err)
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		model.CategoryRecordColumns,
+		{"1", "bank-42", "Groceries", "EXPENSE", "Food, \"household\"", "", "", "", "2024-01-01T10:00:00Z", "2024-01-02T10:00:00Z"},
+		{"2", "", "Salary", "INCOME", "", "", "", "", "2024-01-03T10:00:00Z", "2024-01-03T10:00:00Z"},
+		{"3", "", "Vacation", "GOAL", "", "1500", "2025-06-01", "EUR", "2024-01-04T10:00:00Z", "2024-01-04T10:00:00Z"},
+	}, rows)
+}
+
+func TestExportCategories_JSON(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	exportService := export.NewExportService(&repository.Manager{Category: mockCategoryRepository})
+
+	expectCategories(mockCategoryRepository)
+
+	buf := &bytes.Buffer{}
+	err := exportService.ExportCategories(1, model.ExportJSON, buf)
+	assert.NoError(t, err)
+
+	var records []model.CategoryRecord
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+	assert.Equal(t, []model.CategoryRecord{
+		model.NewCategoryRecord(categories[0]),
+		model.NewCategoryRecord(categories[1]),
+		model.NewCategoryRecord(categories[2]),
+	}, records)
+}
+
+func TestExportCategories_NoCategories_EmptyJSONArray(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	exportService := export.NewExportService(&repository.Manager{Category: mockCategoryRepository})
+
+	mockCategoryRepository.
+		EXPECT().
+		ForEachCategoryByUserId(uint64(1), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	buf := &bytes.Buffer{}
+	err := exportService.ExportCategories(1, model.ExportJSON, buf)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[]`, buf.String())
+}
+
+func TestExportCategories_RepositoryError(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	exportService := export.NewExportService(&repository.Manager{Category: mockCategoryRepository})
+
+	repositoryError := errors.New("connection lost")
+	mockCategoryRepository.
+		EXPECT().
+		ForEachCategoryByUserId(uint64(1), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(repositoryError)
+
+	err := exportService.ExportCategories(1, model.ExportCSV, &bytes.Buffer{})
+
+	assert.Equal(t, repositoryError, err)
+}
+
+func TestExportCategories_InvalidFormat_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	exportService := export.NewExportService(&repository.Manager{Category: mock.NewMockCategoryRepository(ctl)})
+
+	err := exportService.ExportCategories(1, "xml", &bytes.Buffer{})
+
+	assert.Equal(t, serviceerror.InvalidExportFormat, err)
+}
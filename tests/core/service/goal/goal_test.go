@@ -0,0 +1,128 @@
+package goal
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/goal"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+const (
+	userId     = uint64(1)
+	categoryId = uint64(10)
+)
+
+func newGoalService(ctl *gomock.Controller) (service.GoalService, *mock.MockCategoryRepository, *mock.MockSpendingRepository) {
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockSpendingRepository := mock.NewMockSpendingRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+		Spending: mockSpendingRepository,
+	}
+	return goal.NewGoalService(mockManager), mockCategoryRepository, mockSpendingRepository
+}
+
+func goalCategory(targetDate time.Time) *entity.Category {
+	amount := decimal.RequireFromString("2000")
+	currency := "EUR"
+	return &entity.Category{
+		Id:             categoryId,
+		UserId:         userId,
+		Name:           "Vacation fund",
+		Type:           entity.Goal,
+		TargetAmount:   &amount,
+		TargetDate:     &targetDate,
+		TargetCurrency: &currency,
+	}
+}
+
+func contribution(currency, amount string, month time.Month) entity.SpendingTotal {
+	return entity.SpendingTotal{
+		UserId:      userId,
+		CategoryId:  categoryId,
+		Currency:    currency,
+		PeriodStart: time.Date(2024, month, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2024, month+1, 1, 0, 0, 0, 0, time.UTC),
+		Amount:      decimal.RequireFromString(amount),
+	}
+}
+
+func TestGetGoalProgress_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	goalService, mockCategoryRepository, mockSpendingRepository := newGoalService(ctl)
+
+	now := time.Now().UTC()
+	targetDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 30)
+	mockCategoryRepository.EXPECT().GetCategoryById(categoryId).Times(1).Return(goalCategory(targetDate), nil)
+	mockSpendingRepository.EXPECT().GetCategoryTotals(categoryId).Times(1).Return([]entity.SpendingTotal{
+		contribution("EUR", "500", time.January),
+		contribution("EUR", "250", time.February),
+		contribution("USD", "1000", time.February),
+	}, nil)
+
+	progress, err := goalService.GetGoalProgress(userId, categoryId)
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("750").Equal(progress.Saved))
+	assert.True(t, decimal.RequireFromString("1250").Equal(progress.Remaining))
+	assert.True(t, decimal.RequireFromString("37.5").Equal(progress.Percentage))
+	assert.Equal(t, 30, progress.DaysLeft)
+	assert.False(t, progress.Achieved)
+}
+
+func TestGetGoalProgress_Achieved(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	goalService, mockCategoryRepository, mockSpendingRepository := newGoalService(ctl)
+
+	mockCategoryRepository.EXPECT().GetCategoryById(categoryId).Times(1).
+		Return(goalCategory(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)), nil)
+	mockSpendingRepository.EXPECT().GetCategoryTotals(categoryId).Times(1).Return([]entity.SpendingTotal{
+		contribution("EUR", "2100", time.March),
+	}, nil)
+
+	progress, err := goalService.GetGoalProgress(userId, categoryId)
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.Zero.Equal(progress.Remaining))
+	assert.Equal(t, 0, progress.DaysLeft)
+	assert.True(t, progress.Achieved)
+}
+
+func TestGetGoalProgress_NotGoal(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	goalService, mockCategoryRepository, _ := newGoalService(ctl)
+
+	mockCategoryRepository.EXPECT().GetCategoryById(categoryId).Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: userId, Name: "Groceries", Type: entity.Expense}, nil)
+
+	_, err := goalService.GetGoalProgress(userId, categoryId)
+
+	assert.ErrorIs(t, err, serviceerror.CategoryIsNotGoal)
+}
+
+func TestGetGoalProgress_CategoryDoesntBelongToUser(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	goalService, mockCategoryRepository, _ := newGoalService(ctl)
+
+	mockCategoryRepository.EXPECT().GetCategoryById(categoryId).Times(1).
+		Return(goalCategory(time.Now()), nil)
+
+	_, err := goalService.GetGoalProgress(userId+1, categoryId)
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntBelongToUser)
+}
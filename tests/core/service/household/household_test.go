@@ -0,0 +1,269 @@
+package household
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/household"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	ownerId     = uint64(1)
+	memberId    = uint64(2)
+	householdId = uint64(7)
+)
+
+var token = strings.Repeat("ab", 32)
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+func newHouseholdService(ctl *gomock.Controller) (service.HouseholdService, *mock.MockHouseholdRepository) {
+	mockHouseholdRepository := mock.NewMockHouseholdRepository(ctl)
+	mockManager := &repository.Manager{
+		Household: mockHouseholdRepository,
+	}
+	return household.NewHouseholdService(mockManager, config.DefaultHouseholdConfig), mockHouseholdRepository
+}
+
+func expectMember(repo *mock.MockHouseholdRepository, userId uint64, role entity.HouseholdRole) {
+	repo.
+		EXPECT().
+		GetMember(householdId, userId).
+		Times(1).
+		Return(&entity.HouseholdMember{HouseholdId: householdId, UserId: userId, Role: role}, nil)
+}
+
+func TestCreateHousehold_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		CreateHousehold(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(household *entity.Household) (*entity.Household, error) {
+			household.Id = householdId
+			return household, nil
+		})
+
+	result, err := householdService.CreateHousehold(model.HouseholdCreateDTO{UserId: ownerId, Name: "Home"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ownerId, result.OwnerId)
+	assert.Equal(t, "Home", result.Name)
+}
+
+func TestGetMembers_NotMember_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		GetMember(householdId, memberId).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := householdService.GetMembers(memberId, householdId)
+
+	assert.ErrorIs(t, err, serviceerror.NotHouseholdMember)
+}
+
+func TestCreateInvitation_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+	expectMember(mockHouseholdRepository, ownerId, entity.RoleOwner)
+
+	mockHouseholdRepository.
+		EXPECT().
+		CreateInvitation(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(invitation *entity.HouseholdInvitation) (*entity.HouseholdInvitation, error) {
+			return invitation, nil
+		})
+
+	before := time.Now()
+	result, err := householdService.CreateInvitation(model.HouseholdInvitationCreateDTO{
+		UserId:      ownerId,
+		HouseholdId: householdId,
+		Role:        entity.RoleEditor,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Token, 64)
+	assert.Equal(t, entity.RoleEditor, result.Role)
+	assert.Equal(t, ownerId, result.InvitedBy)
+	assert.False(t, result.ExpiresAt.Before(before.Add(config.DefaultHouseholdConfig.InvitationTTL)))
+}
+
+func TestCreateInvitation_NotOwner_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+	expectMember(mockHouseholdRepository, memberId, entity.RoleEditor)
+
+	_, err := householdService.CreateInvitation(model.HouseholdInvitationCreateDTO{
+		UserId:      memberId,
+		HouseholdId: householdId,
+		Role:        entity.RoleViewer,
+	})
+
+	assert.ErrorIs(t, err, serviceerror.HouseholdOwnerRequired)
+}
+
+func TestCreateInvitation_InviteeAlreadyMember_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+	expectMember(mockHouseholdRepository, ownerId, entity.RoleOwner)
+	expectMember(mockHouseholdRepository, memberId, entity.RoleViewer)
+
+	_, err := householdService.CreateInvitation(model.HouseholdInvitationCreateDTO{
+		UserId:      ownerId,
+		HouseholdId: householdId,
+		InviteeId:   ptr(memberId),
+		Role:        entity.RoleViewer,
+	})
+
+	assert.ErrorIs(t, err, serviceerror.AlreadyHouseholdMember)
+}
+
+func TestAcceptInvitation_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		GetInvitationByToken(token).
+		Times(1).
+		Return(&entity.HouseholdInvitation{
+			Id:          3,
+			HouseholdId: householdId,
+			InvitedBy:   ownerId,
+			Role:        entity.RoleViewer,
+			Token:       token,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}, nil)
+	mockHouseholdRepository.
+		EXPECT().
+		AcceptInvitation(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(invitation *entity.HouseholdInvitation, member *entity.HouseholdMember) error {
+			assert.Equal(t, memberId, *invitation.AcceptedBy)
+			assert.NotNil(t, invitation.AcceptedAt)
+			return nil
+		})
+
+	result, err := householdService.AcceptInvitation(model.HouseholdInvitationAcceptDTO{UserId: memberId, Token: token})
+
+	assert.NoError(t, err)
+	assert.Equal(t, householdId, result.HouseholdId)
+	assert.Equal(t, memberId, result.UserId)
+	assert.Equal(t, entity.RoleViewer, result.Role)
+}
+
+func TestAcceptInvitation_Expired_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		GetInvitationByToken(token).
+		Times(1).
+		Return(&entity.HouseholdInvitation{
+			HouseholdId: householdId,
+			Role:        entity.RoleViewer,
+			ExpiresAt:   time.Now().Add(-time.Minute),
+		}, nil)
+
+	_, err := householdService.AcceptInvitation(model.HouseholdInvitationAcceptDTO{UserId: memberId, Token: token})
+
+	assert.ErrorIs(t, err, serviceerror.InvalidInvitation)
+}
+
+func TestAcceptInvitation_ForAnotherUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+
+	mockHouseholdRepository.
+		EXPECT().
+		GetInvitationByToken(token).
+		Times(1).
+		Return(&entity.HouseholdInvitation{
+			HouseholdId: householdId,
+			UserId:      ptr(uint64(9)),
+			Role:        entity.RoleViewer,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}, nil)
+
+	_, err := householdService.AcceptInvitation(model.HouseholdInvitationAcceptDTO{UserId: memberId, Token: token})
+
+	assert.ErrorIs(t, err, serviceerror.InvitationForAnotherUser)
+}
+
+func TestRemoveMember_Leave_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+	expectMember(mockHouseholdRepository, memberId, entity.RoleViewer)
+
+	mockHouseholdRepository.
+		EXPECT().
+		RemoveMember(householdId, memberId).
+		Times(1).
+		Return(nil)
+
+	err := householdService.RemoveMember(model.HouseholdMemberDeleteDTO{UserId: memberId, HouseholdId: householdId, MemberId: memberId})
+
+	assert.NoError(t, err)
+}
+
+func TestRemoveMember_OwnerLeaves_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+	expectMember(mockHouseholdRepository, ownerId, entity.RoleOwner)
+
+	err := householdService.RemoveMember(model.HouseholdMemberDeleteDTO{UserId: ownerId, HouseholdId: householdId, MemberId: ownerId})
+
+	assert.ErrorIs(t, err, serviceerror.HouseholdOwnerCantLeave)
+}
+
+func TestRemoveMember_ByEditor_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	householdService, mockHouseholdRepository := newHouseholdService(ctl)
+	expectMember(mockHouseholdRepository, memberId, entity.RoleEditor)
+
+	err := householdService.RemoveMember(model.HouseholdMemberDeleteDTO{UserId: memberId, HouseholdId: householdId, MemberId: 3})
+
+	assert.ErrorIs(t, err, serviceerror.HouseholdOwnerRequired)
+}
@@ -0,0 +1,162 @@
+package idempotency
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/memory"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/idempotency"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+var idempotencyConfig = config.IdempotencyConfig{TTL: time.Hour}
+
+func TestBegin_NewKey_Reserved(t *testing.T) {
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: memory.NewIdempotencyRepository()}, idempotencyConfig)
+
+	stored, err := idempotencyService.Begin(1, "key", "fingerprint")
+
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestBegin_CompletedKey_ReplaysResponse(t *testing.T) {
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: memory.NewIdempotencyRepository()}, idempotencyConfig)
+
+	_, err := idempotencyService.Begin(1, "key", "fingerprint")
+	assert.NoError(t, err)
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	body := []byte(`{"message":"Category created"}`)
+	err = idempotencyService.Complete(1, "key", http.StatusCreated, headers, body)
+	assert.NoError(t, err)
+
+	stored, err := idempotencyService.Begin(1, "key", "fingerprint")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, stored)
+	assert.Equal(t, http.StatusCreated, stored.StatusCode)
+	assert.Equal(t, headers, stored.Headers)
+	assert.Equal(t, body, stored.Body)
+}
+
+func TestBegin_DifferentRequest_Error(t *testing.T) {
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: memory.NewIdempotencyRepository()}, idempotencyConfig)
+
+	_, err := idempotencyService.Begin(1, "key", "fingerprint")
+	assert.NoError(t, err)
+	assert.NoError(t, idempotencyService.Complete(1, "key", http.StatusCreated, nil, nil))
+
+	stored, err := idempotencyService.Begin(1, "key", "another fingerprint")
+
+	assert.Nil(t, stored)
+	assert.Equal(t, serviceerror.IdempotencyKeyReused, err)
+}
+
+func TestBegin_KeyInProgress_Error(t *testing.T) {
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: memory.NewIdempotencyRepository()}, idempotencyConfig)
+
+	_, err := idempotencyService.Begin(1, "key", "fingerprint")
+	assert.NoError(t, err)
+
+	stored, err := idempotencyService.Begin(1, "key", "fingerprint")
+
+	assert.Nil(t, stored)
+	assert.Equal(t, serviceerror.IdempotencyKeyInProgress, err)
+}
+
+func TestBegin_KeysAreScopedByUser(t *testing.T) {
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: memory.NewIdempotencyRepository()}, idempotencyConfig)
+
+	_, err := idempotencyService.Begin(1, "key", "fingerprint")
+	assert.NoError(t, err)
+
+	stored, err := idempotencyService.Begin(2, "key", "another fingerprint")
+
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestBegin_ReleasedKey_ReservedAgain(t *testing.T) {
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: memory.NewIdempotencyRepository()}, idempotencyConfig)
+
+	_, err := idempotencyService.Begin(1, "key", "fingerprint")
+	assert.NoError(t, err)
+	assert.NoError(t, idempotencyService.Release(1, "key"))
+
+	stored, err := idempotencyService.Begin(1, "key", "another fingerprint")
+
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestBegin_ExpiredKey_ReservedAgain(t *testing.T) {
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: memory.NewIdempotencyRepository()}, config.IdempotencyConfig{TTL: time.Millisecond})
+
+	_, err := idempotencyService.Begin(1, "key", "fingerprint")
+	assert.NoError(t, err)
+	assert.NoError(t, idempotencyService.Complete(1, "key", http.StatusCreated, nil, nil))
+
+	time.Sleep(5 * time.Millisecond)
+
+	stored, err := idempotencyService.Begin(1, "key", "another fingerprint")
+
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestBegin_InvalidKey_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockIdempotencyRepository := mock.NewMockIdempotencyRepository(ctl)
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: mockIdempotencyRepository}, idempotencyConfig)
+
+	for _, key := range []string{"", strings.Repeat("k", 256)} {
+		stored, err := idempotencyService.Begin(1, key, "fingerprint")
+
+		assert.Nil(t, stored)
+		assert.Equal(t, serviceerror.InvalidIdempotencyKey, err)
+	}
+}
+
+func TestBegin_RepositoryReservesKeyWithTTL(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockIdempotencyRepository := mock.NewMockIdempotencyRepository(ctl)
+	idempotencyService := idempotency.NewIdempotencyService(
+		&repository.Manager{Idempotency: mockIdempotencyRepository}, idempotencyConfig)
+
+	mockIdempotencyRepository.
+		EXPECT().
+		CreateKey(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(key *entity.IdempotencyKey) (bool, error) {
+			assert.Equal(t, uint64(1), key.UserId)
+			assert.Equal(t, "key", key.Key)
+			assert.Equal(t, "fingerprint", key.Fingerprint)
+			assert.Equal(t, idempotencyConfig.TTL, key.ExpiresAt.Sub(key.CreatedAt))
+			return true, nil
+		})
+
+	stored, err := idempotencyService.Begin(1, "key", "fingerprint")
+
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+}
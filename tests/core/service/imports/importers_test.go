@@ -0,0 +1,185 @@
+package imports
+
+import (
+	"errors"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	importeradapter "github.com/khivuksergey/portmonetka.category/internal/adapter/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImporters_Fixtures(t *testing.T) {
+	tests := []struct {
+		file       string
+		format     model.ImportFormat
+		categories []model.CategoryCreateDTO
+	}{
+		{
+			file:   "quicken.qif",
+			format: model.ImportQIF,
+			categories: []model.CategoryCreateDTO{
+				{Name: "Auto", Description: "Automobile expenses", Type: "EXPENSE"},
+				{Name: "Auto:Fuel", Type: "EXPENSE"},
+				{Name: "Salary", Description: "Paycheck", Type: "INCOME"},
+				{Name: "Gifts Received", Type: "INCOME"},
+				{Name: "Misc", Type: "EXPENSE"},
+			},
+		},
+		{
+			file:   "ynab_budget.csv",
+			format: model.ImportYNAB,
+			categories: []model.CategoryCreateDTO{
+				{Name: "Inflow: Ready to Assign", Type: "INCOME"},
+				{Name: "Immediate Obligations: Rent/Mortgage", Type: "EXPENSE"},
+				{Name: "Immediate Obligations: Groceries", Type: "EXPENSE"},
+				{Name: "Quality of Life Goals: Vacation", Type: "EXPENSE"},
+			},
+		},
+		{
+			file:   "ynab_register.csv",
+			format: model.ImportYNAB,
+			categories: []model.CategoryCreateDTO{
+				{Name: "Inflow: Ready to Assign", Type: "INCOME"},
+				{Name: "Immediate Obligations: Rent/Mortgage", Type: "EXPENSE"},
+				{Name: "Side Income: Sales", Type: "INCOME"},
+				{Name: "Immediate Obligations: Groceries", Type: "EXPENSE"},
+			},
+		},
+		{
+			file:   "coinkeeper_en.csv",
+			format: model.ImportCoinKeeper,
+			categories: []model.CategoryCreateDTO{
+				{Name: "Salary", Type: "INCOME"},
+				{Name: "Groceries", Type: "EXPENSE"},
+				{Name: "Taxi", Type: "EXPENSE"},
+			},
+		},
+		{
+			file:   "coinkeeper_ru.csv",
+			format: model.ImportCoinKeeper,
+			categories: []model.CategoryCreateDTO{
+				{Name: "Зарплата", Type: "INCOME"},
+				{Name: "Продукты", Type: "EXPENSE"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("testdata", test.file))
+			assert.NoError(t, err)
+
+			var detected []model.ImportFormat
+			for _, importer := range importeradapter.Default() {
+				if importer.Detect(content) {
+					detected = append(detected, importer.Format())
+				}
+				if importer.Format() != test.format {
+					continue
+				}
+				categories, err := importer.Parse(strings.NewReader(string(content)), 100)
+				assert.NoError(t, err)
+				assert.Equal(t, test.categories, categories)
+			}
+			assert.Equal(t, []model.ImportFormat{test.format}, detected)
+		})
+	}
+}
+
+func TestImporters_NativeFormatsDetected(t *testing.T) {
+	files := map[model.ImportFormat]string{
+		model.ImportCSV:  "id,externalId,name,type,description\n1,,Groceries,EXPENSE,\n",
+		model.ImportJSON: ` [{"name":"Groceries","type":"EXPENSE"}]`,
+	}
+
+	for format, file := range files {
+		var detected []model.ImportFormat
+		for _, importer := range importeradapter.Default() {
+			if importer.Detect([]byte(file)) {
+				detected = append(detected, importer.Format())
+			}
+		}
+		assert.Equal(t, []model.ImportFormat{format}, detected)
+	}
+}
+
+func TestImporters_NativeGoalTargets(t *testing.T) {
+	targetAmount := decimal.RequireFromString("1500")
+	targetDate := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	targetCurrency := "EUR"
+	expected := []model.CategoryCreateDTO{
+		{Name: "Vacation", Type: "GOAL", TargetAmount: &targetAmount, TargetDate: &targetDate, TargetCurrency: &targetCurrency},
+		{Name: "Groceries", Type: "EXPENSE"},
+	}
+	files := map[model.ImportFormat]string{
+		model.ImportCSV: "id,externalId,name,type,description,targetAmount,targetDate,targetCurrency,createdAt,updatedAt\n" +
+			"3,,Vacation,GOAL,,1500,2025-06-01,EUR,2024-01-04T10:00:00Z,2024-01-04T10:00:00Z\n" +
+			"1,,Groceries,EXPENSE,,,,,2024-01-01T10:00:00Z,2024-01-01T10:00:00Z\n",
+		model.ImportJSON: `[{"name":"Vacation","type":"GOAL","targetAmount":"1500","targetDate":"2025-06-01T00:00:00Z","targetCurrency":"EUR"},` +
+			`{"name":"Groceries","type":"EXPENSE"}]`,
+	}
+
+	for _, importer := range importeradapter.Default() {
+		file, ok := files[importer.Format()]
+		if !ok {
+			continue
+		}
+		categories, err := importer.Parse(strings.NewReader(file), 100)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, categories)
+	}
+}
+
+func TestImporters_NativeInvalidTarget_Error(t *testing.T) {
+	files := map[model.ImportFormat]string{
+		model.ImportCSV:  "name,type,targetAmount,targetDate,targetCurrency\nVacation,GOAL,lots,2025-06-01,EUR\n",
+		model.ImportJSON: `[{"name":"Vacation","type":"GOAL","targetAmount":"1500","targetDate":"June","targetCurrency":"EUR"}]`,
+	}
+
+	for _, importer := range importeradapter.Default() {
+		file, ok := files[importer.Format()]
+		if !ok {
+			continue
+		}
+		_, err := importer.Parse(strings.NewReader(file), 100)
+		assert.ErrorIs(t, err, serviceerror.InvalidImportFile)
+	}
+}
+
+// failingReader fails the test when the importer reads past the rows it was given.
+type failingReader struct {
+	t *testing.T
+}
+
+func (r failingReader) Read([]byte) (int, error) {
+	r.t.Error("file was read past the row limit")
+	return 0, errors.New("read past the row limit")
+}
+
+func TestImporters_TooManyRows_StopsReading(t *testing.T) {
+	files := map[model.ImportFormat]string{
+		model.ImportCSV:        "name,type\nSalary,INCOME\nRent,EXPENSE\n",
+		model.ImportJSON:       `[{"name":"Salary","type":"INCOME"},{"name":"Rent","type":"EXPENSE"},`,
+		model.ImportQIF:        "!Type:Cat\nNSalary\nI\n^\nNRent\nE\n^\nNTaxi\n",
+		model.ImportYNAB:       "Category Group,Category\nBills,Rent\nBills,Power\n",
+		model.ImportCoinKeeper: "Type,From,To\nExpense,Card,Rent\nExpense,Card,Taxi\n",
+	}
+
+	for _, importer := range importeradapter.Default() {
+		t.Run(string(importer.Format()), func(t *testing.T) {
+			file := io.MultiReader(strings.NewReader(files[importer.Format()]), failingReader{t: t})
+
+			categories, err := importer.Parse(file, 1)
+
+			assert.Nil(t, categories)
+			assert.Equal(t, serviceerror.TooManyImportRows, err)
+		})
+	}
+}
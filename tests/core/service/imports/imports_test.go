@@ -0,0 +1,391 @@
+package imports
+
+import (
+	"errors"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	importeradapter "github.com/khivuksergey/portmonetka.category/internal/adapter/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	eventmock "github.com/khivuksergey/portmonetka.category/internal/core/port/event/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/imports"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+const userId = uint64(1)
+
+var importConfig = config.ImportConfig{MaxRows: 100}
+
+func expectTransaction(mockCategoryRepository *mock.MockCategoryRepository, existing ...entity.Category) {
+	mockCategoryRepository.
+		EXPECT().
+		Transaction(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(fn func(categoryRepository repository.CategoryRepository) error) error {
+			return fn(mockCategoryRepository)
+		})
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return(existing, nil)
+}
+
+func expectTransferCategoryExists(mockCategoryRepository *mock.MockCategoryRepository) {
+	mockCategoryRepository.
+		EXPECT().
+		HasTransferCategory(userId).
+		Times(1).
+		Return(true, nil)
+}
+
+func expectCreate(t *testing.T, mockCategoryRepository *mock.MockCategoryRepository, names ...string) {
+	id := uint64(100)
+	for _, name := range names {
+		mockCategoryRepository.
+			EXPECT().
+			CreateCategory(gomock.Any()).
+			Times(1).
+			DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+				assert.Equal(t, name, category.Name)
+				id++
+				category.Id = id
+				return category, nil
+			})
+	}
+}
+
+func TestImportCategories_CSV_SkipConflicts(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	expectTransaction(mockCategoryRepository, entity.Category{Id: 1, UserId: userId, Name: "Groceries", Type: "EXPENSE"})
+	expectTransferCategoryExists(mockCategoryRepository)
+	expectCreate(t, mockCategoryRepository, "Salary")
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	file := "name,type,description\nGroceries,EXPENSE,Food\n Salary ,income,Monthly\n"
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader(file),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.CategoryImportReport{
+		Created: 1,
+		Skipped: 1,
+		Rows: []model.CategoryImportRow{
+			{Row: 1, Name: "Groceries", Status: model.ImportSkipped, CategoryId: 1},
+			{Row: 2, Name: "Salary", Status: model.ImportCreated, CategoryId: 101},
+		},
+	}, report)
+}
+
+func TestImportCategories_FirstCategories_CreatesTransferCategory(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	expectTransaction(mockCategoryRepository)
+	mockCategoryRepository.
+		EXPECT().
+		HasTransferCategory(userId).
+		Times(1).
+		Return(false, nil)
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return([]entity.Category{{Id: 101, UserId: userId, Name: "Transfers", Type: entity.Expense}}, nil)
+	expectCreate(t, mockCategoryRepository, "Transfers", "Transfers 2")
+
+	var created []*entity.Category
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(2).
+		Do(func(event model.Event) {
+			created = append(created, event.Data.(*entity.Category))
+		})
+
+	file := "name,type\nTransfers,EXPENSE\n"
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader(file),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Len(t, report.Rows, 1)
+	assert.Len(t, created, 2)
+	assert.Equal(t, entity.Transfer, created[1].Type)
+	assert.True(t, created[1].System)
+}
+
+func TestImportCategories_JSON_RenameConflicts(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	expectTransaction(mockCategoryRepository,
+		entity.Category{Id: 1, UserId: userId, Name: "Groceries", Type: "EXPENSE"},
+		entity.Category{Id: 2, UserId: userId, Name: "Groceries (2)", Type: "EXPENSE"},
+	)
+	expectTransferCategoryExists(mockCategoryRepository)
+	expectCreate(t, mockCategoryRepository, "Groceries (3)", "Groceries (4)")
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(2)
+
+	file := `[{"name":"Groceries","type":"EXPENSE"},{"name":"Groceries","type":"EXPENSE","description":"Again"}]`
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId:   userId,
+		Format:   model.ImportJSON,
+		File:     strings.NewReader(file),
+		Conflict: model.ConflictRename,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, model.ImportRenamed, report.Rows[0].Status)
+	assert.Equal(t, "Groceries (3)", report.Rows[0].Name)
+	assert.Equal(t, "Groceries (4)", report.Rows[1].Name)
+}
+
+func TestImportCategories_OverwriteConflicts(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	expectTransaction(mockCategoryRepository,
+		entity.Category{Id: 1, UserId: userId, Name: "Groceries", Description: "Old", Type: "EXPENSE", Version: 3},
+		entity.Category{Id: 2, UserId: userId, Name: "Rent", Description: "Same", Type: "EXPENSE", Version: 1},
+	)
+	expectTransferCategoryExists(mockCategoryRepository)
+
+	mockCategoryRepository.
+		EXPECT().
+		UpdateCategory(gomock.Any(), uint64(3), userId).
+		Times(1).
+		DoAndReturn(func(category *entity.Category, expectedVersion, _ uint64) (*entity.Category, error) {
+			assert.Equal(t, "New", category.Description)
+			return category, nil
+		})
+
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	file := "name,type,description\nGroceries,EXPENSE,New\nRent,EXPENSE,Same\n"
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId:   userId,
+		Format:   model.ImportCSV,
+		File:     strings.NewReader(file),
+		Conflict: model.ConflictOverwrite,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, model.ImportUpdated, report.Rows[0].Status)
+	assert.Equal(t, model.ImportSkipped, report.Rows[1].Status)
+}
+
+func TestImportCategories_DryRun_NothingPublished(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	expectTransaction(mockCategoryRepository)
+	expectTransferCategoryExists(mockCategoryRepository)
+	expectCreate(t, mockCategoryRepository, "Salary")
+
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader("name,type\nSalary,INCOME\n"),
+		DryRun: true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Created)
+	assert.Zero(t, report.Rows[0].CategoryId)
+}
+
+func TestImportCategories_InvalidRows_NothingImported(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	file := "name,type\nSalary,INCOME\nab,EXPENSE\nRent,TRANSFER\n"
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader(file),
+	})
+
+	assert.Equal(t, serviceerror.ImportHasInvalidRows, err)
+	assert.Equal(t, 2, report.Invalid)
+	assert.Equal(t, 2, report.Rows[0].Row)
+	assert.Equal(t, 3, report.Rows[1].Row)
+	assert.NotEmpty(t, report.Rows[1].Error)
+}
+
+func TestImportCategories_RowError_RolledBack(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	expectTransaction(mockCategoryRepository)
+	expectCreate(t, mockCategoryRepository, "Salary")
+
+	mockCategoryRepository.
+		EXPECT().
+		CreateCategory(gomock.Any()).
+		Times(1).
+		Return(nil, serviceerror.CategoryExternalIdExists)
+
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader("name,type,externalId\nSalary,INCOME,\nRent,EXPENSE,bank-1\n"),
+	})
+
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, serviceerror.CategoryExternalIdExists)
+}
+
+func TestImportCategories_InvalidFile_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	files := []model.CategoryImportDTO{
+		{UserId: userId, Format: model.ImportCSV, File: strings.NewReader("title,kind\nSalary,INCOME\n")},
+		{UserId: userId, Format: model.ImportJSON, File: strings.NewReader(`{"name":"Salary"}`)},
+		{UserId: userId, Format: "", File: strings.NewReader("hello world")},
+		{UserId: userId, Format: "xml", File: strings.NewReader("<categories/>")},
+	}
+
+	for _, file := range files {
+		report, err := importService.ImportCategories(file)
+
+		assert.Nil(t, report)
+		assert.True(t, errors.Is(err, serviceerror.InvalidImportFile))
+	}
+}
+
+func TestImportCategories_TooManyRows_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), config.ImportConfig{MaxRows: 1})
+
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader("name,type\nSalary,INCOME\nRent,EXPENSE\n"),
+	})
+
+	assert.Nil(t, report)
+	assert.Equal(t, serviceerror.TooManyImportRows, err)
+}
+
+func TestImportCategories_GoalTarget_Created(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockPublisher := eventmock.NewMockPublisher(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, mockPublisher, importeradapter.Default(), importConfig)
+
+	expectTransaction(mockCategoryRepository)
+	expectTransferCategoryExists(mockCategoryRepository)
+	mockCategoryRepository.
+		EXPECT().
+		CreateCategory(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(category *entity.Category) (*entity.Category, error) {
+			assert.Equal(t, "1500", category.TargetAmount.String())
+			assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), *category.TargetDate)
+			assert.Equal(t, "EUR", *category.TargetCurrency)
+			category.Id = 101
+			return category, nil
+		})
+	mockPublisher.
+		EXPECT().
+		Publish(gomock.Any()).
+		Times(1)
+
+	file := "name,type,targetAmount,targetDate,targetCurrency\nVacation,GOAL,1500,2025-06-01,EUR\n"
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader(file),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+}
+
+func TestImportCategories_GoalWithoutTarget_Invalid(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	importService := imports.NewImportService(&repository.Manager{Category: mockCategoryRepository}, nil, importeradapter.Default(), importConfig)
+
+	file := "name,type,targetAmount,targetDate,targetCurrency\nVacation,GOAL,-5,2025-06-01,EUR\nSavings,GOAL,,,\n"
+	report, err := importService.ImportCategories(model.CategoryImportDTO{
+		UserId: userId,
+		Format: model.ImportCSV,
+		File:   strings.NewReader(file),
+	})
+
+	assert.ErrorIs(t, err, serviceerror.ImportHasInvalidRows)
+	assert.Equal(t, 2, report.Invalid)
+}
@@ -0,0 +1,244 @@
+package mcc
+
+import (
+	"errors"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/mcc"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.category/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+const userId = uint64(1)
+
+func newMccService(ctl *gomock.Controller) (service.MccService, *mock.MockMccMappingRepository, *mock.MockCategoryRepository) {
+	mockMccMappingRepository := mock.NewMockMccMappingRepository(ctl)
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category:   mockCategoryRepository,
+		MccMapping: mockMccMappingRepository,
+	}
+	return mcc.NewMccService(mockManager), mockMccMappingRepository, mockCategoryRepository
+}
+
+func TestCreateMccMapping_Range_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, mockMccMappingRepository, mockCategoryRepository := newMccService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(7)).
+		Times(1).
+		Return(&entity.Category{Id: 7, UserId: userId, Name: "Travel"}, nil)
+
+	expected := &entity.MccMapping{UserId: userId, MccFrom: "3000", MccTo: "3299", CategoryId: 7}
+	mockMccMappingRepository.
+		EXPECT().
+		CreateMccMapping(expected).
+		Times(1).
+		Return(expected, nil)
+
+	mapping, err := mccService.CreateMccMapping(model.MccMappingCreateDTO{UserId: userId, Mcc: "3000-3299", CategoryId: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, mapping)
+}
+
+func TestCreateMccMapping_InvalidMcc(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, _, _ := newMccService(ctl)
+
+	for _, mcc := range []string{"541", "54111", "abcd", "3299-3000", "3000-"} {
+		_, err := mccService.CreateMccMapping(model.MccMappingCreateDTO{UserId: userId, Mcc: mcc, CategoryId: 7})
+		assert.ErrorIs(t, err, serviceerror.InvalidMcc, mcc)
+	}
+}
+
+func TestCreateMccMapping_CategoryDoesntBelongToUser(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, _, mockCategoryRepository := newMccService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(7)).
+		Times(1).
+		Return(&entity.Category{Id: 7, UserId: 2}, nil)
+
+	_, err := mccService.CreateMccMapping(model.MccMappingCreateDTO{UserId: userId, Mcc: "5411", CategoryId: 7})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntBelongToUser)
+}
+
+func TestUpdateMccMapping_DoesntBelongToUser(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, mockMccMappingRepository, _ := newMccService(ctl)
+
+	mockMccMappingRepository.
+		EXPECT().
+		GetMccMappingById(uint64(3)).
+		Times(1).
+		Return(&entity.MccMapping{Id: 3, UserId: 2, MccFrom: "5411", MccTo: "5411"}, nil)
+
+	mcc := "5412"
+	_, err := mccService.UpdateMccMapping(model.MccMappingUpdateDTO{Id: 3, UserId: userId, Mcc: &mcc})
+
+	assert.ErrorIs(t, err, serviceerror.MccMappingDoesntBelongToUser)
+}
+
+func TestResolveMcc_NarrowestMappingWins(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, mockMccMappingRepository, mockCategoryRepository := newMccService(ctl)
+
+	mockMccMappingRepository.
+		EXPECT().
+		GetMccMappingsByMcc(userId, "3010").
+		Times(1).
+		Return([]entity.MccMapping{
+			{Id: 1, UserId: userId, MccFrom: "3000", MccTo: "3299", CategoryId: 10},
+			{Id: 2, UserId: userId, MccFrom: "3010", MccTo: "3010", CategoryId: 20},
+			{Id: 3, UserId: userId, MccFrom: "3000", MccTo: "3100", CategoryId: 30},
+		}, nil)
+
+	narrowest := &entity.Category{Id: 20, UserId: userId, Name: "Business trips"}
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(20)).
+		Times(1).
+		Return(narrowest, nil)
+
+	resolution, err := mccService.ResolveMcc(model.MccResolveDTO{UserId: userId, Mcc: "3010"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.MccSourceMapping, resolution.Source)
+	assert.Equal(t, narrowest, resolution.Category)
+	assert.Equal(t, uint64(2), *resolution.MappingId)
+	assert.Equal(t, "Travel", resolution.Template)
+}
+
+func TestResolveMcc_MappingOfDeletedCategory_Skipped(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, mockMccMappingRepository, mockCategoryRepository := newMccService(ctl)
+
+	mockMccMappingRepository.
+		EXPECT().
+		GetMccMappingsByMcc(userId, "5411").
+		Times(1).
+		Return([]entity.MccMapping{
+			{Id: 1, UserId: userId, MccFrom: "5411", MccTo: "5411", CategoryId: 10},
+			{Id: 2, UserId: userId, MccFrom: "5411", MccTo: "5411", CategoryId: 20},
+		}, nil)
+
+	live := &entity.Category{Id: 10, UserId: userId, Name: "Food"}
+	gomock.InOrder(
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryById(uint64(20)).
+			Return(nil, errors.New("record not found")),
+		mockCategoryRepository.
+			EXPECT().
+			GetCategoryById(uint64(10)).
+			Return(live, nil),
+	)
+
+	resolution, err := mccService.ResolveMcc(model.MccResolveDTO{UserId: userId, Mcc: "5411"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, live, resolution.Category)
+	assert.Equal(t, uint64(1), *resolution.MappingId)
+}
+
+func TestResolveMcc_FallsBackToTemplate(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, mockMccMappingRepository, mockCategoryRepository := newMccService(ctl)
+
+	mockMccMappingRepository.
+		EXPECT().
+		GetMccMappingsByMcc(userId, "5411").
+		Times(1).
+		Return([]entity.MccMapping{}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return([]entity.Category{
+			{Id: 1, UserId: userId, Name: "Salary", Type: entity.Income},
+			{Id: 2, UserId: userId, Name: "groceries", Type: entity.Expense},
+		}, nil)
+
+	resolution, err := mccService.ResolveMcc(model.MccResolveDTO{UserId: userId, Mcc: "5411"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.MccSourceTemplate, resolution.Source)
+	assert.Equal(t, "Groceries", resolution.Template)
+	assert.Equal(t, "Grocery stores and supermarkets", resolution.Description)
+	assert.Equal(t, uint64(2), resolution.Category.Id)
+	assert.Nil(t, resolution.MappingId)
+}
+
+func TestResolveMcc_NoMatch(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mccService, mockMccMappingRepository, mockCategoryRepository := newMccService(ctl)
+
+	mockMccMappingRepository.
+		EXPECT().
+		GetMccMappingsByMcc(userId, gomock.Any()).
+		Times(2).
+		Return(nil, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return([]entity.Category{{Id: 1, UserId: userId, Name: "Salary"}}, nil)
+
+	// hotel range of the reference dataset, user has no Lodging category
+	resolution, err := mccService.ResolveMcc(model.MccResolveDTO{UserId: userId, Mcc: "3650"})
+	assert.NoError(t, err)
+	assert.Equal(t, model.MccSourceNone, resolution.Source)
+	assert.Equal(t, "Lodging", resolution.Template)
+	assert.Nil(t, resolution.Category)
+
+	// code missing from the reference dataset doesn't load categories
+	resolution, err = mccService.ResolveMcc(model.MccResolveDTO{UserId: userId, Mcc: "0001"})
+	assert.NoError(t, err)
+	assert.Equal(t, model.MccSourceNone, resolution.Source)
+	assert.Empty(t, resolution.Template)
+}
+
+func TestLookupMcc_ExactCodeWinsOverRange(t *testing.T) {
+	merchantCategory, ok := reference.LookupMcc("4511")
+	assert.True(t, ok)
+	assert.Equal(t, "Travel", merchantCategory.Template)
+
+	merchantCategory, ok = reference.LookupMcc("3700")
+	assert.True(t, ok)
+	assert.Equal(t, "3501-3999", merchantCategory.Range.String())
+
+	for _, merchantCategory = range reference.MerchantCategories() {
+		_, ok = reference.GetTemplate(merchantCategory.Template)
+		assert.True(t, ok, merchantCategory.Template)
+	}
+}
@@ -0,0 +1,377 @@
+package rules
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/rules"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+const userId = uint64(1)
+
+var rulesConfig = config.RulesConfig{
+	CacheTTL:      time.Hour,
+	MaxConditions: 10,
+}
+
+func newRulesService(ctl *gomock.Controller) (service.RulesService, *mock.MockRuleRepository, *mock.MockCategoryRepository) {
+	mockRuleRepository := mock.NewMockRuleRepository(ctl)
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+		Rule:     mockRuleRepository,
+	}
+	return rules.NewRulesService(mockManager, rulesConfig), mockRuleRepository, mockCategoryRepository
+}
+
+func amount(value string) *decimal.Decimal {
+	d := decimal.RequireFromString(value)
+	return &d
+}
+
+func userCategories(ids ...uint64) []entity.Category {
+	categories := make([]entity.Category, 0, len(ids))
+	for _, id := range ids {
+		categories = append(categories, entity.Category{Id: id, UserId: userId})
+	}
+	return categories
+}
+
+func TestClassify_FirstMatchByPriority(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, mockRuleRepository, mockCategoryRepository := newRulesService(ctl)
+
+	mockRuleRepository.
+		EXPECT().
+		GetRulesByUserId(userId).
+		Times(1).
+		Return([]entity.Rule{
+			{
+				// uber rides under 50 are transport, evaluated first
+				Id: 1, UserId: userId, Priority: 1, CategoryId: 10, Enabled: true,
+				Condition: entity.RuleCondition{Op: entity.RuleAnd, Conditions: []entity.RuleCondition{
+					{Op: entity.RuleContains, Value: "UBER"},
+					{Op: entity.RuleAmount, Max: amount("50")},
+				}},
+			},
+			{
+				Id: 2, UserId: userId, Priority: 2, CategoryId: 20, Enabled: true,
+				Condition: entity.RuleCondition{Op: entity.RuleOr, Conditions: []entity.RuleCondition{
+					{Op: entity.RulePrefix, Value: "uber eats"},
+					{Op: entity.RuleRegex, Value: `^(mcdonald'?s|kfc)\b`},
+				}},
+			},
+			{
+				Id: 3, UserId: userId, Priority: 3, CategoryId: 30, Enabled: true,
+				Condition: entity.RuleCondition{Op: entity.RuleEquals, Value: "Rent", CaseSensitive: true},
+			},
+		}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return(userCategories(10, 20, 30), nil)
+
+	classifications, err := rulesService.Classify(model.ClassifyDTO{
+		UserId: userId,
+		Transactions: []model.TransactionDescriptor{
+			{Id: "a", Description: "Uber *Trip", Amount: decimal.RequireFromString("-12.5")},
+			{Id: "b", Description: "UBER EATS order", Amount: decimal.RequireFromString("-75")},
+			{Id: "c", Description: "KFC Downtown", Amount: decimal.RequireFromString("-9")},
+			{Id: "d", Description: "rent", Amount: decimal.RequireFromString("-1000")},
+			{Id: "e", Description: "Rent", Amount: decimal.RequireFromString("-1000")},
+		},
+	})
+
+	assert.NoError(t, err)
+	expected := []struct {
+		categoryId, ruleId uint64
+	}{{10, 1}, {20, 2}, {20, 2}, {}, {30, 3}}
+	assert.Len(t, classifications, len(expected))
+	for i, classification := range classifications {
+		if expected[i].ruleId == 0 {
+			assert.Nil(t, classification.CategoryId, classification.Id)
+			assert.Nil(t, classification.RuleId, classification.Id)
+			continue
+		}
+		assert.Equal(t, expected[i].categoryId, *classification.CategoryId, classification.Id)
+		assert.Equal(t, expected[i].ruleId, *classification.RuleId, classification.Id)
+	}
+	assert.Equal(t, "e", classifications[4].Id)
+}
+
+func TestClassify_AmountBoundsExact(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, mockRuleRepository, mockCategoryRepository := newRulesService(ctl)
+
+	mockRuleRepository.
+		EXPECT().
+		GetRulesByUserId(userId).
+		Times(1).
+		Return([]entity.Rule{
+			{
+				Id: 1, UserId: userId, CategoryId: 10, Enabled: true,
+				Condition: entity.RuleCondition{Op: entity.RuleAmount, Min: amount("0.3"), Max: amount("9007199254740993")},
+			},
+		}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return(userCategories(10), nil)
+
+	// float64 rounds these amounts onto the bounds
+	classifications, err := rulesService.Classify(model.ClassifyDTO{
+		UserId: userId,
+		Transactions: []model.TransactionDescriptor{
+			{Id: "min", Description: "fee", Amount: decimal.RequireFromString("-0.3")},
+			{Id: "below min", Description: "fee", Amount: decimal.RequireFromString("0.29999999999999999")},
+			{Id: "below max", Description: "fee", Amount: decimal.RequireFromString("9007199254740992")},
+			{Id: "max", Description: "fee", Amount: decimal.RequireFromString("9007199254740993")},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, classifications, 4)
+	assert.NotNil(t, classifications[0].RuleId, classifications[0].Id)
+	assert.Nil(t, classifications[1].RuleId, classifications[1].Id)
+	assert.NotNil(t, classifications[2].RuleId, classifications[2].Id)
+	assert.Nil(t, classifications[3].RuleId, classifications[3].Id)
+}
+
+func TestClassify_SkipsDisabledRulesAndDeletedCategories(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, mockRuleRepository, mockCategoryRepository := newRulesService(ctl)
+
+	contains := entity.RuleCondition{Op: entity.RuleContains, Value: "coffee"}
+	mockRuleRepository.
+		EXPECT().
+		GetRulesByUserId(userId).
+		Times(1).
+		Return([]entity.Rule{
+			{Id: 1, UserId: userId, CategoryId: 10, Enabled: false, Condition: contains},
+			{Id: 2, UserId: userId, CategoryId: 20, Enabled: true, Condition: contains},
+			{Id: 3, UserId: userId, CategoryId: 30, Enabled: true, Condition: contains},
+		}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		Times(1).
+		Return(userCategories(10, 30), nil)
+
+	classifications, err := rulesService.Classify(model.ClassifyDTO{
+		UserId:       userId,
+		Transactions: []model.TransactionDescriptor{{Description: "Coffee shop"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(30), *classifications[0].CategoryId)
+	assert.Equal(t, uint64(3), *classifications[0].RuleId)
+}
+
+func TestClassify_CompiledRulesCachedUntilChanged(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, mockRuleRepository, mockCategoryRepository := newRulesService(ctl)
+
+	mockRuleRepository.
+		EXPECT().
+		GetRulesByUserId(userId).
+		Times(2).
+		Return([]entity.Rule{}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		AnyTimes().
+		Return(userCategories(10), nil)
+
+	mockRuleRepository.
+		EXPECT().
+		RuleBelongsToUser(uint64(5), userId).
+		Times(1).
+		Return(true)
+
+	mockRuleRepository.
+		EXPECT().
+		DeleteRule(uint64(5)).
+		Times(1).
+		Return(nil)
+
+	classifyDTO := model.ClassifyDTO{UserId: userId, Transactions: []model.TransactionDescriptor{{Description: "x"}}}
+	for i := 0; i < 3; i++ {
+		_, err := rulesService.Classify(classifyDTO)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, rulesService.DeleteRule(model.RuleDeleteDTO{Id: 5, UserId: userId}))
+
+	_, err := rulesService.Classify(classifyDTO)
+	assert.NoError(t, err)
+}
+
+func TestClassify_RulesChangedWhileLoading_NotCached(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, mockRuleRepository, mockCategoryRepository := newRulesService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByUserId(userId).
+		AnyTimes().
+		Return(userCategories(10), nil)
+
+	mockRuleRepository.
+		EXPECT().
+		RuleBelongsToUser(uint64(5), userId).
+		Times(1).
+		Return(true)
+
+	mockRuleRepository.
+		EXPECT().
+		DeleteRule(uint64(5)).
+		Times(1).
+		Return(nil)
+
+	staleRules := []entity.Rule{{Id: 5, UserId: userId, CategoryId: 10, Enabled: true, Condition: entity.RuleCondition{Op: entity.RuleContains, Value: "x"}}}
+	first := mockRuleRepository.
+		EXPECT().
+		GetRulesByUserId(userId).
+		Times(1).
+		DoAndReturn(func(userId uint64) ([]entity.Rule, error) {
+			// the rule is deleted after the rules were read but before they are cached
+			assert.NoError(t, rulesService.DeleteRule(model.RuleDeleteDTO{Id: 5, UserId: userId}))
+			return staleRules, nil
+		})
+	mockRuleRepository.
+		EXPECT().
+		GetRulesByUserId(userId).
+		After(first).
+		Times(1).
+		Return([]entity.Rule{}, nil)
+
+	classifyDTO := model.ClassifyDTO{UserId: userId, Transactions: []model.TransactionDescriptor{{Description: "x"}}}
+	_, err := rulesService.Classify(classifyDTO)
+	assert.NoError(t, err)
+
+	classifications, err := rulesService.Classify(classifyDTO)
+	assert.NoError(t, err)
+	assert.Nil(t, classifications[0].RuleId)
+}
+
+func TestCreateRule_InvalidCondition(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, _, _ := newRulesService(ctl)
+
+	tooMany := entity.RuleCondition{Op: entity.RuleOr}
+	for i := 0; i < rulesConfig.MaxConditions; i++ {
+		tooMany.Conditions = append(tooMany.Conditions, entity.RuleCondition{Op: entity.RuleContains, Value: "a"})
+	}
+
+	conditions := map[string]entity.RuleCondition{
+		"unknown operator":  {Op: "startsWith", Value: "a"},
+		"empty value":       {Op: entity.RuleContains},
+		"invalid regex":     {Op: entity.RuleRegex, Value: "(unclosed"},
+		"empty group":       {Op: entity.RuleAnd},
+		"group with value":  {Op: entity.RuleOr, Value: "a", Conditions: []entity.RuleCondition{{Op: entity.RuleContains, Value: "a"}}},
+		"amount without":    {Op: entity.RuleAmount},
+		"amount reversed":   {Op: entity.RuleAmount, Min: amount("50"), Max: amount("10")},
+		"text with amount":  {Op: entity.RuleEquals, Value: "a", Max: amount("10")},
+		"too many nodes":    tooMany,
+		"invalid in nested": {Op: entity.RuleAnd, Conditions: []entity.RuleCondition{{Op: entity.RulePrefix}}},
+	}
+
+	for name, condition := range conditions {
+		_, err := rulesService.CreateRule(model.RuleCreateDTO{
+			UserId:     userId,
+			Name:       "Rule",
+			CategoryId: 10,
+			Condition:  condition,
+		})
+		assert.ErrorIs(t, err, serviceerror.InvalidRuleCondition, name)
+	}
+}
+
+func TestCreateRule_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, mockRuleRepository, mockCategoryRepository := newRulesService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(10)).
+		Times(1).
+		Return(&entity.Category{Id: 10, UserId: userId}, nil)
+
+	expected := &entity.Rule{
+		UserId:     userId,
+		Name:       "Taxi",
+		Priority:   5,
+		CategoryId: 10,
+		Condition:  entity.RuleCondition{Op: entity.RuleRegex, Value: `taxi|cab`},
+		Enabled:    true,
+	}
+	mockRuleRepository.
+		EXPECT().
+		CreateRule(expected).
+		Times(1).
+		Return(expected, nil)
+
+	rule, err := rulesService.CreateRule(model.RuleCreateDTO{
+		UserId:     userId,
+		Name:       "Taxi",
+		Priority:   5,
+		CategoryId: 10,
+		Condition:  entity.RuleCondition{Op: entity.RuleRegex, Value: `taxi|cab`},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rule)
+}
+
+func TestUpdateRule_CategoryDoesntBelongToUser(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	rulesService, mockRuleRepository, mockCategoryRepository := newRulesService(ctl)
+
+	mockRuleRepository.
+		EXPECT().
+		GetRuleById(uint64(1)).
+		Times(1).
+		Return(&entity.Rule{Id: 1, UserId: userId, CategoryId: 10}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(uint64(20)).
+		Times(1).
+		Return(&entity.Category{Id: 20, UserId: 2}, nil)
+
+	categoryId := uint64(20)
+	_, err := rulesService.UpdateRule(model.RuleUpdateDTO{Id: 1, UserId: userId, CategoryId: &categoryId})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntBelongToUser)
+}
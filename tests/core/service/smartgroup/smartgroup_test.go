@@ -0,0 +1,180 @@
+package smartgroup
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/smartgroup"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+)
+
+const (
+	userId  = uint64(1)
+	groupId = uint64(4)
+	tagId   = uint64(3)
+)
+
+var essentialExpenses = entity.GroupFilter{
+	Op: entity.FilterAnd,
+	Filters: []entity.GroupFilter{
+		{Op: entity.FilterTag, TagId: tagId},
+		{Op: entity.FilterType, Type: entity.Expense},
+	},
+}
+
+func newSmartGroupService(ctl *gomock.Controller) (service.SmartGroupService, *mock.MockTagRepository, *mock.MockCategoryRepository) {
+	mockTagRepository := mock.NewMockTagRepository(ctl)
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+		Tag:      mockTagRepository,
+	}
+	return smartgroup.NewSmartGroupService(mockManager), mockTagRepository, mockCategoryRepository
+}
+
+func TestCreateSmartGroup_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	smartGroupService, mockTagRepository, _ := newSmartGroupService(ctl)
+
+	mockTagRepository.
+		EXPECT().
+		GetTagById(tagId).
+		Times(1).
+		Return(&entity.Tag{Id: tagId, UserId: userId, Name: "essential"}, nil)
+	mockTagRepository.
+		EXPECT().
+		CreateSmartGroup(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(group *entity.SmartGroup) (*entity.SmartGroup, error) {
+			group.Id = groupId
+			return group, nil
+		})
+
+	result, err := smartGroupService.CreateSmartGroup(model.SmartGroupCreateDTO{
+		UserId: userId,
+		Name:   "Essential expenses",
+		Filter: essentialExpenses,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, essentialExpenses, result.Filter)
+}
+
+func TestCreateSmartGroup_InvalidFilter_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	smartGroupService, _, _ := newSmartGroupService(ctl)
+
+	filters := []entity.GroupFilter{
+		{Op: "xor"},
+		{Op: entity.FilterAnd},
+		{Op: entity.FilterNot, Filters: []entity.GroupFilter{{Op: entity.FilterType, Type: entity.Income}, {Op: entity.FilterType, Type: entity.Expense}}},
+		{Op: entity.FilterTag},
+		{Op: entity.FilterType, Type: "SAVINGS"},
+		{Op: entity.FilterType, Type: entity.Expense, TagId: tagId},
+	}
+	for _, filter := range filters {
+		_, err := smartGroupService.CreateSmartGroup(model.SmartGroupCreateDTO{UserId: userId, Name: "Group", Filter: filter})
+		assert.ErrorIs(t, err, serviceerror.InvalidGroupFilter, filter.Op)
+	}
+}
+
+func TestCreateSmartGroup_TooManyNodes_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	smartGroupService, _, _ := newSmartGroupService(ctl)
+
+	filter := entity.GroupFilter{Op: entity.FilterOr}
+	for i := 0; i < 50; i++ {
+		filter.Filters = append(filter.Filters, entity.GroupFilter{Op: entity.FilterType, Type: entity.Expense})
+	}
+
+	_, err := smartGroupService.CreateSmartGroup(model.SmartGroupCreateDTO{UserId: userId, Name: "Group", Filter: filter})
+
+	assert.ErrorIs(t, err, serviceerror.InvalidGroupFilter)
+}
+
+func TestCreateSmartGroup_ForeignTag_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	smartGroupService, mockTagRepository, _ := newSmartGroupService(ctl)
+
+	mockTagRepository.
+		EXPECT().
+		GetTagById(tagId).
+		Times(1).
+		Return(&entity.Tag{Id: tagId, UserId: 2, Name: "essential"}, nil)
+
+	_, err := smartGroupService.CreateSmartGroup(model.SmartGroupCreateDTO{UserId: userId, Name: "Group", Filter: essentialExpenses})
+
+	assert.ErrorIs(t, err, serviceerror.TagDoesntBelongToUser)
+}
+
+func TestCreateSmartGroup_MissingTag_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	smartGroupService, mockTagRepository, _ := newSmartGroupService(ctl)
+
+	mockTagRepository.
+		EXPECT().
+		GetTagById(tagId).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := smartGroupService.CreateSmartGroup(model.SmartGroupCreateDTO{UserId: userId, Name: "Group", Filter: essentialExpenses})
+
+	assert.ErrorIs(t, err, serviceerror.InvalidGroupFilter)
+}
+
+func TestGetGroupCategories_ResolvesFilter(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	smartGroupService, mockTagRepository, mockCategoryRepository := newSmartGroupService(ctl)
+	expectedCategories := []entity.Category{{Id: 10, UserId: userId, Name: "Groceries", Type: entity.Expense}}
+
+	mockTagRepository.
+		EXPECT().
+		GetSmartGroupById(groupId).
+		Times(1).
+		Return(&entity.SmartGroup{Id: groupId, UserId: userId, Name: "Essential expenses", Filter: essentialExpenses}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoriesByFilter(userId, essentialExpenses).
+		Times(1).
+		Return(expectedCategories, nil)
+
+	result, err := smartGroupService.GetGroupCategories(userId, groupId)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCategories, result)
+}
+
+func TestGetGroupCategories_DoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	smartGroupService, mockTagRepository, _ := newSmartGroupService(ctl)
+
+	mockTagRepository.
+		EXPECT().
+		GetSmartGroupById(groupId).
+		Times(1).
+		Return(&entity.SmartGroup{Id: groupId, UserId: 2, Filter: essentialExpenses}, nil)
+
+	_, err := smartGroupService.GetGroupCategories(userId, groupId)
+
+	assert.ErrorIs(t, err, serviceerror.SmartGroupDoesntBelongToUser)
+}
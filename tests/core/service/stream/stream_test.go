@@ -0,0 +1,115 @@
+package stream
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/event"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/changefeed"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/stream"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"math"
+	"testing"
+	"time"
+)
+
+var changeFeedConfig = config.ChangeFeedConfig{
+	Retention: time.Hour,
+	PageSize:  1,
+}
+
+func TestSubscribe_ReceivesOnlyOwnEvents(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockManager := &repository.Manager{Category: mock.NewMockCategoryRepository(ctl)}
+	broadcaster := event.NewInProcessBroadcaster(4)
+	streamService := stream.NewStreamService(broadcaster, changefeed.NewChangeFeedService(mockManager, changeFeedConfig))
+
+	own, unsubscribeOwn := streamService.Subscribe(1)
+	defer unsubscribeOwn()
+	other, unsubscribeOther := streamService.Subscribe(2)
+	defer unsubscribeOther()
+
+	category := &entity.Category{Id: 1, UserId: 1, Name: "Food", ChangeSeq: 7}
+	published := model.NewEvent(model.CategoryCreated, 1, category)
+	published.Sequence = category.ChangeSeq
+	broadcaster.Publish(published)
+
+	select {
+	case received := <-own:
+		assert.Equal(t, published, received)
+		message := streamService.Message(received)
+		assert.Equal(t, model.CategoryCreated, message.Event)
+		assert.NotEmpty(t, message.Id)
+	case <-time.After(time.Second):
+		t.Fatal("event not received")
+	}
+
+	select {
+	case <-other:
+		t.Fatal("event of another user received")
+	default:
+	}
+}
+
+func TestSubscribe_SlowSubscriber_Disconnected(t *testing.T) {
+	broadcaster := event.NewInProcessBroadcaster(1)
+
+	events, unsubscribe := broadcaster.Subscribe(1)
+	defer unsubscribe()
+
+	broadcaster.Publish(model.NewEvent(model.CategoryUpdated, 1, nil))
+	broadcaster.Publish(model.NewEvent(model.CategoryUpdated, 1, nil))
+
+	_, ok := <-events
+	assert.True(t, ok)
+	_, ok = <-events
+	assert.False(t, ok)
+}
+
+func TestReplay_FromEventId_ReturnsMissedChanges(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{Category: mockCategoryRepository}
+	changeFeedService := changefeed.NewChangeFeedService(mockManager, changeFeedConfig)
+	streamService := stream.NewStreamService(event.NewInProcessBroadcaster(4), changeFeedService)
+
+	userId := uint64(1)
+	lastEvent := model.NewEvent(model.CategoryUpdated, userId, nil)
+	lastEvent.Sequence = 3
+	lastEventId := streamService.Message(lastEvent).Id
+
+	missed := []entity.Category{
+		{Id: 1, UserId: userId, Name: "Food", CreatedSeq: 1, ChangeSeq: 4},
+		{Id: 2, UserId: userId, Name: "Rent", CreatedSeq: 5, ChangeSeq: 5},
+	}
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(3), uint64(math.MaxInt64), 2).
+		Times(1).
+		Return([]entity.CategoryChange{{Category: missed[0], FeedSeq: 4}, {Category: missed[1], FeedSeq: 5}}, nil)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetChangedCategories(userId, uint64(4), missed[0].Id, 2).
+		Times(1).
+		Return([]entity.CategoryChange{{Category: missed[1], FeedSeq: 5}}, nil)
+
+	messages, err := streamService.Replay(userId, lastEventId)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	for _, message := range messages {
+		assert.Equal(t, model.CategoryChanges, message.Event)
+		assert.NotEmpty(t, message.Id)
+	}
+	assert.Equal(t, missed[:1], messages[0].Data.(*model.CategoryChangesDTO).Updated)
+	assert.Equal(t, missed[1:], messages[1].Data.(*model.CategoryChangesDTO).Created)
+}
@@ -0,0 +1,151 @@
+package tag
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/tag"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+const (
+	userId     = uint64(1)
+	categoryId = uint64(10)
+	tagId      = uint64(3)
+)
+
+func newTagService(ctl *gomock.Controller) (service.TagService, *mock.MockTagRepository, *mock.MockCategoryRepository) {
+	mockTagRepository := mock.NewMockTagRepository(ctl)
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+		Tag:      mockTagRepository,
+	}
+	return tag.NewTagService(mockManager), mockTagRepository, mockCategoryRepository
+}
+
+func expectTag(repo *mock.MockTagRepository, ownerId uint64) {
+	repo.
+		EXPECT().
+		GetTagById(tagId).
+		Times(1).
+		Return(&entity.Tag{Id: tagId, UserId: ownerId, Name: "essential"}, nil)
+}
+
+func TestCreateTag_TrimsName(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tagService, mockTagRepository, _ := newTagService(ctl)
+
+	mockTagRepository.
+		EXPECT().
+		CreateTag(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(tag *entity.Tag) (*entity.Tag, error) {
+			return tag, nil
+		})
+
+	result, err := tagService.CreateTag(model.TagCreateDTO{UserId: userId, Name: "  essential "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "essential", result.Name)
+	assert.Equal(t, userId, result.UserId)
+}
+
+func TestCreateTag_BlankName_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tagService, _, _ := newTagService(ctl)
+
+	_, err := tagService.CreateTag(model.TagCreateDTO{UserId: userId, Name: "   "})
+
+	assert.ErrorIs(t, err, serviceerror.TagNameRequired)
+}
+
+func TestUpdateTag_DoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tagService, mockTagRepository, _ := newTagService(ctl)
+	expectTag(mockTagRepository, 2)
+
+	_, err := tagService.UpdateTag(model.TagUpdateDTO{Id: tagId, UserId: userId, Name: "kids"})
+
+	assert.ErrorIs(t, err, serviceerror.TagDoesntBelongToUser)
+}
+
+func TestAssignTag_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tagService, mockTagRepository, mockCategoryRepository := newTagService(ctl)
+	expectTag(mockTagRepository, userId)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: userId, Type: entity.Expense}, nil)
+	mockTagRepository.
+		EXPECT().
+		AssignTag(categoryId, tagId).
+		Times(1).
+		Return(nil)
+
+	err := tagService.AssignTag(model.CategoryTagDTO{UserId: userId, CategoryId: categoryId, TagId: tagId})
+
+	assert.NoError(t, err)
+}
+
+func TestAssignTag_HouseholdViewer_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tagService, mockTagRepository, mockCategoryRepository := newTagService(ctl)
+	expectTag(mockTagRepository, userId)
+	householdId := uint64(7)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: 2, HouseholdId: &householdId, Type: entity.Expense}, nil)
+	mockCategoryRepository.
+		EXPECT().
+		UserCanAccessCategory(categoryId, userId, entity.RoleViewer).
+		Times(1).
+		Return(true)
+	mockTagRepository.
+		EXPECT().
+		AssignTag(categoryId, tagId).
+		Times(1).
+		Return(nil)
+
+	err := tagService.AssignTag(model.CategoryTagDTO{UserId: userId, CategoryId: categoryId, TagId: tagId})
+
+	assert.NoError(t, err)
+}
+
+func TestAssignTag_CategoryDoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tagService, _, mockCategoryRepository := newTagService(ctl)
+
+	mockCategoryRepository.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: 2, Type: entity.Expense}, nil)
+
+	err := tagService.AssignTag(model.CategoryTagDTO{UserId: userId, CategoryId: categoryId, TagId: tagId})
+
+	assert.ErrorIs(t, err, serviceerror.CategoryDoesntBelongToUser)
+}
@@ -0,0 +1,187 @@
+package tax
+
+import (
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/tax"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+)
+
+const (
+	userId     = uint64(1)
+	categoryId = uint64(10)
+)
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+func newTaxService(ctl *gomock.Controller) (service.TaxService, *mock.MockTaxRepository, *mock.MockCategoryRepository) {
+	mockTaxRepository := mock.NewMockTaxRepository(ctl)
+	mockCategoryRepository := mock.NewMockCategoryRepository(ctl)
+	mockManager := &repository.Manager{
+		Category: mockCategoryRepository,
+		Tax:      mockTaxRepository,
+	}
+	return tax.NewTaxService(mockManager), mockTaxRepository, mockCategoryRepository
+}
+
+func expectCategory(repo *mock.MockCategoryRepository, categoryType entity.CategoryType) {
+	repo.
+		EXPECT().
+		GetCategoryById(categoryId).
+		Times(1).
+		Return(&entity.Category{Id: categoryId, UserId: userId, Name: "Office", Type: categoryType}, nil)
+}
+
+func saveTax(repo *mock.MockTaxRepository) {
+	repo.
+		EXPECT().
+		SaveTax(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(categoryTax *entity.CategoryTax) (*entity.CategoryTax, error) {
+			return categoryTax, nil
+		})
+}
+
+func TestSetCategoryTax_DeductibleDefaultPercentage(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	taxService, mockTaxRepository, mockCategoryRepository := newTaxService(ctl)
+	expectCategory(mockCategoryRepository, entity.Expense)
+	saveTax(mockTaxRepository)
+
+	categoryTax, err := taxService.SetCategoryTax(model.CategoryTaxDTO{
+		UserId:       userId,
+		CategoryId:   categoryId,
+		Deductible:   true,
+		Jurisdiction: ptr("us"),
+		TaxCode:      ptr("schc-18"),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(100).Equal(categoryTax.DeductiblePercentage))
+	assert.Equal(t, "US", *categoryTax.Jurisdiction)
+	assert.Equal(t, "SCHC-18", *categoryTax.TaxCode)
+}
+
+func TestSetCategoryTax_InvalidAttributes(t *testing.T) {
+	for name, test := range map[string]struct {
+		categoryType entity.CategoryType
+		dto          model.CategoryTaxDTO
+		err          error
+	}{
+		"income deductible": {
+			categoryType: entity.Income,
+			dto:          model.CategoryTaxDTO{Deductible: true},
+			err:          serviceerror.InvalidDeductible,
+		},
+		"percentage over 100": {
+			categoryType: entity.Expense,
+			dto:          model.CategoryTaxDTO{Deductible: true, DeductiblePercentage: ptr(decimal.RequireFromString("150"))},
+			err:          serviceerror.InvalidDeductible,
+		},
+		"percentage of not deductible": {
+			categoryType: entity.Expense,
+			dto:          model.CategoryTaxDTO{DeductiblePercentage: ptr(decimal.RequireFromString("50"))},
+			err:          serviceerror.InvalidDeductible,
+		},
+		"unknown jurisdiction": {
+			categoryType: entity.Expense,
+			dto:          model.CategoryTaxDTO{Jurisdiction: ptr("FR"), TaxCode: ptr("SCHC-18")},
+			err:          serviceerror.InvalidJurisdiction,
+		},
+		"code of another jurisdiction": {
+			categoryType: entity.Expense,
+			dto:          model.CategoryTaxDTO{Jurisdiction: ptr("GB"), TaxCode: ptr("SCHC-18")},
+			err:          serviceerror.InvalidTaxCode,
+		},
+		"income code for expense category": {
+			categoryType: entity.Expense,
+			dto:          model.CategoryTaxDTO{Jurisdiction: ptr("US"), TaxCode: ptr("SCHC-1")},
+			err:          serviceerror.InvalidTaxCode,
+		},
+		"code without jurisdiction": {
+			categoryType: entity.Expense,
+			dto:          model.CategoryTaxDTO{TaxCode: ptr("SCHC-18")},
+			err:          serviceerror.InvalidTaxCode,
+		},
+		"goal category": {
+			categoryType: entity.Goal,
+			dto:          model.CategoryTaxDTO{},
+			err:          serviceerror.TaxAttributesNotAllowed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			taxService, _, mockCategoryRepository := newTaxService(ctl)
+			expectCategory(mockCategoryRepository, test.categoryType)
+
+			test.dto.UserId = userId
+			test.dto.CategoryId = categoryId
+			_, err := taxService.SetCategoryTax(test.dto)
+
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestGetCategoryTax_NeverSet(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	taxService, mockTaxRepository, mockCategoryRepository := newTaxService(ctl)
+	expectCategory(mockCategoryRepository, entity.Expense)
+	mockTaxRepository.EXPECT().GetTaxByCategoryId(categoryId).Times(1).Return(nil, gorm.ErrRecordNotFound)
+
+	categoryTax, err := taxService.GetCategoryTax(userId, categoryId)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.CategoryTax{CategoryId: categoryId, UserId: userId}, categoryTax)
+}
+
+func TestGetTaxSummary_GroupsByCode(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	taxService, mockTaxRepository, mockCategoryRepository := newTaxService(ctl)
+
+	mockCategoryRepository.EXPECT().GetCategoriesByUserId(userId).Times(1).Return([]entity.Category{
+		{Id: 1, UserId: userId, Name: "Office supplies", Type: entity.Expense},
+		{Id: 2, UserId: userId, Name: "Software", Type: entity.Expense},
+		{Id: 3, UserId: userId, Name: "Ads", Type: entity.Expense},
+		{Id: 4, UserId: userId, Name: "Home office", Type: entity.Expense},
+		{Id: 5, UserId: userId, Name: "Client fees", Type: entity.Income},
+	}, nil)
+	full := decimal.NewFromInt(100)
+	mockTaxRepository.EXPECT().GetTaxesByUserId(userId).Times(1).Return([]entity.CategoryTax{
+		{CategoryId: 1, UserId: userId, Deductible: true, DeductiblePercentage: full, Jurisdiction: ptr("US"), TaxCode: ptr("SCHC-18")},
+		{CategoryId: 2, UserId: userId, Deductible: true, DeductiblePercentage: full, Jurisdiction: ptr("US"), TaxCode: ptr("SCHC-18")},
+		{CategoryId: 3, UserId: userId, Deductible: true, DeductiblePercentage: full, Jurisdiction: ptr("GB"), TaxCode: ptr("SA103F-24")},
+		{CategoryId: 4, UserId: userId, Deductible: true, DeductiblePercentage: decimal.NewFromInt(20)},
+		{CategoryId: 5, UserId: userId, Jurisdiction: ptr("US"), TaxCode: ptr("SCHC-1")},
+		{CategoryId: 6, UserId: userId, Deductible: true, DeductiblePercentage: full, Jurisdiction: ptr("US"), TaxCode: ptr("SCHC-8")},
+	}, nil)
+
+	summary, err := taxService.GetTaxSummary(userId, "us")
+
+	assert.NoError(t, err)
+	assert.Len(t, summary, 3)
+	assert.Equal(t, "SCHC-1", summary[0].TaxCode)
+	assert.Equal(t, "SCHC-18", summary[1].TaxCode)
+	assert.Equal(t, "18", summary[1].Line)
+	assert.Len(t, summary[1].Categories, 2)
+	assert.Equal(t, "", summary[2].TaxCode)
+	assert.Equal(t, uint64(4), summary[2].Categories[0].CategoryId)
+}
@@ -0,0 +1,5 @@
+package webhook
+
+func ptr[T any](t T) *T {
+	return &t
+}
@@ -0,0 +1,370 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/khivuksergey/portmonetka.category/config"
+	serviceerror "github.com/khivuksergey/portmonetka.category/error"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/webhook"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const secret = "very-strong-webhook-secret"
+
+var testConfig = config.WebhookConfig{
+	Timeout:              time.Second,
+	MaxAttempts:          3,
+	InitialBackoff:       time.Millisecond,
+	MaxBackoff:           5 * time.Millisecond,
+	DisableAfterFailures: 2,
+	// httptest receivers listen on loopback over http
+	AllowInsecureTargets: true,
+}
+
+var secureConfig = func() config.WebhookConfig {
+	cfg := testConfig
+	cfg.AllowInsecureTargets = false
+	return cfg
+}()
+
+func TestPublish_SignedDelivery_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	var received atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get(webhook.SignatureHeader))
+		assert.Equal(t, string(model.CategoryCreated), r.Header.Get(webhook.EventHeader))
+		assert.NotEmpty(t, r.Header.Get(webhook.DeliveryHeader))
+
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, r.Header.Get(webhook.DeliveryHeader), payload["id"])
+		assert.Equal(t, string(model.CategoryCreated), payload["type"])
+
+		received.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, testConfig)
+
+	hook := entity.Webhook{Id: 1, UserId: 1, Url: receiver.URL, Secret: secret, Enabled: true}
+
+	mockWebhookRepository.
+		EXPECT().
+		GetEnabledWebhooksByUserId(hook.UserId).
+		Times(1).
+		Return([]entity.Webhook{hook}, nil)
+
+	mockWebhookRepository.
+		EXPECT().
+		CreateDelivery(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(delivery *entity.WebhookDelivery) error {
+			assert.True(t, delivery.Success)
+			assert.Equal(t, 1, delivery.Attempt)
+			assert.Equal(t, http.StatusNoContent, delivery.StatusCode)
+			return nil
+		})
+
+	webhookService.Publish(model.NewEvent(model.CategoryCreated, hook.UserId, entity.Category{Id: 1, UserId: 1, Name: "Food"}))
+
+	assert.NoError(t, webhookService.Shutdown())
+	assert.Equal(t, int32(1), received.Load())
+}
+
+func TestPublish_RetriesWithBackoff_Success(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	var received atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if received.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, testConfig)
+
+	hook := entity.Webhook{Id: 1, UserId: 1, Url: receiver.URL, Secret: secret, Enabled: true, ConsecutiveFailures: 1}
+
+	mockWebhookRepository.
+		EXPECT().
+		GetEnabledWebhooksByUserId(hook.UserId).
+		Times(1).
+		Return([]entity.Webhook{hook}, nil)
+
+	var attempts []*entity.WebhookDelivery
+	mockWebhookRepository.
+		EXPECT().
+		CreateDelivery(gomock.Any()).
+		Times(3).
+		DoAndReturn(func(delivery *entity.WebhookDelivery) error {
+			attempts = append(attempts, delivery)
+			return nil
+		})
+
+	var reset atomic.Bool
+	mockWebhookRepository.
+		EXPECT().
+		ResetFailures(hook.Id).
+		Times(1).
+		DoAndReturn(func(id uint64) error {
+			reset.Store(true)
+			return nil
+		})
+
+	webhookService.Publish(model.NewEvent(model.CategoryUpdated, hook.UserId, nil))
+
+	assert.Eventually(t, reset.Load, time.Second, time.Millisecond)
+	assert.NoError(t, webhookService.Shutdown())
+	assert.Equal(t, int32(3), received.Load())
+	assert.Len(t, attempts, 3)
+	assert.False(t, attempts[0].Success)
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.True(t, attempts[2].Success)
+	assert.Equal(t, attempts[0].DeliveryId, attempts[2].DeliveryId)
+}
+
+func TestPublish_AllAttemptsFailed_RegistersFailure(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, testConfig)
+
+	hook := entity.Webhook{Id: 1, UserId: 1, Url: receiver.URL, Secret: secret, Enabled: true}
+
+	mockWebhookRepository.
+		EXPECT().
+		GetEnabledWebhooksByUserId(hook.UserId).
+		Times(1).
+		Return([]entity.Webhook{hook}, nil)
+
+	mockWebhookRepository.
+		EXPECT().
+		CreateDelivery(gomock.Any()).
+		Times(testConfig.MaxAttempts).
+		Return(nil)
+
+	var failed atomic.Bool
+	mockWebhookRepository.
+		EXPECT().
+		RegisterFailure(hook.Id, testConfig.DisableAfterFailures).
+		Times(1).
+		DoAndReturn(func(id uint64, disableAfter int) error {
+			failed.Store(true)
+			return nil
+		})
+
+	webhookService.Publish(model.NewEvent(model.CategoryDeleted, hook.UserId, nil))
+
+	assert.Eventually(t, failed.Load, time.Second, time.Millisecond)
+	assert.NoError(t, webhookService.Shutdown())
+}
+
+func TestPublish_NotSubscribedEvent_Skipped(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected delivery")
+	}))
+	defer receiver.Close()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, testConfig)
+
+	hook := entity.Webhook{
+		Id:      1,
+		UserId:  1,
+		Url:     receiver.URL,
+		Secret:  secret,
+		Events:  []string{string(model.CategoryDeleted)},
+		Enabled: true,
+	}
+
+	mockWebhookRepository.
+		EXPECT().
+		GetEnabledWebhooksByUserId(hook.UserId).
+		Times(1).
+		Return([]entity.Webhook{hook}, nil)
+
+	webhookService.Publish(model.NewEvent(model.CategoryCreated, hook.UserId, nil))
+
+	assert.NoError(t, webhookService.Shutdown())
+}
+
+func TestUpdateWebhook_Enable_ResetsFailures(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, testConfig)
+
+	existingWebhook := &entity.Webhook{Id: 1, UserId: 1, Url: "https://example.com", Enabled: false, ConsecutiveFailures: 2}
+	webhookUpdateDTO := model.WebhookUpdateDTO{Id: 1, UserId: 1, Enabled: ptr(true)}
+
+	mockWebhookRepository.
+		EXPECT().
+		GetWebhookById(webhookUpdateDTO.Id).
+		Times(1).
+		Return(existingWebhook, nil)
+
+	mockWebhookRepository.
+		EXPECT().
+		UpdateWebhook(existingWebhook).
+		Times(1).
+		Return(existingWebhook, nil)
+
+	updatedWebhook, err := webhookService.UpdateWebhook(webhookUpdateDTO)
+
+	assert.NoError(t, err)
+	assert.True(t, updatedWebhook.Enabled)
+	assert.Equal(t, 0, updatedWebhook.ConsecutiveFailures)
+}
+
+func TestDeleteWebhook_WebhookDoesntBelongToUser_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, testConfig)
+
+	webhookDeleteDTO := model.WebhookDeleteDTO{Id: 1, UserId: 2}
+
+	mockWebhookRepository.
+		EXPECT().
+		WebhookBelongsToUser(webhookDeleteDTO.Id, webhookDeleteDTO.UserId).
+		Times(1).
+		Return(false)
+
+	err := webhookService.DeleteWebhook(webhookDeleteDTO)
+
+	assert.Error(t, err)
+	assert.Equal(t, serviceerror.WebhookDoesntBelongToUser, err)
+}
+
+func TestPublish_LoopbackTarget_RefusedOnConnect(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected delivery")
+	}))
+	defer receiver.Close()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, secureConfig)
+
+	// the host name passes validation and resolves to loopback only when connecting
+	hook := entity.Webhook{Id: 1, UserId: 1, Url: strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1), Secret: secret, Enabled: true}
+
+	mockWebhookRepository.
+		EXPECT().
+		GetEnabledWebhooksByUserId(hook.UserId).
+		Times(1).
+		Return([]entity.Webhook{hook}, nil)
+
+	mockWebhookRepository.
+		EXPECT().
+		CreateDelivery(gomock.Any()).
+		Times(secureConfig.MaxAttempts).
+		DoAndReturn(func(delivery *entity.WebhookDelivery) error {
+			assert.False(t, delivery.Success)
+			assert.Zero(t, delivery.StatusCode)
+			assert.Contains(t, delivery.Error, serviceerror.InsecureWebhookUrl.Error())
+			return nil
+		})
+
+	var failed atomic.Bool
+	mockWebhookRepository.
+		EXPECT().
+		RegisterFailure(hook.Id, secureConfig.DisableAfterFailures).
+		Times(1).
+		DoAndReturn(func(id uint64, disableAfter int) error {
+			failed.Store(true)
+			return nil
+		})
+
+	webhookService.Publish(model.NewEvent(model.CategoryCreated, hook.UserId, nil))
+
+	assert.Eventually(t, failed.Load, time.Second, time.Millisecond)
+	assert.NoError(t, webhookService.Shutdown())
+}
+
+func TestCreateWebhook_InsecureUrl_Error(t *testing.T) {
+	urls := []string{
+		"http://example.com/hook",
+		"https://127.0.0.1/hook",
+		"https://10.0.0.5/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hook",
+		"https://0.0.0.0/hook",
+	}
+	for _, url := range urls {
+		t.Run(url, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+			webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, secureConfig)
+
+			createdWebhook, err := webhookService.CreateWebhook(model.WebhookCreateDTO{UserId: 1, Url: url, Secret: secret})
+
+			assert.Nil(t, createdWebhook)
+			assert.Equal(t, serviceerror.InsecureWebhookUrl, err)
+		})
+	}
+}
+
+func TestUpdateWebhook_InsecureUrl_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockWebhookRepository := mock.NewMockWebhookRepository(ctl)
+	webhookService := webhook.NewWebhookService(&repository.Manager{Webhook: mockWebhookRepository}, secureConfig)
+
+	existingWebhook := &entity.Webhook{Id: 1, UserId: 1, Url: "https://example.com", Enabled: true}
+	webhookUpdateDTO := model.WebhookUpdateDTO{Id: 1, UserId: 1, Url: ptr("http://example.com")}
+
+	mockWebhookRepository.
+		EXPECT().
+		GetWebhookById(webhookUpdateDTO.Id).
+		Times(1).
+		Return(existingWebhook, nil)
+
+	updatedWebhook, err := webhookService.UpdateWebhook(webhookUpdateDTO)
+
+	assert.Nil(t, updatedWebhook)
+	assert.Equal(t, serviceerror.InsecureWebhookUrl, err)
+}
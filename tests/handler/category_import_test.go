@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/khivuksergey/portmonetka.category/config"
+	importeradapter "github.com/khivuksergey/portmonetka.category/internal/adapter/importer"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/gorm/repo/mock"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/imports"
+	"github.com/khivuksergey/portmonetka.category/internal/handler"
+	errormiddleware "github.com/khivuksergey/portmonetka.common/middleware/error"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var importConfig = config.ImportConfig{MaxRows: 100, MaxBytes: 64}
+
+func newImportServer(ctl *gomock.Controller) *echo.Echo {
+	services := &service.Manager{
+		Import: imports.NewImportService(
+			&repository.Manager{Category: mock.NewMockCategoryRepository(ctl)},
+			nil,
+			importeradapter.Default(),
+			importConfig,
+		),
+	}
+	categoryHandler := handler.NewCategoryHandler(services, config.CategoryConfig{}, importConfig, logger.NewConsoleLogger())
+
+	e := echo.New()
+	e.Use(errormiddleware.NewErrorHandlingMiddleware().HandleError)
+	e.POST("/import", categoryHandler.ImportCategories, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("userId", uint64(1))
+			return next(c)
+		}
+	})
+	return e
+}
+
+// largeFile is a valid CSV file longer than the configured limit.
+var largeFile = "name,type\n" + strings.Repeat("Groceries,EXPENSE\n", 10)
+
+func TestImportCategories_BodyTooLarge_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	req := httptest.NewRequest(http.MethodPost, "/import?format=csv", strings.NewReader(largeFile))
+	req.Header.Set(echo.HeaderContentType, "text/csv")
+	rec := httptest.NewRecorder()
+	newImportServer(ctl).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestImportCategories_MultipartTooLarge_Error(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "categories.csv")
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(largeFile))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/import", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	newImportServer(ctl).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"github.com/khivuksergey/portmonetka.category/config"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/memory"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/repository"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	"github.com/khivuksergey/portmonetka.category/internal/core/service/idempotency"
+	"github.com/khivuksergey/portmonetka.category/internal/handler"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	errormiddleware "github.com/khivuksergey/portmonetka.common/middleware/error"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var idempotencyConfig = config.IdempotencyConfig{MaxBodySize: 16}
+
+func newIdempotentServer() *echo.Echo {
+	services := &service.Manager{
+		Idempotency: idempotency.NewIdempotencyService(
+			&repository.Manager{Idempotency: memory.NewIdempotencyRepository()},
+			idempotencyConfig,
+		),
+	}
+	middleware := handler.NewIdempotencyMiddleware(services, idempotencyConfig, logger.NewConsoleLogger())
+
+	e := echo.New()
+	e.Use(errormiddleware.NewErrorHandlingMiddleware().HandleError)
+	e.POST("/categories", func(c echo.Context) error {
+		return c.JSON(http.StatusCreated, model.Response{Message: "Category created"})
+	}, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("userId", uint64(1))
+			return next(c)
+		}
+	}, middleware.HandleIdempotencyKey)
+	return e
+}
+
+func post(e *echo.Echo, body string) *httptest.ResponseRecorder {
+	return postWith(e, "/categories", echo.MIMEApplicationJSON, body)
+}
+
+func postWith(e *echo.Echo, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleIdempotencyKey_BodyWithinLimit_Replayed(t *testing.T) {
+	e := newIdempotentServer()
+
+	first := post(e, `{"name":"Food"}`)
+	second := post(e, `{"name":"Food"}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+}
+
+func TestHandleIdempotencyKey_BodyTooLarge_Error(t *testing.T) {
+	rec := post(newIdempotentServer(), `{"name":"Groceries and household"}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestHandleIdempotencyKey_SameQueryReordered_Replayed(t *testing.T) {
+	e := newIdempotentServer()
+
+	first := postWith(e, "/categories?dryRun=true&conflict=skip", echo.MIMEApplicationJSON, `{"name":"Food"}`)
+	second := postWith(e, "/categories?conflict=skip&dryRun=true", "application/json; charset=UTF-8", `{"name":"Food"}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+}
+
+func TestHandleIdempotencyKey_DifferentQuery_Error(t *testing.T) {
+	e := newIdempotentServer()
+
+	first := postWith(e, "/categories?dryRun=true", echo.MIMEApplicationJSON, `{"name":"Food"}`)
+	second := postWith(e, "/categories?dryRun=false", echo.MIMEApplicationJSON, `{"name":"Food"}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, second.Code)
+	assert.Empty(t, second.Header().Get("Idempotent-Replayed"))
+}
+
+func TestHandleIdempotencyKey_DifferentContentType_Error(t *testing.T) {
+	e := newIdempotentServer()
+
+	first := postWith(e, "/categories", "application/merge-patch+json", `{"name":"Food"}`)
+	second := postWith(e, "/categories", "application/json-patch+json", `{"name":"Food"}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, second.Code)
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/khivuksergey/portmonetka.category/internal/adapter/storage/entity"
+	"github.com/khivuksergey/portmonetka.category/internal/core/port/service"
+	internalhttp "github.com/khivuksergey/portmonetka.category/internal/http"
+	"github.com/khivuksergey/portmonetka.category/internal/model"
+	"github.com/khivuksergey/portmonetka.common"
+	"github.com/khivuksergey/portmonetka.common/middleware/authentication"
+	errormiddleware "github.com/khivuksergey/portmonetka.common/middleware/error"
+	"github.com/khivuksergey/webserver/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const jwtSecret = "secret"
+
+// auditRecorder keeps recorded entries, it fails every Record when err is set.
+type auditRecorder struct {
+	entries []entity.AuditEntry
+	err     error
+}
+
+func (r *auditRecorder) Record(entry *entity.AuditEntry) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *auditRecorder) Verify(_, _ time.Time) (*model.AuditVerification, error) {
+	return nil, nil
+}
+
+func (r *auditRecorder) Export(_, _ time.Time, _ io.Writer) error {
+	return nil
+}
+
+func newAuditedServer(recorder *auditRecorder) *echo.Echo {
+	audit := internalhttp.NewAuditMiddleware(&service.Manager{Audit: recorder}, logger.NewConsoleLogger())
+	auth := authentication.NewAuthenticationMiddleware(jwtSecret, logger.NewConsoleLogger())
+
+	e := echo.New()
+	e.Use(errormiddleware.NewErrorHandlingMiddleware().HandleError, audit.RecordAudit)
+	users := e.Group("users/:userId", auth.AuthenticateJWT)
+	users.GET("/categories", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, model.Response{Message: "Categories"})
+	})
+	users.POST("/categories", func(c echo.Context) error {
+		return c.JSON(http.StatusCreated, model.Response{Message: "Category created"})
+	})
+	users.POST("/invalid", func(c echo.Context) error {
+		return common.NewValidationError("invalid input data", errors.New("name is required"))
+	})
+	users.POST("/conflict", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusConflict, "conflict")
+	})
+	return e
+}
+
+func request(t *testing.T, e *echo.Echo, method, path string, subject uint64) *httptest.ResponseRecorder {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": subject}).SignedString([]byte(jwtSecret))
+	assert.NoError(t, err)
+	req := httptest.NewRequest(method, path, strings.NewReader(`{}`))
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRecordAudit_Statuses(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		subject uint64
+		status  int
+	}{
+		{name: "success", path: "/users/1/categories", subject: 1, status: http.StatusCreated},
+		{name: "validation error", path: "/users/1/invalid", subject: 1, status: http.StatusBadRequest},
+		{name: "HTTP error", path: "/users/1/conflict", subject: 1, status: http.StatusConflict},
+		{name: "authentication failure", path: "/users/1/categories", subject: 2, status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := &auditRecorder{}
+			rec := request(t, newAuditedServer(recorder), http.MethodPost, test.path, test.subject)
+
+			assert.Equal(t, test.status, rec.Code)
+			assert.Len(t, recorder.entries, 1)
+			entry := recorder.entries[0]
+			assert.Equal(t, test.status, entry.Status)
+			assert.Equal(t, http.MethodPost, entry.Method)
+			assert.Equal(t, test.path, entry.Path)
+			assert.NotEmpty(t, entry.RequestUuid)
+			if assert.NotNil(t, entry.UserId) {
+				// the subject is recorded for refused tokens too
+				assert.Equal(t, test.subject, *entry.UserId)
+			}
+		})
+	}
+}
+
+func TestRecordAudit_SuccessBodyKept(t *testing.T) {
+	recorder := &auditRecorder{}
+	rec := request(t, newAuditedServer(recorder), http.MethodPost, "/users/1/categories", 1)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Category created")
+	assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "/users/:userId/categories", recorder.entries[0].Route)
+}
+
+func TestRecordAudit_InvalidToken_NoUser(t *testing.T) {
+	recorder := &auditRecorder{}
+	req := httptest.NewRequest(http.MethodPost, "/users/1/categories", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer forged")
+	rec := httptest.NewRecorder()
+	newAuditedServer(recorder).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Len(t, recorder.entries, 1)
+	assert.Equal(t, http.StatusUnauthorized, recorder.entries[0].Status)
+	assert.Nil(t, recorder.entries[0].UserId)
+}
+
+func TestRecordAudit_RecordFailed_ResponseKept(t *testing.T) {
+	recorder := &auditRecorder{err: errors.New("connection lost")}
+	rec := request(t, newAuditedServer(recorder), http.MethodPost, "/users/1/categories", 1)
+
+	// the change is stored, a retry after a failure would repeat it
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Category created")
+}
+
+func TestRecordAudit_RecordFailed_ErrorKept(t *testing.T) {
+	recorder := &auditRecorder{err: errors.New("connection lost")}
+	rec := request(t, newAuditedServer(recorder), http.MethodPost, "/users/1/conflict", 1)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestRecordAudit_SafeMethod_NotRecorded(t *testing.T) {
+	recorder := &auditRecorder{}
+	rec := request(t, newAuditedServer(recorder), http.MethodGet, "/users/1/categories", 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, recorder.entries)
+}